@@ -0,0 +1,253 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/gookit/color"
+	"github.com/ignite/cli/v28/ignite/pkg/cliui"
+	"github.com/ignite/cli/v28/ignite/pkg/cosmosaccount"
+	"github.com/ignite/cli/v28/ignite/pkg/cosmosclient"
+	"github.com/ignite/cli/v28/ignite/pkg/errors"
+	"github.com/ignite/cli/v28/ignite/services/plugin"
+
+	"github.com/ignite/apps/hermes/pkg/hermes"
+)
+
+// defaultTopUpMultiplier is the default funding key top-up amount in thresholds.
+const defaultTopUpMultiplier = 10
+
+// balanceWatch represents the balance watch settings of a chain.
+type balanceWatch struct {
+	chain      hermes.Chain
+	address    string
+	threshold  sdk.Coin
+	faucet     string
+	fundingKey string
+	topUp      sdk.Coins
+}
+
+func BalanceHandler(ctx context.Context, cmd *plugin.ExecutedCommand) error {
+	var (
+		flags             = plugin.Flags(cmd.Flags)
+		interval, _       = flags.GetString(flagInterval)
+		thresholds, _     = flags.GetStringSlice(flagThreshold)
+		faucets, _        = flags.GetStringSlice(flagFaucet)
+		fundingKeys, _    = flags.GetStringSlice(flagFundingKey)
+		topUps, _         = flags.GetStringSlice(flagTopUp)
+		keyringBackend, _ = flags.GetString(flagKeyringBackend)
+		keyringDir, _     = flags.GetString(flagKeyringDir)
+	)
+
+	refresh, err := time.ParseDuration(interval)
+	if err != nil {
+		return errors.Wrapf(err, "invalid check interval %s", interval)
+	}
+
+	if len(cmd.Args) == 0 && getConfig(flags) == "" {
+		return errors.Errorf("the chain IDs or the --%s flag are required", flagConfig)
+	}
+	cfgPath, err := getConfigPath(flags, cmd.Args...)
+	if err != nil {
+		return err
+	}
+	hermesCfg, err := hermes.LoadConfig(cfgPath)
+	if err != nil {
+		return err
+	}
+
+	session := cliui.New(cliui.StartSpinnerWithText("Loading the relayer wallets"))
+	defer session.End()
+
+	h, err := newHermes(cmd.Flags)
+	if err != nil {
+		return err
+	}
+	defer h.Cleanup()
+
+	watches, err := balanceWatches(ctx, h, hermesCfg, cfgPath, thresholds, faucets, fundingKeys, topUps)
+	if err != nil {
+		return err
+	}
+
+	fundOptions := []cosmosclient.Option{cosmosclient.WithKeyringBackend(cosmosaccount.KeyringBackend(keyringBackend))}
+	if keyringDir != "" {
+		fundOptions = append(fundOptions, cosmosclient.WithKeyringDir(keyringDir))
+	}
+
+	if refresh == 0 {
+		return checkBalances(ctx, session, watches, fundOptions)
+	}
+
+	ctx, cancel := signal.NotifyContext(ctx, os.Interrupt)
+	defer cancel()
+	for {
+		if err := checkBalances(ctx, session, watches, fundOptions); err != nil {
+			_ = session.Println(color.Red.Sprint(err))
+		}
+		_ = session.Printf("Next check in %s (press Ctrl+C to exit)\n\n", refresh)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(refresh):
+		}
+	}
+}
+
+// checkBalances checks the relayer wallets balance and tops up the low balances from
+// the faucet or the funding key. The failed checks are returned as one error.
+func checkBalances(ctx context.Context, session *cliui.Session, watches []balanceWatch, fundOptions []cosmosclient.Option) error {
+	var (
+		entries = make([][]string, 0, len(watches))
+		failed  = make([]string, 0)
+	)
+	for _, w := range watches {
+		session.StartSpinner(fmt.Sprintf("Checking %s balance", w.chain.ID))
+		status, err := w.chain.CheckBalance(ctx, w.address, w.threshold)
+		if err != nil {
+			failed = append(failed, w.chain.ID)
+			entries = append(entries, []string{w.chain.ID, w.address, "-", w.threshold.String(), color.Red.Sprint(err)})
+			continue
+		}
+
+		state := color.Green.Sprint("ok")
+		if status.Low() {
+			state = color.Yellow.Sprint("low")
+			switch {
+			case w.faucet != "":
+				session.StartSpinner(fmt.Sprintf("Requesting %s funds from the faucet", w.chain.ID))
+				status.Balance, err = w.chain.TryRetrieve(ctx, w.address, w.faucet)
+			case w.fundingKey != "":
+				session.StartSpinner(fmt.Sprintf("Sending %s funds from the key %s", w.chain.ID, w.fundingKey))
+				status.Balance, err = w.chain.Fund(ctx, w.fundingKey, w.address, w.topUp, fundOptions...)
+			}
+			switch {
+			case err != nil:
+				failed = append(failed, w.chain.ID)
+				state = color.Red.Sprintf("low, top-up failed: %s", err)
+			case w.faucet != "" || w.fundingKey != "":
+				state = color.Green.Sprint("topped up")
+				if status.Low() {
+					state = color.Yellow.Sprint("topped up, still low")
+				}
+			}
+		}
+		entries = append(entries, []string{w.chain.ID, w.address, status.Balance.String(), w.threshold.String(), state})
+	}
+
+	session.StopSpinner()
+	_ = session.Printf("Relayer wallets at %s\n", time.Now().Format(time.TimeOnly))
+	if err := session.PrintTable([]string{"Chain", "Address", "Balance", "Threshold", "Status"}, entries...); err != nil {
+		return err
+	}
+	if len(failed) > 0 {
+		return errors.Errorf("failed to check or top up the balance of %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// balanceWatches returns the balance watch settings of the config chains from the flag values.
+func balanceWatches(
+	ctx context.Context,
+	h *hermes.Hermes,
+	cfg *hermes.Config,
+	cfgPath string,
+	thresholds,
+	faucets,
+	fundingKeys,
+	topUps []string,
+) ([]balanceWatch, error) {
+	chainThresholds, err := parseChainValues(cfg, flagThreshold, thresholds)
+	if err != nil {
+		return nil, err
+	}
+	chainFaucets, err := parseChainValues(cfg, flagFaucet, faucets)
+	if err != nil {
+		return nil, err
+	}
+	chainFundingKeys, err := parseChainValues(cfg, flagFundingKey, fundingKeys)
+	if err != nil {
+		return nil, err
+	}
+	chainTopUps, err := parseChainValues(cfg, flagTopUp, topUps)
+	if err != nil {
+		return nil, err
+	}
+
+	watches := make([]balanceWatch, 0, len(cfg.Chains))
+	for _, chain := range cfg.Chains {
+		address, err := relayerAddress(ctx, h, chain.ID, cfgPath)
+		if err != nil {
+			return nil, err
+		}
+
+		w := balanceWatch{
+			chain:      chain,
+			address:    address,
+			threshold:  chain.DefaultBalanceThreshold(),
+			faucet:     chainFaucets[chain.ID],
+			fundingKey: chainFundingKeys[chain.ID],
+		}
+		if threshold, ok := chainThresholds[chain.ID]; ok {
+			if w.threshold, err = sdk.ParseCoinNormalized(threshold); err != nil {
+				return nil, errors.Wrapf(err, "invalid %s threshold %s", chain.ID, threshold)
+			}
+		}
+		if topUp, ok := chainTopUps[chain.ID]; ok {
+			if w.topUp, err = sdk.ParseCoinsNormalized(topUp); err != nil {
+				return nil, errors.Wrapf(err, "invalid %s top-up amount %s", chain.ID, topUp)
+			}
+		} else {
+			w.topUp = sdk.NewCoins(sdk.NewCoin(w.threshold.Denom, w.threshold.Amount.MulRaw(defaultTopUpMultiplier)))
+		}
+		watches = append(watches, w)
+	}
+	return watches, nil
+}
+
+// parseChainValues parses the flag values in the format <chain-id>=<value> by chain ID.
+func parseChainValues(cfg *hermes.Config, flag string, values []string) (map[string]string, error) {
+	chainValues := make(map[string]string)
+	for _, v := range values {
+		chainID, value, ok := strings.Cut(v, "=")
+		if !ok || chainID == "" || value == "" {
+			return nil, errors.Errorf("invalid --%s value %s, expected <chain-id>=<value>", flag, v)
+		}
+		if !cfg.HasChains(chainID) {
+			return nil, errors.Errorf("invalid --%s value %s: chain %s not found in the config", flag, v, chainID)
+		}
+		chainValues[chainID] = value
+	}
+	return chainValues, nil
+}
+
+// relayerAddress returns the address of the chain relayer key.
+func relayerAddress(ctx context.Context, h *hermes.Hermes, chainID, cfgPath string) (string, error) {
+	var (
+		buf    bytes.Buffer
+		result hermes.KeysListResult
+	)
+	if err := h.KeysList(
+		ctx,
+		chainID,
+		hermes.WithConfigFile(cfgPath),
+		hermes.WithStdOut(&buf),
+		hermes.WithJSONOutput(),
+	); err != nil {
+		return "", err
+	}
+	if err := hermes.UnmarshalResult(buf.Bytes(), &result); err != nil {
+		return "", err
+	}
+	if result.Wallet.Account == "" {
+		return "", errors.Errorf("chain %s doesn't have a Hermes relayer key", chainID)
+	}
+	return result.Wallet.Account, nil
+}
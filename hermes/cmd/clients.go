@@ -0,0 +1,208 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/gookit/color"
+	"github.com/ignite/cli/v28/ignite/pkg/cliui"
+	"github.com/ignite/cli/v28/ignite/pkg/errors"
+	"github.com/ignite/cli/v28/ignite/services/plugin"
+
+	"github.com/ignite/apps/hermes/pkg/hermes"
+)
+
+// clientStatus represents the client status JSON output.
+type clientStatus struct {
+	HostChain           string    `json:"host_chain"`
+	ClientID            string    `json:"client_id"`
+	ReferenceChain      string    `json:"reference_chain"`
+	Status              string    `json:"status"`
+	TrustingPeriod      string    `json:"trusting_period"`
+	LastUpdateHeight    string    `json:"last_update_height"`
+	LastUpdateTime      time.Time `json:"last_update_time"`
+	ExpiresAt           time.Time `json:"expires_at"`
+	TimeToExpirySeconds int64     `json:"time_to_expiry_seconds"`
+	NearExpiry          bool      `json:"near_expiry"`
+	Updated             bool      `json:"updated"`
+	UpdateError         string    `json:"update_error,omitempty"`
+}
+
+func ClientsStatusHandler(ctx context.Context, cmd *plugin.ExecutedCommand) error {
+	var (
+		flags              = plugin.Flags(cmd.Flags)
+		jsonOutput, _      = flags.GetBool(flagJSON)
+		update, _          = flags.GetBool(flagUpdate)
+		expiryThreshold, _ = flags.GetString(flagExpiryThreshold)
+	)
+
+	var threshold time.Duration
+	if expiryThreshold != "" {
+		var err error
+		if threshold, err = time.ParseDuration(expiryThreshold); err != nil {
+			return errors.Wrapf(err, "invalid expiry threshold %s", expiryThreshold)
+		}
+	}
+
+	if len(cmd.Args) == 0 && getConfig(flags) == "" {
+		return errors.Errorf("the chain IDs or the --%s flag are required", flagConfig)
+	}
+	cfgPath, err := getConfigPath(flags, cmd.Args...)
+	if err != nil {
+		return err
+	}
+	hermesCfg, err := hermes.LoadConfig(cfgPath)
+	if err != nil {
+		return err
+	}
+
+	var session *cliui.Session
+	if jsonOutput {
+		session = cliui.New(cliui.WithStdout(os.Stderr))
+	} else {
+		session = cliui.New(cliui.StartSpinnerWithText("Querying clients"))
+	}
+	defer session.End()
+
+	h, err := newHermes(cmd.Flags)
+	if err != nil {
+		return err
+	}
+	defer h.Cleanup()
+
+	var (
+		now        = time.Now()
+		statuses   = make([]clientStatus, 0)
+		updateErrs = make([]error, 0)
+	)
+	for _, chain := range hermesCfg.Chains {
+		session.StartSpinner(fmt.Sprintf("Querying %s clients", chain.ID))
+		clients, err := h.Clients(ctx, chain.ID, "", hermes.WithConfigFile(cfgPath))
+		if err != nil {
+			return errors.Wrapf(err, "failed to query the %s clients", chain.ID)
+		}
+
+		for _, client := range clients {
+			// only clients tracking the config chains are relayed.
+			if !hermesCfg.HasChains(client.ChainID) {
+				continue
+			}
+
+			session.StartSpinner(fmt.Sprintf("Querying %s client %s", chain.ID, client.ClientID))
+			health, err := h.ClientHealth(ctx, chain.ID, client.ClientID, hermes.WithConfigFile(cfgPath))
+			if err != nil {
+				return errors.Wrapf(err, "failed to query the %s client %s", chain.ID, client.ClientID)
+			}
+
+			status := clientStatus{
+				HostChain:           health.HostChain,
+				ClientID:            health.ClientID,
+				ReferenceChain:      health.ReferenceChain,
+				Status:              health.Status,
+				TrustingPeriod:      formatDuration(health.TrustingPeriod),
+				LastUpdateHeight:    fmt.Sprintf("%d-%d", health.LatestHeight.RevisionNumber, health.LatestHeight.RevisionHeight),
+				LastUpdateTime:      health.LastUpdate,
+				ExpiresAt:           health.ExpiresAt(),
+				TimeToExpirySeconds: int64(health.TimeToExpiry(now).Seconds()),
+				NearExpiry:          health.NearExpiry(now, threshold),
+			}
+
+			// expired and frozen clients cannot be updated anymore.
+			if update && status.NearExpiry && health.Status == hermes.ClientStatusActive {
+				session.StartSpinner(fmt.Sprintf("Updating %s client %s", chain.ID, client.ClientID))
+				if err := h.UpdateClient(
+					ctx,
+					chain.ID,
+					client.ClientID,
+					hermes.WithConfigFile(cfgPath),
+					hermes.WithStdOut(io.Discard),
+					hermes.WithJSONOutput(),
+				); err != nil {
+					status.UpdateError = err.Error()
+					updateErrs = append(updateErrs, errors.Wrapf(err, "failed to update the %s client %s", chain.ID, client.ClientID))
+				} else {
+					status.Updated = true
+				}
+			}
+			statuses = append(statuses, status)
+		}
+	}
+
+	session.StopSpinner()
+	if jsonOutput {
+		out, err := json.MarshalIndent(statuses, "", "  ")
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintln(os.Stdout, string(out)); err != nil {
+			return err
+		}
+		// the update failures are reported after the statuses, so the command fails for scripts.
+		return errors.Join(updateErrs...)
+	}
+
+	if len(statuses) == 0 {
+		_ = session.Println("No clients found between the config chains")
+		return nil
+	}
+	entries := make([][]string, 0, len(statuses))
+	for _, status := range statuses {
+		entries = append(entries, clientStatusRow(status))
+	}
+	if err := session.PrintTable(
+		[]string{"Host chain", "Client", "Reference chain", "Status", "Trusting period", "Last update", "Expires in"},
+		entries...,
+	); err != nil {
+		return err
+	}
+	return errors.Join(updateErrs...)
+}
+
+// clientStatusRow returns the client status table row, highlighting the clients close to expiry.
+func clientStatusRow(status clientStatus) []string {
+	expiresIn := formatDuration(time.Duration(status.TimeToExpirySeconds) * time.Second)
+	switch {
+	case status.Status != hermes.ClientStatusActive:
+		expiresIn = color.Red.Sprint(status.Status)
+	case status.Updated:
+		expiresIn = color.Green.Sprintf("%s (updated)", expiresIn)
+	case status.UpdateError != "":
+		expiresIn = color.Red.Sprintf("%s (update failed: %s)", expiresIn, status.UpdateError)
+	case status.NearExpiry:
+		expiresIn = color.Yellow.Sprintf("%s (near expiry)", expiresIn)
+	}
+	return []string{
+		status.HostChain,
+		status.ClientID,
+		status.ReferenceChain,
+		status.Status,
+		status.TrustingPeriod,
+		fmt.Sprintf("%s (%s)", status.LastUpdateHeight, status.LastUpdateTime.UTC().Format(time.RFC3339)),
+		expiresIn,
+	}
+}
+
+// formatDuration formats a duration in days, hours and minutes.
+func formatDuration(d time.Duration) string {
+	sign := ""
+	if d < 0 {
+		sign, d = "-", -d
+	}
+	var (
+		days    = d / (24 * time.Hour)
+		hours   = (d % (24 * time.Hour)) / time.Hour
+		minutes = (d % time.Hour) / time.Minute
+	)
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%s%dd%dh", sign, days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%s%dh%dm", sign, hours, minutes)
+	default:
+		return fmt.Sprintf("%s%dm", sign, minutes)
+	}
+}
@@ -1,7 +1,10 @@
 package cmd
 
 import (
+	"github.com/ignite/cli/v28/ignite/pkg/cosmosaccount"
 	"github.com/ignite/cli/v28/ignite/services/plugin"
+
+	"github.com/ignite/apps/hermes/pkg/hermes"
 )
 
 // GetCommands returns the list of app commands.
@@ -24,6 +27,18 @@ func GetCommands() []*plugin.Command {
 							Persistent: true,
 							Type:       plugin.FlagTypeString,
 						},
+						{
+							Name:       flagHermesVersion,
+							Usage:      "Hermes version to download and cache (default: the embedded Hermes version)",
+							Persistent: true,
+							Type:       plugin.FlagTypeString,
+						},
+						{
+							Name:       flagHermesPath,
+							Usage:      "use a system installed Hermes binary by path or name in the PATH",
+							Persistent: true,
+							Type:       plugin.FlagTypeString,
+						},
 					},
 					Commands: []*plugin.Command{
 						{
@@ -31,8 +46,179 @@ func GetCommands() []*plugin.Command {
 							Short: "Execute a hermes raw command",
 						},
 						{
-							Use:   "start [chain-a-id] [chain-a-rpc]",
+							Use:   "start [chain-a-id] [chain-b-id] [chain-n-id...]",
 							Short: "Start the Hermes relayer",
+							Long: "Start the Hermes relayer in the foreground or, with the --detach flag, as a background " +
+								"process restarted on crash and managed by the stop, status and logs commands.",
+							Flags: plugin.Flags{
+								{Name: flagDetach, Shorthand: "d", DefaultValue: "false", Usage: "run the relayer as a supervised background process", Type: plugin.FlagTypeBool},
+							},
+						},
+						{
+							Use:   "metrics [chain-a-id] [chain-b-id] [chain-n-id...]",
+							Short: "Show the Hermes relayer metrics dashboard",
+							Long: "Show a live dashboard of the wallet balances, msgs submitted and txs failed, packet relay " +
+								"latency and backlog of each chain, scraped from the Hermes telemetry endpoint and REST API.",
+							Flags: plugin.Flags{
+								{Name: flagInterval, DefaultValue: "5s", Usage: "dashboard refresh interval, zero prints the metrics once", Type: plugin.FlagTypeString},
+							},
+						},
+						{
+							Use:   "balance [chain-a-id] [chain-b-id] [chain-n-id...]",
+							Short: "Watch the relayer wallets balance",
+							Long: "Periodically check the relayer wallet balance of each chain, warn when it is below the " +
+								"threshold and top it up from the chain faucet or a funding key on testnets. The default " +
+								"threshold pays the fees of ten txs at the chain max gas and gas price.",
+							Flags: plugin.Flags{
+								{Name: flagInterval, DefaultValue: "1m", Usage: "balance check interval, zero checks the balances once", Type: plugin.FlagTypeString},
+								{Name: flagThreshold, Usage: "minimum balance by chain in the format <chain-id>=<coin>", Type: plugin.FlagTypeStringSlice},
+								{Name: flagFaucet, Usage: "faucet address to top up the balance by chain in the format <chain-id>=<url>", Type: plugin.FlagTypeStringSlice},
+								{Name: flagFundingKey, Usage: "keyring key to top up the balance by chain in the format <chain-id>=<key-name>", Type: plugin.FlagTypeStringSlice},
+								{Name: flagTopUp, Usage: "amount sent from the funding key by chain in the format <chain-id>=<coins> (default ten times the threshold)", Type: plugin.FlagTypeStringSlice},
+								{Name: flagKeyringBackend, DefaultValue: string(cosmosaccount.KeyringTest), Usage: "funding key keyring backend", Type: plugin.FlagTypeString},
+								{Name: flagKeyringDir, Usage: "funding key keyring directory (default the chain home)", Type: plugin.FlagTypeString},
+							},
+						},
+						{
+							Use:   "stop [chain-a-id] [chain-b-id] [chain-n-id...]",
+							Short: "Stop the Hermes relayer background process",
+						},
+						{
+							Use:   "status [chain-a-id] [chain-b-id] [chain-n-id...]",
+							Short: "Show the Hermes relayer background process status",
+						},
+						{
+							Use:   "logs [chain-a-id] [chain-b-id] [chain-n-id...]",
+							Short: "Show the Hermes relayer background process logs",
+							Flags: plugin.Flags{
+								{Name: flagFollow, Shorthand: "f", DefaultValue: "false", Usage: "follow the log output", Type: plugin.FlagTypeBool},
+								{Name: flagLines, Shorthand: "n", DefaultValue: "100", Usage: "number of last log lines to show", Type: plugin.FlagTypeUint64},
+							},
+						},
+						{
+							Use:   "packets [command]",
+							Short: "Inspect and clear the pending packets of a channel",
+							Commands: []*plugin.Command{
+								{
+									Use:   "pending [chain-id] [port-id] [channel-id]",
+									Short: "Show the unreceived packets and acknowledgments on both ends of a channel",
+								},
+								{
+									Use:   "clear [chain-id] [port-id] [channel-id]",
+									Short: "Relay the pending packets and acknowledgments on both ends of a channel",
+								},
+							},
+						},
+						{
+							Use:   "clients [command]",
+							Short: "Monitor the Hermes relayer clients",
+							Commands: []*plugin.Command{
+								{
+									Use:   "status [chain-a-id] [chain-b-id] [chain-n-id...]",
+									Short: "Show the trusting period, last update and time to expiry of the config clients",
+									Long: "List every client hosted by the config chains and tracking another config chain with " +
+										"its trusting period, last update height and time and time to expiry. The clients close " +
+										"to expiry are flagged and can be updated with the --update flag.",
+									Flags: plugin.Flags{
+										{Name: flagExpiryThreshold, Usage: "flag the clients expiring within this duration (default: a third of the trusting period)", Type: plugin.FlagTypeString},
+										{Name: flagUpdate, DefaultValue: "false", Usage: "update the clients close to expiry", Type: plugin.FlagTypeBool},
+										{Name: flagJSON, DefaultValue: "false", Usage: "print the clients status as JSON", Type: plugin.FlagTypeBool},
+									},
+								},
+							},
+						},
+						{
+							Use:   "config [command]",
+							Short: "Manage the Hermes relayer configs",
+							Commands: []*plugin.Command{
+								{
+									Use:   "validate [chain-a-id] [chain-b-id] [chain-n-id...]",
+									Short: "Validate the Hermes config and probe the chain endpoints",
+									Long: "Run the schema checks of the Hermes config, like the gas price, trust threshold and " +
+										"durations, then probe the RPC, gRPC and event source endpoints of each chain, checking " +
+										"the account prefix and the trusting period against the chain unbonding period.",
+									Flags: plugin.Flags{
+										{Name: flagOffline, DefaultValue: "false", Usage: "only run the schema checks without probing the chains", Type: plugin.FlagTypeBool},
+										{Name: flagJSON, DefaultValue: "false", Usage: "print the config issues as JSON", Type: plugin.FlagTypeBool},
+									},
+								},
+								{
+									Use:   "migrate [chain-a-id] [chain-b-id] [chain-n-id...]",
+									Short: "Migrate the Hermes config to the format of a Hermes version",
+									Long: "Detect the schema version of the Hermes config from its keys and rewrite it to the format " +
+										"of the target Hermes version, like the event source, compat mode and dynamic gas price " +
+										"sections. The target is the --hermes-version flag or the embedded Hermes version, and the " +
+										"original config is kept into the backup directory next to it. The migrated config is " +
+										"rewritten from its parsed keys, so its comments are removed and its keys are reordered.",
+									Flags: plugin.Flags{
+										{Name: flagAll, DefaultValue: "false", Usage: "migrate all the saved Hermes configs", Type: plugin.FlagTypeBool},
+										{Name: flagDryRun, DefaultValue: "false", Usage: "only show the changes without writing the configs", Type: plugin.FlagTypeBool},
+									},
+								},
+								{
+									Use:   "list",
+									Short: "List the saved Hermes configs",
+								},
+								{
+									Use:   "show [config-name|chain-a-id] [chain-b-id...]",
+									Short: "Show a saved Hermes config as TOML or JSON",
+									Flags: plugin.Flags{
+										{Name: flagJSON, DefaultValue: "false", Usage: "print the config as JSON", Type: plugin.FlagTypeBool},
+									},
+								},
+								{
+									Use:   "remove [config-name|chain-a-id] [chain-b-id...]",
+									Short: "Remove a saved Hermes config and its chain keys",
+									Long: "Remove a saved Hermes config, its relayer daemon files and the Hermes keys of its chains. " +
+										"The chain keys used by other saved configs are kept.",
+									Flags: plugin.Flags{
+										{Name: flagYes, Shorthand: "y", DefaultValue: "false", Usage: "remove the config without asking for confirmation", Type: plugin.FlagTypeBool},
+										{Name: flagKeepKeys, DefaultValue: "false", Usage: "keep the Hermes keys of the config chains", Type: plugin.FlagTypeBool},
+									},
+								},
+								{
+									Use:   "export [config-name...]",
+									Short: "Bundle the saved Hermes configs into a tar.gz file",
+									Long:  "Bundle the saved Hermes configs, or all of them if no name is set, to share them. The chain keys are not exported.",
+									Flags: plugin.Flags{
+										{Name: flagOutput, Shorthand: "o", DefaultValue: "hermes-configs.tar.gz", Usage: "path of the bundle file", Type: plugin.FlagTypeString},
+									},
+								},
+								{
+									Use:   "import [bundle-path]",
+									Short: "Import the Hermes configs from a bundle or a TOML config file",
+									Flags: plugin.Flags{
+										{Name: flagOverwrite, DefaultValue: "false", Usage: "replace the saved configs with the same name", Type: plugin.FlagTypeBool},
+									},
+								},
+							},
+						},
+						{
+							Use:   "filter [command]",
+							Short: "Manage the packet filter of the Hermes relayer chains",
+							Long: "Restrict the channels relayed for a chain with an allow or deny packet filter policy. " +
+								"The port and channel IDs support the * wildcard, like channel-*, and the channels are checked " +
+								"on the chain before updating the config.",
+							Commands: []*plugin.Command{
+								{
+									Use:   "allow [chain-id] [port-id] [channel-id]",
+									Short: "Relay the packets of a channel, adding it to the allow list or removing it from the deny list",
+									Flags: plugin.Flags{
+										{Name: flagOffline, DefaultValue: "false", Usage: "skip checking the channel exists on the chain", Type: plugin.FlagTypeBool},
+									},
+								},
+								{
+									Use:   "deny [chain-id] [port-id] [channel-id]",
+									Short: "Stop relaying the packets of a channel, adding it to the deny list or removing it from the allow list",
+									Flags: plugin.Flags{
+										{Name: flagOffline, DefaultValue: "false", Usage: "skip checking the channel exists on the chain", Type: plugin.FlagTypeBool},
+									},
+								},
+								{
+									Use:   "list [chain-id]",
+									Short: "List the packet filter policy and channels of the config chains",
+								},
+							},
 						},
 						{
 							Use:   "keys [command]",
@@ -41,6 +227,16 @@ func GetCommands() []*plugin.Command {
 								{
 									Use:   "add [chain-id] [mnemonic]",
 									Short: "Add a new key from mnemonic to Hermes relayer",
+									Long: "Add a new key from mnemonic to Hermes relayer. The mnemonic is read from the stdin " +
+										"if it is piped or from a prompt with hidden input, passing it as argument is supported " +
+										"but exposes it in the shell history. The --from-keyring flag imports a key by name " +
+										"from the local Ignite or Cosmos keyring instead.",
+									Flags: plugin.Flags{
+										{Name: flagFromKeyring, Usage: "name of the Ignite or Cosmos keyring key to import", Type: plugin.FlagTypeString},
+										{Name: flagKeyringBackend, DefaultValue: string(cosmosaccount.KeyringTest), Usage: "keyring backend of the imported key", Type: plugin.FlagTypeString},
+										{Name: flagKeyringDir, Usage: "keyring directory of the imported key (default the Ignite accounts dir)", Type: plugin.FlagTypeString},
+										{Name: flagOverwrite, DefaultValue: "false", Usage: "overwrite the chain key if it already exists", Type: plugin.FlagTypeBool},
+									},
 								},
 								{
 									Use:   "file [chain-id] [filepath]",
@@ -57,8 +253,14 @@ func GetCommands() []*plugin.Command {
 							},
 						},
 						{
-							Use:   "configure [chain-a-id] [chain-a-rpc] [chain-a-grpc] [chain-b-id] [chain-b-rpc] [chain-b-grpc]",
+							Use:   "configure [chain-a-id] [chain-a-rpc] [chain-a-grpc] [chain-b-id] [chain-b-rpc] [chain-b-grpc] [chain-n-id chain-n-rpc chain-n-grpc...]",
 							Short: "Configure the Hermes relayer creating the config file, client, channels and connection",
+							Long: "Configure the Hermes relayer for two or more chains creating the config file and the clients, " +
+								"connections and channels for each relayer path. The chain A flags configure the first chain " +
+								"and the chain B flags are the defaults of all other chains, overridden by chain with the " +
+								"--chain-set flag, e.g. --chain-set zone-1:gas-price=0.025uzone. If no --path is set, the first chain is " +
+								"connected to every other chain. The chains can be loaded from the cosmos chain registry with the " +
+								"--from-registry flag.",
 							Flags: plugin.Flags{
 								{Name: flagChainAPortID, DefaultValue: "transfer", Usage: "port ID of the chain A", Type: plugin.FlagTypeString},
 								{Name: flagChainBPortID, DefaultValue: "transfer", Usage: "port ID of the chain B", Type: plugin.FlagTypeString},
@@ -112,6 +314,22 @@ func GetCommands() []*plugin.Command {
 								{Name: flagChainBFaucet, Usage: "faucet URL of the chain B", Type: plugin.FlagTypeString},
 								{Name: flagChainAType, DefaultValue: "CosmosSdk", Usage: "type of the chain A", Type: plugin.FlagTypeString},
 								{Name: flagChainBType, DefaultValue: "CosmosSdk", Usage: "type of the chain B", Type: plugin.FlagTypeString},
+								{Name: flagChainADynamicGasPrice, DefaultValue: "false", Usage: "query the gas price of the chain A from its fee market module", Type: plugin.FlagTypeBool},
+								{Name: flagChainBDynamicGasPrice, DefaultValue: "false", Usage: "query the gas price of the chain B from its fee market module", Type: plugin.FlagTypeBool},
+								{Name: flagChainADynamicGasMultiplier, DefaultValue: "1.1", Usage: "dynamic gas price multiplier of the chain A", Type: plugin.FlagTypeString},
+								{Name: flagChainBDynamicGasMultiplier, DefaultValue: "1.1", Usage: "dynamic gas price multiplier of the chain B", Type: plugin.FlagTypeString},
+								{Name: flagChainADynamicGasMax, DefaultValue: "0.6", Usage: "maximum dynamic gas price of the chain A", Type: plugin.FlagTypeString},
+								{Name: flagChainBDynamicGasMax, DefaultValue: "0.6", Usage: "maximum dynamic gas price of the chain B", Type: plugin.FlagTypeString},
+								{Name: flagChainAFeeGranter, Usage: "fee granter address paying the chain A fees", Type: plugin.FlagTypeString},
+								{Name: flagChainBFeeGranter, Usage: "fee granter address paying the chain B fees", Type: plugin.FlagTypeString},
+								{Name: flagChainAExcludedSequences, Usage: "packet sequences never cleared on the chain A in the format <channel-id>=<sequence> or <channel-id>=<from>-<to>", Type: plugin.FlagTypeStringSlice},
+								{Name: flagChainBExcludedSequences, Usage: "packet sequences never cleared on the chain B in the format <channel-id>=<sequence> or <channel-id>=<from>-<to>", Type: plugin.FlagTypeStringSlice},
+								{Name: flagChainAPacketFilterPolicy, DefaultValue: hermes.PacketFilterAllow, Usage: "packet filter policy of the chain A (allow or deny)", Type: plugin.FlagTypeString},
+								{Name: flagChainBPacketFilterPolicy, DefaultValue: hermes.PacketFilterAllow, Usage: "packet filter policy of the chain B (allow or deny)", Type: plugin.FlagTypeString},
+								{Name: flagChainAPacketFilter, Usage: "packet filter of the chain A in the format <port-id>/<channel-id>, with * wildcards support", Type: plugin.FlagTypeStringSlice},
+								{Name: flagChainBPacketFilter, Usage: "packet filter of the chain B in the format <port-id>/<channel-id>, with * wildcards support", Type: plugin.FlagTypeStringSlice},
+								{Name: flagChainACompatMode, Usage: "CometBFT compatibility mode of the chain A (0.34, 0.37 or 0.38)", Type: plugin.FlagTypeString},
+								{Name: flagChainBCompatMode, Usage: "CometBFT compatibility mode of the chain B (0.34, 0.37 or 0.38)", Type: plugin.FlagTypeString},
 								{Name: flagChainASequentialBatchTx, DefaultValue: "false", Usage: "enable sequential batch transaction on the chain A", Type: plugin.FlagTypeBool},
 								{Name: flagChainBSequentialBatchTx, DefaultValue: "false", Usage: "enable sequential batch transaction on the chain B", Type: plugin.FlagTypeBool},
 								{Name: flagTelemetryEnabled, DefaultValue: "false", Usage: "enable hermes telemetry", Type: plugin.FlagTypeBool},
@@ -133,6 +351,59 @@ func GetCommands() []*plugin.Command {
 								{Name: flagGenerateWallets, DefaultValue: "false", Usage: "automatically generate wallets if they do not exist", Type: plugin.FlagTypeBool},
 								{Name: flagOverwriteConfig, DefaultValue: "false", Usage: "overwrite the current config if it already exists", Type: plugin.FlagTypeBool},
 								{Name: flagChannelVersion, Usage: "set the channel version for the create channel hermes command", Type: plugin.FlagTypeString},
+								{Name: flagReuse, DefaultValue: "false", Usage: "reuse the existing clients, connections and channels between the chains without asking", Type: plugin.FlagTypeBool},
+								{Name: flagFromRegistry, Usage: "add a chain by name from the cosmos chain registry, with its RPC and gRPC endpoints, account prefix and fee token", Type: plugin.FlagTypeStringSlice},
+								{Name: flagRegistryDir, Usage: "local checkout of the cosmos chain registry (default: download and cache the chain files)", Type: plugin.FlagTypeString},
+								{Name: flagDiscover, DefaultValue: "false", Usage: "discover the account prefix, trusting period, max block time and gas price from the chains, the chain flags set act as overrides", Type: plugin.FlagTypeBool},
+								{Name: flagChainSet, Usage: "chain setting overriding the chain A or B flag in the format <chain-id>:<setting>=<value>, where the setting is the flag name without the chain-a- or chain-b- prefix", Type: plugin.FlagTypeStringSlice},
+								{Name: flagPath, Usage: "relayer path between two configured chains in the format <chain-a>[:<port-a>]/<chain-b>[:<port-b>][@<version>] (default: the first chain to every other chain)", Type: plugin.FlagTypeStringSlice},
+							},
+						},
+						{
+							Use:   "test-transfer [chain-a-id] [chain-b-id]",
+							Short: "Send a test IBC transfer and wait for the relayer to deliver it",
+							Long: "Send an ICS-20 transfer from the chain A relayer key to the chain B relayer key through " +
+								"the given or first transfer channel, wait for the relayer to deliver the packet and its " +
+								"acknowledgment, verify the IBC denom balance on chain B and report the timing of each step.",
+							Flags: plugin.Flags{
+								{Name: flagChannel, Usage: "chain A channel ID (default: the first channel of the port to chain B)", Type: plugin.FlagTypeString},
+								{Name: flagPort, DefaultValue: "transfer", Usage: "chain A port ID", Type: plugin.FlagTypeString},
+								{Name: flagAmount, Usage: "amount to transfer (default: one unit of the chain A gas price denom)", Type: plugin.FlagTypeString},
+								{Name: flagTimeout, DefaultValue: "2m", Usage: "time to wait for the packet to be received and acknowledged", Type: plugin.FlagTypeString},
+							},
+						},
+						{
+							Use:   "sandbox [app-path-a] [app-path-b]",
+							Short: "Start two local chains connected by the Hermes relayer",
+							Long: "Initialize and start the chains of two Ignite apps with their own ports and homes, fund " +
+								"the relayer keys from genesis accounts, create a transfer channel between the chains and " +
+								"start the Hermes relayer in background. The sandbox teardown command stops and removes everything.",
+							Flags: plugin.Flags{
+								{Name: flagChainAID, DefaultValue: "sandbox-a", Usage: "chain ID of the first app", Type: plugin.FlagTypeString},
+								{Name: flagChainBID, DefaultValue: "sandbox-b", Usage: "chain ID of the second app", Type: plugin.FlagTypeString},
+							},
+							Commands: []*plugin.Command{
+								{
+									Use:   "teardown",
+									Short: "Stop the sandbox chains and relayer and remove their files",
+								},
+							},
+						},
+						{
+							Use:   "plan [manifest-path]",
+							Short: "Show the clients, connections and channels a relayer manifest would create",
+							Long: "Load a YAML or TOML relayer manifest describing the chains and paths, query the " +
+								"existing clients, connections and channels between the chains and show which ones " +
+								"would be created by the apply command.",
+						},
+						{
+							Use:   "apply [manifest-path]",
+							Short: "Reconcile the on-chain relayer paths with a relayer manifest",
+							Long: "Load a YAML or TOML relayer manifest, save the Hermes config and create the " +
+								"clients, connections and channels of the paths that don't exist on-chain yet, reusing the existing ones.",
+							Flags: plugin.Flags{
+								{Name: flagGenerateWallets, DefaultValue: "false", Usage: "automatically generate wallets if they do not exist", Type: plugin.FlagTypeBool},
+								{Name: flagYes, Shorthand: "y", DefaultValue: "false", Usage: "create the missing paths without asking for confirmation", Type: plugin.FlagTypeBool},
 							},
 						},
 					},
@@ -0,0 +1,447 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/gookit/color"
+	"github.com/ignite/cli/v28/ignite/pkg/cliui"
+	"github.com/ignite/cli/v28/ignite/pkg/errors"
+	"github.com/ignite/cli/v28/ignite/services/plugin"
+	"github.com/manifoldco/promptui"
+	"github.com/pelletier/go-toml/v2"
+
+	"github.com/ignite/apps/hermes/pkg/hermes"
+)
+
+func ConfigValidateHandler(ctx context.Context, cmd *plugin.ExecutedCommand) error {
+	var (
+		flags         = plugin.Flags(cmd.Flags)
+		offline, _    = flags.GetBool(flagOffline)
+		jsonOutput, _ = flags.GetBool(flagJSON)
+	)
+
+	if len(cmd.Args) == 0 && getConfig(flags) == "" {
+		return errors.Errorf("the chain IDs or the --%s flag are required", flagConfig)
+	}
+	cfgPath, err := getConfigPath(flags, cmd.Args...)
+	if err != nil {
+		return err
+	}
+	hermesCfg, err := hermes.LoadConfig(cfgPath)
+	if err != nil {
+		return err
+	}
+
+	var session *cliui.Session
+	if jsonOutput {
+		session = cliui.New(cliui.WithStdout(os.Stderr))
+	} else {
+		session = cliui.New(cliui.StartSpinnerWithText("Validating the Hermes config"))
+	}
+	defer session.End()
+
+	issues := hermesCfg.Validate()
+	if !offline {
+		for _, chain := range hermesCfg.Chains {
+			session.StartSpinner(fmt.Sprintf("Probing the %s endpoints", chain.ID))
+			issues = append(issues, chain.Probe(ctx)...)
+		}
+	}
+	session.StopSpinner()
+
+	if jsonOutput {
+		out, err := json.MarshalIndent(issues, "", "  ")
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintln(os.Stdout, string(out)); err != nil {
+			return err
+		}
+	} else if len(issues) > 0 {
+		entries := make([][]string, 0, len(issues))
+		for _, issue := range issues {
+			severity := color.Yellow.Sprint(issue.Severity)
+			if issue.Severity == hermes.SeverityError {
+				severity = color.Red.Sprint(issue.Severity)
+			}
+			entries = append(entries, []string{issue.ChainID, issue.Field, severity, issue.Message})
+		}
+		if err := session.PrintTable([]string{"Chain", "Field", "Severity", "Message"}, entries...); err != nil {
+			return err
+		}
+	}
+
+	if issues.HasErrors() {
+		return errors.Errorf("the Hermes config %s is invalid", cfgPath)
+	}
+	if !jsonOutput {
+		_ = session.Println(color.Green.Sprintf("Hermes config %s is valid", cfgPath))
+	}
+	return nil
+}
+
+func ConfigMigrateHandler(_ context.Context, cmd *plugin.ExecutedCommand) error {
+	var (
+		flags         = plugin.Flags(cmd.Flags)
+		all, _        = flags.GetBool(flagAll)
+		dryRun, _     = flags.GetBool(flagDryRun)
+		target, _     = flags.GetString(flagHermesVersion)
+		hermesPath, _ = flags.GetString(flagHermesPath)
+	)
+	if hermesPath != "" {
+		return errors.Errorf("the --%s flag cannot be used to migrate, set the target with --%s", flagHermesPath, flagHermesVersion)
+	}
+	if target == "" {
+		target = hermes.EmbeddedVersion
+	}
+
+	var cfgPaths []string
+
+	switch {
+	case all:
+		configs, err := hermes.ListConfigs()
+		if err != nil {
+			return err
+		}
+		for _, saved := range configs {
+			cfgPaths = append(cfgPaths, saved.Path)
+		}
+	case len(cmd.Args) == 0 && getConfig(flags) == "":
+		return errors.Errorf("the chain IDs, the --%s or the --%s flags are required", flagConfig, flagAll)
+	default:
+		cfgPath, err := getConfigPath(flags, cmd.Args...)
+		if err != nil {
+			return err
+		}
+		cfgPaths = append(cfgPaths, cfgPath)
+	}
+
+	session := cliui.New()
+	defer session.End()
+
+	var migrated int
+	for _, cfgPath := range cfgPaths {
+		var (
+			result     hermes.MigrationResult
+			backupPath string
+		)
+		if dryRun {
+			data, err := os.ReadFile(cfgPath)
+			if err != nil {
+				return err
+			}
+			if _, result, err = hermes.MigrateConfig(data, target); err != nil {
+				return errors.Wrapf(err, "failed to migrate the Hermes config %s", cfgPath)
+			}
+		} else {
+			var err error
+			if result, backupPath, err = hermes.MigrateConfigFile(cfgPath, target); err != nil {
+				return err
+			}
+		}
+
+		if len(result.Changes) == 0 {
+			_ = session.Printf("Hermes config %s is up to date with Hermes %s\n", cfgPath, result.To)
+			continue
+		}
+		migrated++
+		_ = session.Printf("Hermes config %s schema %s -> %s\n", cfgPath, result.From, result.To)
+		for _, change := range result.Changes {
+			_ = session.Printf("  - %s\n", change)
+		}
+		if backupPath != "" {
+			_ = session.Printf("  backup: %s\n", backupPath)
+		}
+	}
+
+	switch {
+	case dryRun:
+		_ = session.Println(color.Yellow.Sprintf(
+			"Dry run, %d Hermes config(s) would be migrated, removing their comments and reordering their keys",
+			migrated,
+		))
+	case migrated > 0:
+		_ = session.Println(color.Green.Sprintf("%d Hermes config(s) migrated to Hermes %s", migrated, target))
+	}
+	return nil
+}
+
+func ConfigListHandler(_ context.Context, _ *plugin.ExecutedCommand) error {
+	session := cliui.New()
+	defer session.End()
+
+	configs, err := hermes.ListConfigs()
+	if err != nil {
+		return err
+	}
+	if len(configs) == 0 {
+		return session.Println(color.Yellow.Sprint("No Hermes config saved yet"))
+	}
+
+	entries := make([][]string, 0, len(configs))
+	for _, saved := range configs {
+		status := "stopped"
+		d, _, err := newDaemon(saved.Path)
+		if err != nil {
+			return err
+		}
+		if daemonStatus, err := d.Status(); err == nil && daemonStatus.Running {
+			status = color.Green.Sprint("running")
+		}
+		entries = append(entries, []string{
+			saved.Name,
+			strings.Join(saved.Config.Chains.IDs(), ", "),
+			status,
+			saved.Path,
+		})
+	}
+	return session.PrintTable([]string{"Name", "Chains", "Relayer", "Path"}, entries...)
+}
+
+func ConfigShowHandler(_ context.Context, cmd *plugin.ExecutedCommand) error {
+	var (
+		flags         = plugin.Flags(cmd.Flags)
+		jsonOutput, _ = flags.GetBool(flagJSON)
+	)
+
+	cfgPath, err := resolveConfigPath(flags, cmd.Args)
+	if err != nil {
+		return err
+	}
+	hermesCfg, err := hermes.LoadConfig(cfgPath)
+	if err != nil {
+		return err
+	}
+
+	if jsonOutput {
+		out, err := json.MarshalIndent(hermesCfg, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(os.Stdout, string(out))
+		return err
+	}
+	return toml.NewEncoder(os.Stdout).Encode(hermesCfg)
+}
+
+func ConfigRemoveHandler(ctx context.Context, cmd *plugin.ExecutedCommand) error {
+	var (
+		flags       = plugin.Flags(cmd.Flags)
+		yes, _      = flags.GetBool(flagYes)
+		keepKeys, _ = flags.GetBool(flagKeepKeys)
+	)
+
+	cfgPath, err := resolveConfigPath(flags, cmd.Args)
+	if err != nil {
+		return err
+	}
+
+	// only the saved configs are removed, never a user file or the daemon of another config.
+	configs, err := hermes.ListConfigs()
+	if err != nil {
+		return err
+	}
+	saved, ok := findSavedConfig(configs, cfgPath)
+	if !ok {
+		return errors.Errorf("%s is not a saved Hermes config, see the config list command", cfgPath)
+	}
+	cfgPath, hermesCfg := saved.Path, saved.Config
+
+	d, cfgName, err := newDaemon(cfgPath)
+	if err != nil {
+		return err
+	}
+	if status, err := d.Status(); err != nil {
+		return err
+	} else if status.Running {
+		return errors.Errorf("Hermes relayer %s is running, stop it first", cfgName)
+	}
+
+	session := cliui.New()
+	defer session.End()
+
+	if !yes {
+		question := fmt.Sprintf("Remove the Hermes config %s and its chain keys", cfgName)
+		if keepKeys {
+			question = fmt.Sprintf("Remove the Hermes config %s", cfgName)
+		}
+		if err := session.AskConfirm(question); err != nil {
+			if errors.Is(err, promptui.ErrAbort) {
+				return errors.New("remove canceled")
+			}
+			return err
+		}
+	}
+
+	if !keepKeys {
+		// the keys are stored by chain, so keep the ones used by other configs.
+		h, err := newHermes(cmd.Flags)
+		if err != nil {
+			return err
+		}
+		defer h.Cleanup()
+
+		for _, chain := range hermesCfg.Chains {
+			if keyInUse(configs, cfgPath, chain) {
+				_ = session.Printf("Chain %s key %s is used by another config, keeping it\n", chain.ID, chain.KeyName)
+				continue
+			}
+			session.StartSpinner(fmt.Sprintf("Deleting chain %s key %s", chain.ID, chain.KeyName))
+			if err := h.DeleteKey(
+				ctx,
+				chain.ID,
+				chain.KeyName,
+				hermes.WithConfigFile(cfgPath),
+				hermes.WithStdOut(&bytes.Buffer{}),
+				hermes.WithJSONOutput(),
+			); err != nil {
+				session.StopSpinner()
+				_ = session.Println(color.Yellow.Sprintf("Failed to delete the chain %s key %s: %s", chain.ID, chain.KeyName, err))
+			}
+		}
+		session.StopSpinner()
+	}
+
+	if err := os.RemoveAll(d.Dir()); err != nil {
+		return err
+	}
+	if err := os.Remove(cfgPath); err != nil {
+		return err
+	}
+	return session.Println(color.Green.Sprintf("Hermes config %s removed", cfgName))
+}
+
+func ConfigExportHandler(_ context.Context, cmd *plugin.ExecutedCommand) error {
+	var (
+		flags     = plugin.Flags(cmd.Flags)
+		output, _ = flags.GetString(flagOutput)
+	)
+
+	configs, err := hermes.ListConfigs()
+	if err != nil {
+		return err
+	}
+	cfgPaths := make([]string, 0)
+	for _, saved := range configs {
+		if len(cmd.Args) == 0 || slices.Contains(cmd.Args, saved.Name) {
+			cfgPaths = append(cfgPaths, saved.Path)
+		}
+	}
+	if len(cfgPaths) == 0 {
+		return errors.New("no Hermes config to export")
+	}
+	if len(cmd.Args) > 0 && len(cfgPaths) != len(cmd.Args) {
+		return errors.Errorf("Hermes configs not found, expected %v", cmd.Args)
+	}
+
+	f, err := os.Create(output)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err := hermes.ExportConfigs(f, cfgPaths...); err != nil {
+		return err
+	}
+
+	session := cliui.New()
+	defer session.End()
+	return session.Println(color.Green.Sprintf("%d Hermes config(s) exported to %s, the chain keys are not included", len(cfgPaths), output))
+}
+
+func ConfigImportHandler(_ context.Context, cmd *plugin.ExecutedCommand) error {
+	var (
+		flags        = plugin.Flags(cmd.Flags)
+		overwrite, _ = flags.GetBool(flagOverwrite)
+	)
+	if len(cmd.Args) != 1 {
+		return errors.New("the bundle path is required")
+	}
+
+	f, err := os.Open(cmd.Args[0])
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	configDir, err := hermes.ConfigDir()
+	if err != nil {
+		return err
+	}
+	result, err := hermes.ImportConfigs(f, configDir, overwrite)
+	if err != nil {
+		return errors.Wrapf(err, "failed to import the bundle %s", cmd.Args[0])
+	}
+
+	session := cliui.New()
+	defer session.End()
+
+	for _, name := range result.Skipped {
+		_ = session.Println(color.Yellow.Sprintf("Hermes config %s already exists, skipped (use --%s to replace it)", name, flagOverwrite))
+	}
+	for _, name := range result.Imported {
+		_ = session.Printf("Hermes config %s imported\n", name)
+	}
+	_ = session.Println(color.Green.Sprintf("%d Hermes config(s) imported", len(result.Imported)))
+	if len(result.Imported) > 0 {
+		return session.Println("Add the chain keys with the keys add command before starting the relayer")
+	}
+	return nil
+}
+
+// resolveConfigPath returns the custom config flag, the saved config by name if a single
+// argument is set, or the config file containing all the chains of the args. It returns
+// an ErrAmbiguousConfig error asking for the config flag if more than one config contains
+// the chains.
+func resolveConfigPath(flags plugin.Flags, args []string) (string, error) {
+	if customCfg := getConfig(flags); customCfg != "" {
+		return customCfg, nil
+	}
+	if len(args) == 0 {
+		return "", errors.Errorf("the config name, the chain IDs or the --%s flag are required", flagConfig)
+	}
+	if len(args) == 1 {
+		cfgPath, err := hermes.ConfigPath(args[0])
+		if err != nil {
+			return "", err
+		}
+		if info, err := os.Stat(cfgPath); err == nil && !info.IsDir() {
+			return cfgPath, nil
+		}
+	}
+	return findConfig(args...)
+}
+
+// findSavedConfig returns the saved config of the config path.
+func findSavedConfig(configs []hermes.SavedConfig, cfgPath string) (hermes.SavedConfig, bool) {
+	absPath, err := filepath.Abs(cfgPath)
+	if err != nil {
+		return hermes.SavedConfig{}, false
+	}
+	for _, saved := range configs {
+		if savedPath, err := filepath.Abs(saved.Path); err == nil && savedPath == absPath {
+			return saved, true
+		}
+	}
+	return hermes.SavedConfig{}, false
+}
+
+// keyInUse returns true if another saved config than cfgPath uses the chain key.
+func keyInUse(configs []hermes.SavedConfig, cfgPath string, chain hermes.Chain) bool {
+	for _, saved := range configs {
+		if saved.Path == cfgPath {
+			continue
+		}
+		other, err := saved.Config.Chains.Get(chain.ID)
+		if err == nil && other.KeyName == chain.KeyName {
+			return true
+		}
+	}
+	return false
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"math/big"
 	"os"
+	"strconv"
+	"strings"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/go-bip39"
@@ -19,6 +21,11 @@ import (
 	"github.com/ignite/apps/hermes/pkg/hermes"
 )
 
+const (
+	// chainArgsLen is the number of configure args for each chain (chain id, RPC and gRPC addresses).
+	chainArgsLen = 3
+)
+
 func ConfigureHandler(ctx context.Context, cmd *plugin.ExecutedCommand) error {
 	var (
 		args  = cmd.Args
@@ -29,16 +36,9 @@ func ConfigureHandler(ctx context.Context, cmd *plugin.ExecutedCommand) error {
 	defer session.End()
 
 	var (
-		chainAID = args[0]
-		chainBID = args[3]
-
 		generateWallets, _ = flags.GetBool(flagGenerateWallets)
 		overwriteConfig, _ = flags.GetBool(flagOverwriteConfig)
-		chainAPortID, _    = flags.GetString(flagChainAPortID)
-		chainAFaucet, _    = flags.GetString(flagChainAFaucet)
-		chainBPortID, _    = flags.GetString(flagChainBPortID)
-		chainBFaucet, _    = flags.GetString(flagChainBFaucet)
-		channelVersion, _  = flags.GetString(flagChannelVersion)
+		reuse, _           = flags.GetBool(flagReuse)
 		customCfg          = getConfig(flags)
 	)
 
@@ -52,11 +52,17 @@ func ConfigureHandler(ctx context.Context, cmd *plugin.ExecutedCommand) error {
 			return err
 		}
 	} else {
-		hermesCfg, err = newHermesConfig(flags, args, customCfg)
+		hermesCfg, err = newHermesConfig(ctx, session, flags, args, cmd.OsArgs)
 		if err != nil {
 			return err
 		}
 	}
+
+	paths, err := getPaths(flags, hermesCfg)
+	if err != nil {
+		return err
+	}
+
 	cfgPath, err := hermesCfg.ConfigPath()
 	if err != nil {
 		return err
@@ -69,9 +75,8 @@ func ConfigureHandler(ctx context.Context, cmd *plugin.ExecutedCommand) error {
 	} else {
 		session.StopSpinner()
 		if err := session.AskConfirm(fmt.Sprintf(
-			"Hermes %s <-> %s config already exist at %s. Do you want to reuse this config file",
-			chainAID,
-			chainBID,
+			"Hermes %s config already exist at %s. Do you want to reuse this config file",
+			strings.Join(hermesCfg.Chains.IDs(), " <-> "),
 			cfgPath,
 		)); err != nil {
 			if !errors.Is(err, promptui.ErrAbort) {
@@ -86,130 +91,177 @@ func ConfigureHandler(ctx context.Context, cmd *plugin.ExecutedCommand) error {
 	session.StopSpinner()
 	_ = session.Println(color.Green.Sprintf("Hermes config created at %s", cfgPath))
 
-	h, err := hermes.New()
+	h, err := newHermes(cmd.Flags)
 	if err != nil {
 		return err
 	}
 	defer h.Cleanup()
 
-	session.StartSpinner(fmt.Sprintf("Verifying chain A (%s) keys", chainAID))
-
-	if err := ensureAccount(
-		ctx,
-		session,
-		hermesCfg,
-		h,
-		chainAID,
-		chainAFaucet,
-		cfgPath,
-		generateWallets,
-	); err != nil {
+	settings, err := parseChainSettings(flags, hermesCfg.Chains.IDs())
+	if err != nil {
 		return err
 	}
+	for i, chain := range hermesCfg.Chains {
+		names := flagsForChain(i)
+		chainFlags, _ := settings.apply(flags, nil, names, chain.ID)
+		faucet, _ := chainFlags.GetString(names.faucet)
 
-	session.StartSpinner(fmt.Sprintf("Verifying chain B (%s) keys", chainBID))
+		// the chain B faucet is shared by all the chains after the first one.
+		faucetSource := "--" + names.faucet
+		if i > 0 && len(hermesCfg.Chains) > 2 {
+			faucetSource = fmt.Sprintf("--%s %s:faucet=<url>", flagChainSet, chain.ID)
+		}
 
-	if err := ensureAccount(
-		ctx,
-		session,
-		hermesCfg,
-		h,
-		chainBID,
-		chainBFaucet,
-		cfgPath,
-		generateWallets,
-	); err != nil {
-		return err
+		session.StartSpinner(fmt.Sprintf("Verifying chain %s keys", chain.ID))
+		if err := ensureAccount(
+			ctx,
+			session,
+			hermesCfg,
+			h,
+			chain.ID,
+			faucet,
+			faucetSource,
+			cfgPath,
+			generateWallets,
+		); err != nil {
+			return err
+		}
 	}
 
-	// create client A
-	session.StartSpinner("Creating client A")
+	for _, path := range paths {
+		session.StartSpinner(fmt.Sprintf("Querying existing clients and connections of the path %s", path))
+		state, err := queryPathState(ctx, h, cfgPath, path)
+		if err != nil {
+			return errors.Wrapf(err, "failed to query the path %s", path)
+		}
+		if len(state.existing()) > 0 && !reuse {
+			session.StopSpinner()
+			printPathState(session, state)
+			if err := session.AskConfirm("Do you want to reuse them"); err != nil {
+				if !errors.Is(err, promptui.ErrAbort) {
+					return err
+				}
+				state = pathState{path: path}
+			}
+		}
+		if err := createPath(ctx, session, h, cfgPath, state); err != nil {
+			return errors.Wrapf(err, "failed to create the path %s", path)
+		}
+	}
+	return nil
+}
+
+// getPaths returns the relayer paths from the path flag. If no path is set, the first chain
+// is connected to every other chain using the chain A and chain B port flags.
+func getPaths(flags plugin.Flags, hermesCfg *hermes.Config) (hermes.Paths, error) {
 	var (
-		bufClientAResult = bytes.Buffer{}
-		clientAResult    = hermes.ClientResult{}
+		pathDefs, _       = flags.GetStringSlice(flagPath)
+		chainAPortID, _   = flags.GetString(flagChainAPortID)
+		chainBPortID, _   = flags.GetString(flagChainBPortID)
+		channelVersion, _ = flags.GetString(flagChannelVersion)
+		paths             = make(hermes.Paths, 0)
 	)
-	if err := h.CreateClient(
-		ctx,
-		chainAID,
-		chainBID,
-		hermes.WithConfigFile(cfgPath),
-		hermes.WithStdOut(&bufClientAResult),
-		hermes.WithJSONOutput(),
-	); err != nil {
-		return err
+	if len(pathDefs) > 0 {
+		parsed, err := hermes.ParsePaths(pathDefs...)
+		if err != nil {
+			return nil, err
+		}
+		paths = parsed
+	} else if len(hermesCfg.Chains) > 1 {
+		for _, chain := range hermesCfg.Chains[1:] {
+			paths = append(paths, hermes.Path{
+				ChainA: hermesCfg.Chains[0].ID,
+				PortA:  chainAPortID,
+				ChainB: chain.ID,
+				PortB:  chainBPortID,
+			})
+		}
 	}
-	if err := hermes.UnmarshalResult(bufClientAResult.Bytes(), &clientAResult); err != nil {
-		return err
+
+	for i := range paths {
+		if paths[i].Version == "" {
+			paths[i].Version = channelVersion
+		}
 	}
+	return paths, hermesCfg.ValidatePaths(paths)
+}
 
-	session.StopSpinner()
-	_ = session.Println(color.Green.Sprintf(
-		"Client '%s' created (%s -> %s)",
-		clientAResult.CreateClient.ClientID,
-		chainAID,
-		chainBID,
-	))
+// createPath creates the clients, connection and channel of a relayer path
+// not found on-chain, reusing the existing ones from the path state.
+func createPath(
+	ctx context.Context,
+	session *cliui.Session,
+	h *hermes.Hermes,
+	cfgPath string,
+	state pathState,
+) error {
+	path := state.path
 
-	// create client B
-	session.StartSpinner("Creating client B")
-	var (
-		bufClientBResult = bytes.Buffer{}
-		clientBResult    = hermes.ClientResult{}
-	)
-	if err := h.CreateClient(
-		ctx,
-		chainBID,
-		chainAID,
-		hermes.WithConfigFile(cfgPath),
-		hermes.WithStdOut(&bufClientBResult),
-		hermes.WithJSONOutput(),
-	); err != nil {
-		return err
-	}
-	if err := hermes.UnmarshalResult(bufClientBResult.Bytes(), &clientBResult); err != nil {
-		return err
+	// create client A
+	if state.clientA == "" {
+		clientID, err := createClient(ctx, session, h, cfgPath, path.ChainA, path.ChainB)
+		if err != nil {
+			return err
+		}
+		state.clientA = clientID
 	}
 
-	session.StopSpinner()
-	_ = session.Println(color.Green.Sprintf(
-		"Client %s' created (%s -> %s)",
-		clientBResult.CreateClient.ClientID,
-		chainBID,
-		chainAID,
-	))
+	// create client B
+	if state.clientB == "" {
+		clientID, err := createClient(ctx, session, h, cfgPath, path.ChainB, path.ChainA)
+		if err != nil {
+			return err
+		}
+		state.clientB = clientID
+	}
 
 	// create connection
-	session.StartSpinner("Creating connection")
-	var (
-		bufConnection = bytes.Buffer{}
-		connection    = hermes.ConnectionResult{}
-	)
-	if err := h.CreateConnection(
-		ctx,
-		chainAID,
-		clientAResult.CreateClient.ClientID,
-		clientBResult.CreateClient.ClientID,
-		hermes.WithConfigFile(cfgPath),
-		hermes.WithStdOut(&bufConnection),
-		hermes.WithJSONOutput(),
-	); err != nil {
-		return err
-	}
-	if err := hermes.UnmarshalResult(bufConnection.Bytes(), &connection); err != nil {
-		return err
+	if state.connectionA == "" {
+		session.StartSpinner(fmt.Sprintf("Creating connection %s <-> %s", path.ChainA, path.ChainB))
+		var (
+			bufConnection = bytes.Buffer{}
+			connection    = hermes.ConnectionResult{}
+		)
+		if err := h.CreateConnection(
+			ctx,
+			path.ChainA,
+			state.clientA,
+			state.clientB,
+			hermes.WithConfigFile(cfgPath),
+			hermes.WithStdOut(&bufConnection),
+			hermes.WithJSONOutput(),
+		); err != nil {
+			return err
+		}
+		if err := hermes.UnmarshalResult(bufConnection.Bytes(), &connection); err != nil {
+			return err
+		}
+		state.connectionA, state.connectionB = connection.ASide.ConnectionID, connection.BSide.ConnectionID
+
+		session.StopSpinner()
+		_ = session.Println(color.Green.Sprintf(
+			"Connection '%s (%s) <-> %s (%s)' created",
+			path.ChainA,
+			state.connectionA,
+			path.ChainB,
+			state.connectionB,
+		))
 	}
 
-	session.StopSpinner()
-	_ = session.Println(color.Green.Sprintf(
-		"Connection '%s (%s) <-> %s (%s)' created",
-		chainAID,
-		connection.ASide.ConnectionID,
-		chainBID,
-		connection.BSide.ConnectionID,
-	))
+	if state.channelA != "" {
+		session.StopSpinner()
+		_ = session.Println(color.Green.Sprintf(
+			"Channel '%s (%s) <-> %s (%s)' already exists",
+			path.ChainA,
+			state.channelA,
+			path.ChainB,
+			state.channelB,
+		))
+		return nil
+	}
 
 	// create and query channel
-	session.StartSpinner("Creating channel")
+	session.StartSpinner(fmt.Sprintf("Creating channel %s <-> %s", path.ChainA, path.ChainB))
 	var (
 		bufChannel     = bytes.Buffer{}
 		channel        = hermes.ConnectionResult{}
@@ -219,16 +271,16 @@ func ConfigureHandler(ctx context.Context, cmd *plugin.ExecutedCommand) error {
 			hermes.WithJSONOutput(),
 		}
 	)
-	if channelVersion != "" {
-		createChanOpts = append(createChanOpts, hermes.WithFlags(hermes.Flags{flagChannelVersion: channelVersion}))
+	if path.Version != "" {
+		createChanOpts = append(createChanOpts, hermes.WithFlags(hermes.Flags{flagChannelVersion: path.Version}))
 	}
 
 	if err := h.CreateChannel(
 		ctx,
-		chainAID,
-		connection.ASide.ConnectionID,
-		chainAPortID,
-		chainBPortID,
+		path.ChainA,
+		state.connectionA,
+		path.PortA,
+		path.PortB,
 		createChanOpts...,
 	); err != nil {
 		return err
@@ -240,16 +292,55 @@ func ConfigureHandler(ctx context.Context, cmd *plugin.ExecutedCommand) error {
 	session.StopSpinner()
 	_ = session.Println(color.Green.Sprintf(
 		"Channel '%s (%s) <-> %s (%s)' created",
-		chainAID,
+		path.ChainA,
 		channel.ASide.ChannelID,
-		chainBID,
+		path.ChainB,
 		channel.BSide.ChannelID,
 	))
 
 	return nil
 }
 
+// createClient creates a client hosted by the host chain tracking the reference chain.
+func createClient(
+	ctx context.Context,
+	session *cliui.Session,
+	h *hermes.Hermes,
+	cfgPath,
+	hostChain,
+	referenceChain string,
+) (string, error) {
+	session.StartSpinner(fmt.Sprintf("Creating client %s -> %s", hostChain, referenceChain))
+	var (
+		bufClientResult = bytes.Buffer{}
+		clientResult    = hermes.ClientResult{}
+	)
+	if err := h.CreateClient(
+		ctx,
+		hostChain,
+		referenceChain,
+		hermes.WithConfigFile(cfgPath),
+		hermes.WithStdOut(&bufClientResult),
+		hermes.WithJSONOutput(),
+	); err != nil {
+		return "", err
+	}
+	if err := hermes.UnmarshalResult(bufClientResult.Bytes(), &clientResult); err != nil {
+		return "", err
+	}
+
+	session.StopSpinner()
+	_ = session.Println(color.Green.Sprintf(
+		"Client '%s' created (%s -> %s)",
+		clientResult.CreateClient.ClientID,
+		hostChain,
+		referenceChain,
+	))
+	return clientResult.CreateClient.ClientID, nil
+}
+
 // ensureAccount ensures the account exists and get found if the faucet is set.
+// The faucet source describes where the chain faucet is set, for the empty balance error.
 func ensureAccount(
 	ctx context.Context,
 	session *cliui.Session,
@@ -257,6 +348,7 @@ func ensureAccount(
 	h *hermes.Hermes,
 	chainID,
 	faucetAddr,
+	faucetSource,
 	cfgPath string,
 	generateWallets bool,
 ) error {
@@ -277,10 +369,11 @@ func ensureAccount(
 	}
 	if balance.Empty() && faucetAddr == "" {
 		return errors.Errorf(
-			"wallet %s balance is empty, please add funds or provide the faucet address flag (--%s or --%s)",
+			"chain %s wallet %s balance is empty, please add funds or provide the faucet address (%s)",
+			chainID,
 			chainAddr,
-			flagChainAFaucet,
-			flagChainBFaucet)
+			faucetSource,
+		)
 	}
 	if faucetAddr != "" {
 		session.StartSpinner(fmt.Sprintf("requesting faucet balance for %s", chainAddr))
@@ -336,18 +429,15 @@ GetKey:
 					chainID,
 				),
 				&mnemonic,
+				cliquiz.HideAnswer(),
 			)); err != nil {
 				return "", err
 			}
 		}
 
 		if mnemonic == "" {
-			entropySeed, err := bip39.NewEntropy(mnemonicEntropySize)
-			if err != nil {
-				return "", err
-			}
-			mnemonic, err = bip39.NewMnemonic(entropySeed)
-			if err != nil {
+			var err error
+			if mnemonic, err = newMnemonic(); err != nil {
 				return "", err
 			}
 
@@ -360,7 +450,7 @@ GetKey:
 		}
 
 		if !bip39.IsMnemonicValid(mnemonic) {
-			return "", errors.Errorf("invalid mnemonic: %s", mnemonic)
+			return "", errors.Errorf("invalid chain %s mnemonic", chainID)
 		}
 
 		bufKeysChainAdd := bytes.Buffer{}
@@ -395,14 +485,26 @@ GetKey:
 }
 
 // newHermesConfig create a new hermes config based in the cmd args.
-func newHermesConfig(flags plugin.Flags, args []string, customCfg string) (*hermes.Config, error) {
-	// if a custom config was set, save it in the ignite hermes folder
-	if customCfg != "" {
-		c, err := hermes.LoadConfig(customCfg)
-		if err != nil {
-			return nil, err
-		}
-		return c, c.Save()
+// The args are a list of chain id, RPC and gRPC addresses for each chain.
+// With the discover flag, the chain params are queried from the chains and
+// only overridden by the flags set in the command line.
+func newHermesConfig(
+	ctx context.Context,
+	session *cliui.Session,
+	flags plugin.Flags,
+	args,
+	osArgs []string,
+) (*hermes.Config, error) {
+	var (
+		registryNames, _ = flags.GetStringSlice(flagFromRegistry)
+		registryDir, _   = flags.GetString(flagRegistryDir)
+	)
+	if len(args)%chainArgsLen != 0 || len(args)/chainArgsLen+len(registryNames) < 2 {
+		return nil, errors.Errorf(
+			"expected the chain id, RPC and gRPC addresses or the --%s chain name of at least two chains, got %d args",
+			flagFromRegistry,
+			len(args),
+		)
 	}
 
 	// Create the default hermes config
@@ -423,6 +525,7 @@ func newHermesConfig(flags plugin.Flags, args []string, customCfg string) (*herm
 		modePacketsClearOnStart, _                  = flags.GetBool(flagModePacketsClearOnStart)
 		modePacketsTxConfirmation, _                = flags.GetBool(flagModePacketsTxConfirmation)
 		modePacketsAutoRegisterCounterpartyPayee, _ = flags.GetBool(flagAutoRegisterCounterpartyPayee)
+		discover, _                                 = flags.GetBool(flagDiscover)
 	)
 
 	c := hermes.DefaultConfig(
@@ -444,226 +547,334 @@ func newHermesConfig(flags plugin.Flags, args []string, customCfg string) (*herm
 		hermes.WithAutoRegisterCounterpartyPayee(modePacketsAutoRegisterCounterpartyPayee),
 	)
 
-	// Add chain A into the config
-	var (
-		chainAID       = args[0]
-		chainARPCAddr  = args[1]
-		chainAGRPCAddr = args[2]
-
-		chainAEventSourceMode, _           = flags.GetString(flagChainAEventSourceMode)
-		chainAEventSourceURL, _            = flags.GetString(flagChainAEventSourceURL)
-		chainAEventSourceBatchDelay, _     = flags.GetString(flagChainAEventSourceBatchDelay)
-		chainARPCTimeout, _                = flags.GetString(flagChainARPCTimeout)
-		chainAAccountPrefix, _             = flags.GetString(flagChainAAccountPrefix)
-		chainAAddressType, _               = flags.GetString(flagChainAAddressType)
-		chainAKeyName, _                   = flags.GetString(flagChainAKeyName)
-		chainAKeyStoreType, _              = flags.GetString(flagChainAKeyStoreType)
-		chainAStorePrefix, _               = flags.GetString(flagChainAStorePrefix)
-		chainADefaultGas, _                = flags.GetUint64(flagChainADefaultGas)
-		chainAMaxGas, _                    = flags.GetUint64(flagChainAMaxGas)
-		chainAGasPrice, _                  = flags.GetString(flagChainAGasPrice)
-		chainAGasMultiplier, _             = flags.GetString(flagChainAGasMultiplier)
-		chainAMaxMsgNum, _                 = flags.GetUint64(flagChainAMaxMsgNum)
-		chainAMaxTxSize, _                 = flags.GetUint64(flagChainAMaxTxSize)
-		chainAClockDrift, _                = flags.GetString(flagChainAClockDrift)
-		chainAMaxBlockTime, _              = flags.GetString(flagChainAMaxBlockTime)
-		chainATrustingPeriod, _            = flags.GetString(flagChainATrustingPeriod)
-		chainATrustThresholdNumerator, _   = flags.GetUint64(flagChainATrustThresholdNumerator)
-		chainATrustThresholdDenominator, _ = flags.GetUint64(flagChainATrustThresholdDenominator)
-		chainACCVConsumerChain, _          = flags.GetBool(flagChainACCVConsumerChain)
-		chainATrustedNode, _               = flags.GetBool(flagChainATrustedNode)
-		chainAMemoPrefix, _                = flags.GetString(flagChainAMemoPrefix)
-		chainAType, _                      = flags.GetString(flagChainAType)
-		chainASequentialBatchTx, _         = flags.GetBool(flagChainASequentialBatchTx)
-	)
-
-	fmt.Println("aefaefaefeaf _ " + chainAGasMultiplier)
-	chainAGasMulti := new(big.Float)
-	chainAGasMulti, ok := chainAGasMulti.SetString(chainAGasMultiplier)
-	if !ok {
-		return nil, errors.Errorf("invalid chain A gas multiplier: %s", chainAGasMultiplier)
-	}
-
-	optChainA := []hermes.ChainOption{
-		hermes.WithChainTrustThreshold(chainATrustThresholdNumerator, chainATrustThresholdDenominator),
-		hermes.WithChainGasMultiplier(chainAGasMulti),
-		hermes.WithChainCCVConsumerChain(chainACCVConsumerChain),
-		hermes.WithChainTrustedNode(chainATrustedNode),
-		hermes.WithChainSequentialBatchTx(chainASequentialBatchTx),
+	// Load the chains from the args and the chain registry
+	chains := make([]chainDef, 0, len(args)/chainArgsLen+len(registryNames))
+	for i := 0; i < len(args); i += chainArgsLen {
+		chains = append(chains, chainDef{id: args[i], rpcAddr: args[i+1], grpcAddr: args[i+2]})
 	}
-	if chainAEventSourceURL != "" {
-		optChainA = append(optChainA, hermes.WithChainEventSource(
-			chainAEventSourceMode,
-			chainAEventSourceURL,
-			chainAEventSourceBatchDelay,
-		))
-	}
-	if chainARPCTimeout != "" {
-		optChainA = append(optChainA, hermes.WithChainRPCTimeout(chainARPCTimeout))
-	}
-	if chainAAccountPrefix != "" {
-		optChainA = append(optChainA, hermes.WithChainAccountPrefix(chainAAccountPrefix))
-	}
-	if chainAAddressType != "" {
-		optChainA = append(optChainA, hermes.WithChainAddressType(chainAAddressType))
-	}
-	if chainAKeyName != "" {
-		optChainA = append(optChainA, hermes.WithChainKeyName(chainAKeyName))
-	}
-	if chainAKeyStoreType != "" {
-		optChainA = append(optChainA, hermes.WithChainKeyStoreType(chainAKeyStoreType))
-	}
-	if chainAStorePrefix != "" {
-		optChainA = append(optChainA, hermes.WithChainStorePrefix(chainAStorePrefix))
-	}
-	if chainADefaultGas > 0 {
-		optChainA = append(optChainA, hermes.WithChainDefaultGas(chainADefaultGas))
-	}
-	if chainAMaxGas > 0 {
-		optChainA = append(optChainA, hermes.WithChainMaxGas(chainAMaxGas))
-	}
-	if chainAGasPrice != "" {
-		gasPrice, err := sdk.ParseDecCoin(chainAGasPrice)
+	if len(registryNames) > 0 {
+		registryChains, err := loadRegistryChains(ctx, session, registryDir, registryNames)
 		if err != nil {
 			return nil, err
 		}
-		optChainA = append(optChainA, hermes.WithChainGasPrice(gasPrice))
-	}
-	if chainAMaxMsgNum > 0 {
-		optChainA = append(optChainA, hermes.WithChainMaxMsgNum(chainAMaxMsgNum))
-	}
-	if chainAMaxTxSize > 0 {
-		optChainA = append(optChainA, hermes.WithChainMaxTxSize(chainAMaxTxSize))
-	}
-	if chainAClockDrift != "" {
-		optChainA = append(optChainA, hermes.WithChainClockDrift(chainAClockDrift))
-	}
-	if chainAMaxBlockTime != "" {
-		optChainA = append(optChainA, hermes.WithChainMaxBlockTime(chainAMaxBlockTime))
-	}
-	if chainATrustingPeriod != "" {
-		optChainA = append(optChainA, hermes.WithChainTrustingPeriod(chainATrustingPeriod))
+		chains = append(chains, registryChains...)
 	}
-	if chainAMemoPrefix != "" {
-		optChainA = append(optChainA, hermes.WithChainMemoPrefix(chainAMemoPrefix))
+
+	chainIDs := make([]string, 0, len(chains))
+	for _, chain := range chains {
+		chainIDs = append(chainIDs, chain.id)
 	}
-	if chainAType != "" {
-		optChainA = append(optChainA, hermes.WithChainType(chainAType))
+	settings, err := parseChainSettings(flags, chainIDs)
+	if err != nil {
+		return nil, err
 	}
 
-	if _, err := c.AddChain(chainAID, chainARPCAddr, chainAGRPCAddr, optChainA...); err != nil {
-		return nil, err
+	// Add the chains into the config
+	for i, chain := range chains {
+		var (
+			chainID       = chain.id
+			chainRPCAddr  = chain.rpcAddr
+			chainGRPCAddr = chain.grpcAddr
+		)
+		if _, err := c.Chains.Get(chainID); err == nil {
+			return nil, errors.Errorf("duplicated chain %s", chainID)
+		}
+
+		names := flagsForChain(i)
+		chainFlags, chainArgs := settings.apply(flags, osArgs, names, chainID)
+		options, err := newChainOptions(chainFlags, names, chainID)
+		if err != nil {
+			return nil, err
+		}
+		if chain.registry != nil {
+			registryOptions, err := registryChainOptions(names, chainArgs, *chain.registry, chain.assets)
+			if err != nil {
+				return nil, err
+			}
+			options = append(options, registryOptions...)
+		}
+		if discover {
+			session.StartSpinner(fmt.Sprintf("Discovering chain %s params", chainID))
+			params, err := hermes.DiscoverChainParams(ctx, chainRPCAddr, chainGRPCAddr)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to discover the chain %s params", chainID)
+			}
+			if params.ChainID != chainID {
+				return nil, errors.Errorf("the RPC %s runs the chain %s instead of %s", chainRPCAddr, params.ChainID, chainID)
+			}
+			discovered, err := discoveredChainOptions(chainFlags, names, chainArgs, params)
+			if err != nil {
+				return nil, err
+			}
+			options = append(options, discovered...)
+
+			session.StopSpinner()
+			_ = session.Printf(
+				"%s prefix %s, unbonding period %s, fee denom %s, block time %s, SDK %s\n",
+				color.Green.Sprintf("Chain %s discovered:", chainID),
+				params.AccountPrefix,
+				hermes.FormatDuration(params.UnbondingPeriod),
+				params.FeeDenom,
+				params.BlockTime,
+				params.SDKVersion,
+			)
+		}
+		if _, err := c.AddChain(chainID, chainRPCAddr, chainGRPCAddr, options...); err != nil {
+			return nil, err
+		}
 	}
 
-	// Add chain B into the config
+	return c, nil
+}
+
+// newChainOptions creates the chain config options from the chain flags.
+func newChainOptions(flags plugin.Flags, names chainFlags, chainID string) ([]hermes.ChainOption, error) {
 	var (
-		chainBID       = args[3]
-		chainBRPCAddr  = args[4]
-		chainBGRPCAddr = args[5]
-
-		chainBEventSourceMode, _           = flags.GetString(flagChainBEventSourceMode)
-		chainBEventSourceURL, _            = flags.GetString(flagChainBEventSourceURL)
-		chainBEventSourceBatchDelay, _     = flags.GetString(flagChainBEventSourceBatchDelay)
-		chainBRPCTimeout, _                = flags.GetString(flagChainBRPCTimeout)
-		chainBAccountPrefix, _             = flags.GetString(flagChainBAccountPrefix)
-		chainBAddressType, _               = flags.GetString(flagChainBAddressType)
-		chainBKeyName, _                   = flags.GetString(flagChainBKeyName)
-		chainBKeyStoreType, _              = flags.GetString(flagChainBKeyStoreType)
-		chainBStorePrefix, _               = flags.GetString(flagChainBStorePrefix)
-		chainBDefaultGas, _                = flags.GetUint64(flagChainBDefaultGas)
-		chainBMaxGas, _                    = flags.GetUint64(flagChainBMaxGas)
-		chainBGasPrice, _                  = flags.GetString(flagChainBGasPrice)
-		chainBGasMultiplier, _             = flags.GetString(flagChainBGasMultiplier)
-		chainBMaxMsgNum, _                 = flags.GetUint64(flagChainBMaxMsgNum)
-		chainBMaxTxSize, _                 = flags.GetUint64(flagChainBMaxTxSize)
-		chainBClockDrift, _                = flags.GetString(flagChainBClockDrift)
-		chainBMaxBlockTime, _              = flags.GetString(flagChainBMaxBlockTime)
-		chainBTrustingPeriod, _            = flags.GetString(flagChainBTrustingPeriod)
-		chainBTrustThresholdNumerator, _   = flags.GetUint64(flagChainBTrustThresholdNumerator)
-		chainBTrustThresholdDenominator, _ = flags.GetUint64(flagChainBTrustThresholdDenominator)
-		chainBCCVConsumerChain, _          = flags.GetBool(flagChainBCCVConsumerChain)
-		chainBTrustedNode, _               = flags.GetBool(flagChainBTrustedNode)
-		chainBMemoPrefix, _                = flags.GetString(flagChainBMemoPrefix)
-		chainBType, _                      = flags.GetString(flagChainBType)
-		chainBSequentialBatchTx, _         = flags.GetBool(flagChainBSequentialBatchTx)
+		eventSourceMode, _           = flags.GetString(names.eventSourceMode)
+		eventSourceURL, _            = flags.GetString(names.eventSourceURL)
+		eventSourceBatchDelay, _     = flags.GetString(names.eventSourceBatchDelay)
+		rpcTimeout, _                = flags.GetString(names.rpcTimeout)
+		accountPrefix, _             = flags.GetString(names.accountPrefix)
+		addressType, _               = flags.GetString(names.addressType)
+		keyName, _                   = flags.GetString(names.keyName)
+		keyStoreType, _              = flags.GetString(names.keyStoreType)
+		storePrefix, _               = flags.GetString(names.storePrefix)
+		defaultGas, _                = flags.GetUint64(names.defaultGas)
+		maxGas, _                    = flags.GetUint64(names.maxGas)
+		gasPrice, _                  = flags.GetString(names.gasPrice)
+		gasMultiplier, _             = flags.GetString(names.gasMultiplier)
+		maxMsgNum, _                 = flags.GetUint64(names.maxMsgNum)
+		maxTxSize, _                 = flags.GetUint64(names.maxTxSize)
+		clockDrift, _                = flags.GetString(names.clockDrift)
+		maxBlockTime, _              = flags.GetString(names.maxBlockTime)
+		trustingPeriod, _            = flags.GetString(names.trustingPeriod)
+		trustThresholdNumerator, _   = flags.GetUint64(names.trustThresholdNumerator)
+		trustThresholdDenominator, _ = flags.GetUint64(names.trustThresholdDenominator)
+		ccvConsumerChain, _          = flags.GetBool(names.ccvConsumerChain)
+		trustedNode, _               = flags.GetBool(names.trustedNode)
+		memoPrefix, _                = flags.GetString(names.memoPrefix)
+		chainType, _                 = flags.GetString(names.chainType)
+		sequentialBatchTx, _         = flags.GetBool(names.sequentialBatchTx)
+		dynamicGasPrice, _           = flags.GetBool(names.dynamicGasPrice)
+		dynamicGasMultiplier, _      = flags.GetString(names.dynamicGasMultiplier)
+		dynamicGasMax, _             = flags.GetString(names.dynamicGasMax)
+		feeGranter, _                = flags.GetString(names.feeGranter)
+		excludedSequences, _         = flags.GetStringSlice(names.excludedSequences)
+		packetFilterPolicy, _        = flags.GetString(names.packetFilterPolicy)
+		packetFilter, _              = flags.GetStringSlice(names.packetFilter)
+		compatMode, _                = flags.GetString(names.compatMode)
 	)
 
-	chainBGasMulti := new(big.Float)
-	chainBGasMulti, ok = chainBGasMulti.SetString(chainBGasMultiplier)
+	gasMulti := new(big.Float)
+	gasMulti, ok := gasMulti.SetString(gasMultiplier)
 	if !ok {
-		return nil, errors.Errorf("invalid chain B gas multiplier: %s", chainBGasMultiplier)
+		return nil, errors.Errorf("invalid chain %s gas multiplier: %s", chainID, gasMultiplier)
 	}
 
-	optChainB := []hermes.ChainOption{
-		hermes.WithChainTrustThreshold(chainBTrustThresholdNumerator, chainBTrustThresholdDenominator),
-		hermes.WithChainGasMultiplier(chainBGasMulti),
-		hermes.WithChainCCVConsumerChain(chainBCCVConsumerChain),
-		hermes.WithChainTrustedNode(chainBTrustedNode),
-		hermes.WithChainSequentialBatchTx(chainBSequentialBatchTx),
+	options := []hermes.ChainOption{
+		hermes.WithChainTrustThreshold(trustThresholdNumerator, trustThresholdDenominator),
+		hermes.WithChainGasMultiplier(gasMulti),
+		hermes.WithChainCCVConsumerChain(ccvConsumerChain),
+		hermes.WithChainTrustedNode(trustedNode),
+		hermes.WithChainSequentialBatchTx(sequentialBatchTx),
 	}
-	if chainBEventSourceURL != "" {
-		optChainB = append(optChainB, hermes.WithChainEventSource(
-			chainBEventSourceMode,
-			chainBEventSourceURL,
-			chainBEventSourceBatchDelay,
+	if eventSourceURL != "" {
+		options = append(options, hermes.WithChainEventSource(
+			eventSourceMode,
+			eventSourceURL,
+			eventSourceBatchDelay,
 		))
 	}
-	if chainBRPCTimeout != "" {
-		optChainB = append(optChainB, hermes.WithChainRPCTimeout(chainBRPCTimeout))
+	if rpcTimeout != "" {
+		options = append(options, hermes.WithChainRPCTimeout(rpcTimeout))
 	}
-	if chainBAccountPrefix != "" {
-		optChainB = append(optChainB, hermes.WithChainAccountPrefix(chainBAccountPrefix))
+	if accountPrefix != "" {
+		options = append(options, hermes.WithChainAccountPrefix(accountPrefix))
 	}
-	if chainBAddressType != "" {
-		optChainB = append(optChainB, hermes.WithChainAddressType(chainBAddressType))
+	if addressType != "" {
+		options = append(options, hermes.WithChainAddressType(addressType))
 	}
-	if chainBKeyName != "" {
-		optChainB = append(optChainB, hermes.WithChainKeyName(chainBKeyName))
+	if keyName != "" {
+		options = append(options, hermes.WithChainKeyName(keyName))
 	}
-	if chainBKeyStoreType != "" {
-		optChainB = append(optChainB, hermes.WithChainKeyStoreType(chainBKeyStoreType))
+	if keyStoreType != "" {
+		options = append(options, hermes.WithChainKeyStoreType(keyStoreType))
 	}
-	if chainBStorePrefix != "" {
-		optChainB = append(optChainB, hermes.WithChainStorePrefix(chainBStorePrefix))
+	if storePrefix != "" {
+		options = append(options, hermes.WithChainStorePrefix(storePrefix))
 	}
-	if chainBDefaultGas > 0 {
-		optChainB = append(optChainB, hermes.WithChainDefaultGas(chainBDefaultGas))
+	if defaultGas > 0 {
+		options = append(options, hermes.WithChainDefaultGas(defaultGas))
 	}
-	if chainBMaxGas > 0 {
-		optChainB = append(optChainB, hermes.WithChainMaxGas(chainBMaxGas))
+	if maxGas > 0 {
+		options = append(options, hermes.WithChainMaxGas(maxGas))
 	}
-	if chainBGasPrice != "" {
-		gasPrice, err := sdk.ParseDecCoin(chainBGasPrice)
+	if gasPrice != "" {
+		price, err := sdk.ParseDecCoin(gasPrice)
 		if err != nil {
 			return nil, err
 		}
-		optChainB = append(optChainB, hermes.WithChainGasPrice(gasPrice))
+		options = append(options, hermes.WithChainGasPrice(price))
+	}
+	if maxMsgNum > 0 {
+		options = append(options, hermes.WithChainMaxMsgNum(maxMsgNum))
 	}
-	if chainBMaxMsgNum > 0 {
-		optChainB = append(optChainB, hermes.WithChainMaxMsgNum(chainBMaxMsgNum))
+	if maxTxSize > 0 {
+		options = append(options, hermes.WithChainMaxTxSize(maxTxSize))
 	}
-	if chainBMaxTxSize > 0 {
-		optChainB = append(optChainB, hermes.WithChainMaxTxSize(chainBMaxTxSize))
+	if clockDrift != "" {
+		options = append(options, hermes.WithChainClockDrift(clockDrift))
 	}
-	if chainBClockDrift != "" {
-		optChainB = append(optChainB, hermes.WithChainClockDrift(chainBClockDrift))
+	if maxBlockTime != "" {
+		options = append(options, hermes.WithChainMaxBlockTime(maxBlockTime))
 	}
-	if chainBMaxBlockTime != "" {
-		optChainB = append(optChainB, hermes.WithChainMaxBlockTime(chainBMaxBlockTime))
+	if trustingPeriod != "" {
+		options = append(options, hermes.WithChainTrustingPeriod(trustingPeriod))
 	}
-	if chainBTrustingPeriod != "" {
-		optChainB = append(optChainB, hermes.WithChainTrustingPeriod(chainBTrustingPeriod))
+	if memoPrefix != "" {
+		options = append(options, hermes.WithChainMemoPrefix(memoPrefix))
+	}
+	if chainType != "" {
+		options = append(options, hermes.WithChainType(chainType))
+	}
+	if dynamicGasPrice {
+		multiplier, err := strconv.ParseFloat(dynamicGasMultiplier, 64)
+		if err != nil {
+			return nil, errors.Errorf("invalid chain %s dynamic gas multiplier: %s", chainID, dynamicGasMultiplier)
+		}
+		maxPrice, err := strconv.ParseFloat(dynamicGasMax, 64)
+		if err != nil {
+			return nil, errors.Errorf("invalid chain %s dynamic gas max: %s", chainID, dynamicGasMax)
+		}
+		options = append(options, hermes.WithChainDynamicGasPrice(true, multiplier, maxPrice))
+	}
+	if feeGranter != "" {
+		options = append(options, hermes.WithChainFeeGranter(feeGranter))
+	}
+	if len(excludedSequences) > 0 {
+		sequences, err := hermes.ParseExcludedSequences(excludedSequences)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid chain %s excluded sequences", chainID)
+		}
+		options = append(options, hermes.WithChainExcludedSequences(sequences))
+	}
+	if len(packetFilter) > 0 {
+		filters := make([]hermes.PortFilter, 0, len(packetFilter))
+		for _, value := range packetFilter {
+			filter, err := hermes.ParsePortFilter(value)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid chain %s packet filter", chainID)
+			}
+			filters = append(filters, filter)
+		}
+		options = append(options, hermes.WithChainPacketFilter(packetFilterPolicy, filters...))
 	}
-	if chainBMemoPrefix != "" {
-		optChainB = append(optChainB, hermes.WithChainMemoPrefix(chainBMemoPrefix))
+	if compatMode != "" {
+		options = append(options, hermes.WithChainCompatMode(compatMode))
 	}
-	if chainBType != "" {
-		optChainB = append(optChainB, hermes.WithChainType(chainBType))
+	return options, nil
+}
+
+// discoveredChainOptions creates the chain config options from the discovered chain params,
+// skipping the params overridden by the chain flags set in the command line.
+func discoveredChainOptions(
+	flags plugin.Flags,
+	names chainFlags,
+	osArgs []string,
+	params hermes.ChainParams,
+) ([]hermes.ChainOption, error) {
+	options := make([]hermes.ChainOption, 0)
+	if !flagChanged(osArgs, names.accountPrefix) {
+		options = append(options, hermes.WithChainAccountPrefix(params.AccountPrefix))
+	}
+	if !flagChanged(osArgs, names.trustingPeriod) && params.UnbondingPeriod > 0 {
+		options = append(options, hermes.WithChainTrustingPeriod(params.TrustingPeriod()))
+	}
+	if !flagChanged(osArgs, names.maxBlockTime) && params.BlockTime > 0 {
+		options = append(options, hermes.WithChainMaxBlockTime(params.MaxBlockTime()))
+	}
+	if !flagChanged(osArgs, names.gasPrice) && params.FeeDenom != "" {
+		// without node minimum gas price, keep the flag price with the fee denom.
+		gasPrice, _ := flags.GetString(names.gasPrice)
+		flagPrice, err := sdk.ParseDecCoin(gasPrice)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, hermes.WithChainGasPrice(params.GasPrice(flagPrice.Amount)))
 	}
+	return options, nil
+}
+
+// chainDef represents a chain to add into the config, from the args or the chain registry.
+type chainDef struct {
+	id       string
+	rpcAddr  string
+	grpcAddr string
+	registry *hermes.RegistryChain
+	assets   hermes.RegistryAssetList
+}
 
-	if _, err := c.AddChain(chainBID, chainBRPCAddr, chainBGRPCAddr, optChainB...); err != nil {
+// loadRegistryChains loads the chains by name from the chain registry local checkout
+// or the cached download.
+func loadRegistryChains(ctx context.Context, session *cliui.Session, registryDir string, names []string) ([]chainDef, error) {
+	registry, err := hermes.NewRegistry(hermes.WithRegistryDir(registryDir))
+	if err != nil {
 		return nil, err
 	}
 
-	return c, nil
+	chains := make([]chainDef, 0, len(names))
+	for _, name := range names {
+		session.StartSpinner(fmt.Sprintf("Loading chain %s from the chain registry", name))
+		chain, assets, err := registry.Chain(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		rpcAddr, err := chain.RPCAddr()
+		if err != nil {
+			return nil, err
+		}
+		grpcAddr, err := chain.GRPCAddr()
+		if err != nil {
+			return nil, err
+		}
+
+		session.StopSpinner()
+		_ = session.Printf(
+			"%s %s (RPC %s, gRPC %s)\n",
+			color.Green.Sprintf("Chain %s loaded from the chain registry:", name),
+			chain.ChainID,
+			rpcAddr,
+			grpcAddr,
+		)
+		chains = append(chains, chainDef{
+			id:       chain.ChainID,
+			rpcAddr:  rpcAddr,
+			grpcAddr: grpcAddr,
+			registry: &chain,
+			assets:   assets,
+		})
+	}
+	return chains, nil
+}
+
+// registryChainOptions creates the chain config options from the chain registry,
+// skipping the values overridden by the chain flags set in the command line.
+func registryChainOptions(
+	names chainFlags,
+	osArgs []string,
+	chain hermes.RegistryChain,
+	assets hermes.RegistryAssetList,
+) ([]hermes.ChainOption, error) {
+	options := make([]hermes.ChainOption, 0)
+	if !flagChanged(osArgs, names.accountPrefix) && chain.Bech32Prefix != "" {
+		options = append(options, hermes.WithChainAccountPrefix(chain.Bech32Prefix))
+	}
+	if !flagChanged(osArgs, names.gasPrice) {
+		price, err := chain.GasPrice(assets)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, hermes.WithChainGasPrice(price))
+	}
+	return options, nil
 }
@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gookit/color"
+	"github.com/ignite/cli/v28/ignite/pkg/cliui"
+	"github.com/ignite/cli/v28/ignite/pkg/errors"
+	"github.com/ignite/cli/v28/ignite/services/plugin"
+
+	"github.com/ignite/apps/hermes/pkg/daemon"
+	"github.com/ignite/apps/hermes/pkg/hermes"
+)
+
+const (
+	// daemonDirName is the directory name of the relayer daemons into the config dir.
+	daemonDirName = "daemon"
+	// daemonStopTimeout is the time to wait for the relayer daemon to stop.
+	daemonStopTimeout = 30 * time.Second
+)
+
+func StopHandler(ctx context.Context, cmd *plugin.ExecutedCommand) error {
+	session := cliui.New(cliui.StartSpinnerWithText("Stopping the Hermes relayer"))
+	defer session.End()
+
+	d, cfgName, err := getDaemon(cmd.Flags, cmd.Args)
+	if err != nil {
+		return err
+	}
+	if err := d.Stop(ctx, daemonStopTimeout); errors.Is(err, daemon.ErrNotRunning) {
+		return errors.Errorf("Hermes relayer %s is not running", cfgName)
+	} else if err != nil {
+		return err
+	}
+
+	session.StopSpinner()
+	return session.Println(color.Green.Sprintf("Hermes relayer %s stopped", cfgName))
+}
+
+func StatusHandler(_ context.Context, cmd *plugin.ExecutedCommand) error {
+	session := cliui.New()
+	defer session.End()
+
+	d, cfgName, err := getDaemon(cmd.Flags, cmd.Args)
+	if err != nil {
+		return err
+	}
+	status, err := d.Status()
+	if err != nil {
+		return err
+	}
+	if !status.Running {
+		return session.Println(color.Yellow.Sprintf("Hermes relayer %s is not running", cfgName))
+	}
+
+	state := status.State
+	entries := [][]string{
+		{"Config", cfgName},
+		{"Supervisor PID", fmt.Sprint(state.SupervisorPID)},
+		{"Hermes PID", fmt.Sprint(state.PID)},
+		{"Started at", state.StartedAt.Format(time.RFC3339)},
+		{"Uptime", time.Since(state.LastStart).Round(time.Second).String()},
+		{"Restarts", fmt.Sprint(state.Restarts)},
+		{"Log file", d.LogPath()},
+	}
+	if state.PID == 0 {
+		entries[2][1] = color.Yellow.Sprint("restarting")
+	}
+	if state.LastExitError != "" {
+		entries = append(entries, []string{"Last exit", color.Red.Sprint(state.LastExitError)})
+	}
+	return session.PrintTable([]string{"Hermes relayer", color.Green.Sprint("running")}, entries...)
+}
+
+func LogsHandler(ctx context.Context, cmd *plugin.ExecutedCommand) error {
+	var (
+		flags     = plugin.Flags(cmd.Flags)
+		follow, _ = flags.GetBool(flagFollow)
+		lines, _  = flags.GetUint64(flagLines)
+	)
+
+	d, _, err := getDaemon(flags, cmd.Args)
+	if err != nil {
+		return err
+	}
+	if err := d.Tail(os.Stdout, int(lines)); err != nil {
+		return err
+	}
+	if !follow {
+		return nil
+	}
+
+	ctx, cancel := signal.NotifyContext(ctx, os.Interrupt)
+	defer cancel()
+	return d.Follow(ctx, os.Stdout)
+}
+
+// startDaemon starts the Hermes relayer as a supervised background process.
+func startDaemon(h *hermes.Hermes, d *daemon.Daemon, cfgName, cfgPath string) error {
+	session := cliui.New()
+	defer session.End()
+
+	pid, err := d.Start(h.StartCommand(hermes.WithConfigFile(cfgPath)))
+	if errors.Is(err, daemon.ErrRunning) {
+		return errors.Errorf("Hermes relayer %s is already running, stop it first", cfgName)
+	} else if err != nil {
+		return err
+	}
+
+	_ = session.Println(color.Green.Sprintf("Hermes relayer %s started in background (supervisor PID %d)", cfgName, pid))
+	return session.Printf("Logs are written to %s\n", d.LogPath())
+}
+
+// getDaemon returns the relayer daemon keyed by the config name from the
+// custom config flag or the chain IDs args.
+func getDaemon(flags plugin.Flags, args []string) (*daemon.Daemon, string, error) {
+	cfgPath := getConfig(flags)
+	if cfgPath == "" {
+		if len(args) == 0 {
+			return nil, "", errors.Errorf("the chain IDs or the --%s flag are required", flagConfig)
+		}
+		var err error
+		cfgPath, err = hermes.ConfigPath(strings.Join(args, hermes.ConfigNameSeparator))
+		if err != nil {
+			return nil, "", err
+		}
+	}
+	return newDaemon(cfgPath)
+}
+
+// newDaemon returns the relayer daemon keyed by the config name.
+func newDaemon(cfgPath string) (*daemon.Daemon, string, error) {
+	configDir, err := hermes.ConfigDir()
+	if err != nil {
+		return nil, "", err
+	}
+	cfgName := filepath.Base(cfgPath)
+	return daemon.New(filepath.Join(configDir, daemonDirName, cfgName)), cfgName, nil
+}
@@ -10,7 +10,7 @@ import (
 )
 
 func ExecuteHandler(ctx context.Context, cmd *plugin.ExecutedCommand) error {
-	h, err := hermes.New()
+	h, err := newHermes(cmd.Flags)
 	if err != nil {
 		return err
 	}
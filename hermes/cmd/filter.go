@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gookit/color"
+	"github.com/ignite/cli/v28/ignite/pkg/cliui"
+	"github.com/ignite/cli/v28/ignite/pkg/errors"
+	"github.com/ignite/cli/v28/ignite/services/plugin"
+
+	"github.com/ignite/apps/hermes/pkg/hermes"
+)
+
+func FilterAllowHandler(ctx context.Context, cmd *plugin.ExecutedCommand) error {
+	return setPacketFilter(ctx, cmd, hermes.PacketFilterAllow)
+}
+
+func FilterDenyHandler(ctx context.Context, cmd *plugin.ExecutedCommand) error {
+	return setPacketFilter(ctx, cmd, hermes.PacketFilterDeny)
+}
+
+func FilterListHandler(_ context.Context, cmd *plugin.ExecutedCommand) error {
+	flags := plugin.Flags(cmd.Flags)
+	if len(cmd.Args) == 0 && getConfig(flags) == "" {
+		return errors.Errorf("the chain ID or the --%s flag are required", flagConfig)
+	}
+	cfgPath, err := getConfigPath(flags, cmd.Args...)
+	if err != nil {
+		return err
+	}
+	hermesCfg, err := hermes.LoadConfig(cfgPath)
+	if err != nil {
+		return err
+	}
+
+	session := cliui.New()
+	defer session.End()
+
+	entries := make([][]string, 0)
+	for _, chain := range hermesCfg.Chains {
+		if len(cmd.Args) > 0 && chain.ID != cmd.Args[0] {
+			continue
+		}
+		filter := chain.PacketFilter
+		switch {
+		case filter.IsEmpty():
+			entries = append(entries, []string{chain.ID, "-", "*", "*"})
+		case len(filter.List) == 0:
+			entries = append(entries, []string{chain.ID, filter.Policy, "-", "-"})
+		default:
+			for _, f := range filter.List {
+				entries = append(entries, []string{chain.ID, filter.Policy, f.Port(), f.Channel()})
+			}
+		}
+	}
+	return session.PrintTable([]string{"Chain", "Policy", "Port", "Channel"}, entries...)
+}
+
+// setPacketFilter allows or denies the packets of the channel in the chain packet filter,
+// checking the channel exists on the chain first.
+func setPacketFilter(ctx context.Context, cmd *plugin.ExecutedCommand, policy string) error {
+	var (
+		flags      = plugin.Flags(cmd.Flags)
+		offline, _ = flags.GetBool(flagOffline)
+	)
+	if len(cmd.Args) != 3 {
+		return errors.Errorf("expected the chain ID, port ID and channel ID, got %v", cmd.Args)
+	}
+	var (
+		chainID = cmd.Args[0]
+		filter  = hermes.PortFilter{cmd.Args[1], cmd.Args[2]}
+	)
+	if err := filter.Validate(); err != nil {
+		return err
+	}
+
+	cfgPath, err := getConfigPath(flags, chainID)
+	if err != nil {
+		return err
+	}
+	hermesCfg, err := hermes.LoadConfig(cfgPath)
+	if err != nil {
+		return err
+	}
+	chain, err := hermesCfg.Chains.Get(chainID)
+	if err != nil {
+		return err
+	}
+
+	session := cliui.New()
+	defer session.End()
+
+	if !offline {
+		session.StartSpinner(fmt.Sprintf("Looking for the %s channels on %s", filter, chainID))
+		h, err := newHermes(cmd.Flags)
+		if err != nil {
+			return err
+		}
+		defer h.Cleanup()
+
+		channels, err := h.Channels(ctx, chainID, "", hermes.WithConfigFile(cfgPath))
+		if err != nil {
+			return err
+		}
+		matches := 0
+		for _, channel := range channels {
+			if filter.Match(channel.PortID, channel.ChannelID) {
+				matches++
+			}
+		}
+		session.StopSpinner()
+		switch {
+		case matches > 0:
+		case filter.IsWildcard():
+			_ = session.Println(color.Yellow.Sprintf("No channel matches %s on %s yet", filter, chainID))
+		default:
+			return errors.Errorf("channel %s not found on %s", filter, chainID)
+		}
+	}
+
+	var changed bool
+	if policy == hermes.PacketFilterAllow {
+		changed, err = chain.PacketFilter.Allow(filter)
+	} else {
+		changed, err = chain.PacketFilter.Deny(filter)
+	}
+	if err != nil {
+		return err
+	}
+	action := "allowed"
+	if policy == hermes.PacketFilterDeny {
+		action = "denied"
+	}
+	if !changed {
+		return session.Printf("The %s packets are already %s on %s\n", filter, action, chainID)
+	}
+
+	if err := hermesCfg.Chains.Set(chain); err != nil {
+		return err
+	}
+	if err := hermesCfg.SaveFile(cfgPath); err != nil {
+		return err
+	}
+
+	if chain.PacketFilter.Policy == hermes.PacketFilterAllow && len(chain.PacketFilter.List) == 0 {
+		_ = session.Println(color.Yellow.Sprintf("The %s allow list is empty, no packet is relayed", chainID))
+	}
+	return session.Println(color.Green.Sprintf(
+		"The %s packets are %s on %s, restart the relayer to apply the filter",
+		filter,
+		action,
+		chainID,
+	))
+}
@@ -1,7 +1,13 @@
 package cmd
 
 import (
+	"slices"
+	"strings"
+
+	"github.com/ignite/cli/v28/ignite/pkg/errors"
 	"github.com/ignite/cli/v28/ignite/services/plugin"
+
+	"github.com/ignite/apps/hermes/pkg/hermes"
 )
 
 const (
@@ -32,6 +38,14 @@ const (
 	flagChainAMemoPrefix                = "chain-a-memo-prefix"
 	flagChainAType                      = "chain-a-type"
 	flagChainASequentialBatchTx         = "chain-a-sequential-batch-tx"
+	flagChainADynamicGasPrice           = "chain-a-dynamic-gas-price"
+	flagChainADynamicGasMultiplier      = "chain-a-dynamic-gas-multiplier"
+	flagChainADynamicGasMax             = "chain-a-dynamic-gas-max"
+	flagChainAFeeGranter                = "chain-a-fee-granter"
+	flagChainAExcludedSequences         = "chain-a-excluded-sequences"
+	flagChainAPacketFilterPolicy        = "chain-a-packet-filter-policy"
+	flagChainAPacketFilter              = "chain-a-packet-filter"
+	flagChainACompatMode                = "chain-a-compat-mode"
 
 	flagChainBPortID                    = "chain-b-port-id"
 	flagChainBEventSourceMode           = "chain-b-event-source-mode"
@@ -60,6 +74,14 @@ const (
 	flagChainBMemoPrefix                = "chain-b-memo-prefix"
 	flagChainBType                      = "chain-b-type"
 	flagChainBSequentialBatchTx         = "chain-b-sequential-batch-tx"
+	flagChainBDynamicGasPrice           = "chain-b-dynamic-gas-price"
+	flagChainBDynamicGasMultiplier      = "chain-b-dynamic-gas-multiplier"
+	flagChainBDynamicGasMax             = "chain-b-dynamic-gas-max"
+	flagChainBFeeGranter                = "chain-b-fee-granter"
+	flagChainBExcludedSequences         = "chain-b-excluded-sequences"
+	flagChainBPacketFilterPolicy        = "chain-b-packet-filter-policy"
+	flagChainBPacketFilter              = "chain-b-packet-filter"
+	flagChainBCompatMode                = "chain-b-compat-mode"
 
 	flagTelemetryEnabled              = "telemetry-enabled"
 	flagTelemetryHost                 = "telemetry-host"
@@ -80,13 +102,320 @@ const (
 	flagGenerateWallets               = "generate-wallets"
 	flagOverwriteConfig               = "overwrite-config"
 	flagChannelVersion                = "channel-version"
+	flagPath                          = "path"
+	flagYes                           = "yes"
+	flagReuse                         = "reuse"
+	flagJSON                          = "json"
+	flagUpdate                        = "update"
+	flagExpiryThreshold               = "expiry-threshold"
+	flagDetach                        = "detach"
+	flagFollow                        = "follow"
+	flagLines                         = "lines"
+	flagInterval                      = "interval"
+	flagThreshold                     = "threshold"
+	flagFaucet                        = "faucet"
+	flagFundingKey                    = "funding-key"
+	flagTopUp                         = "top-up"
+	flagKeyringBackend                = "keyring-backend"
+	flagKeyringDir                    = "keyring-dir"
+	flagOffline                       = "offline"
+	flagDiscover                      = "discover"
+	flagFromRegistry                  = "from-registry"
+	flagRegistryDir                   = "registry-dir"
+	flagChainSet                      = "chain-set"
+	flagChainAID                      = "chain-a-id"
+	flagChainBID                      = "chain-b-id"
+	flagChannel                       = "channel"
+	flagPort                          = "port"
+	flagAmount                        = "amount"
+	flagTimeout                       = "timeout"
+	flagFromKeyring                   = "from-keyring"
+	flagOverwrite                     = "overwrite"
+	flagAll                           = "all"
+	flagDryRun                        = "dry-run"
+	flagKeepKeys                      = "keep-keys"
+	flagOutput                        = "output"
 
-	flagConfig = "config"
+	flagConfig        = "config"
+	flagHermesVersion = "hermes-version"
+	flagHermesPath    = "hermes-path"
 
 	mnemonicEntropySize = 256
 )
 
+// chainFlags represents the flag names used to configure a chain.
+type chainFlags struct {
+	prefix                    string
+	portID                    string
+	eventSourceMode           string
+	eventSourceURL            string
+	eventSourceBatchDelay     string
+	rpcTimeout                string
+	accountPrefix             string
+	addressType               string
+	keyName                   string
+	keyStoreType              string
+	storePrefix               string
+	defaultGas                string
+	maxGas                    string
+	gasPrice                  string
+	gasMultiplier             string
+	maxMsgNum                 string
+	maxTxSize                 string
+	clockDrift                string
+	maxBlockTime              string
+	trustingPeriod            string
+	trustThresholdNumerator   string
+	trustThresholdDenominator string
+	faucet                    string
+	ccvConsumerChain          string
+	trustedNode               string
+	memoPrefix                string
+	chainType                 string
+	sequentialBatchTx         string
+	dynamicGasPrice           string
+	dynamicGasMultiplier      string
+	dynamicGasMax             string
+	feeGranter                string
+	excludedSequences         string
+	packetFilterPolicy        string
+	packetFilter              string
+	compatMode                string
+}
+
+var (
+	chainAFlags = chainFlags{
+		prefix:                    "chain-a-",
+		portID:                    flagChainAPortID,
+		eventSourceMode:           flagChainAEventSourceMode,
+		eventSourceURL:            flagChainAEventSourceURL,
+		eventSourceBatchDelay:     flagChainAEventSourceBatchDelay,
+		rpcTimeout:                flagChainARPCTimeout,
+		accountPrefix:             flagChainAAccountPrefix,
+		addressType:               flagChainAAddressType,
+		keyName:                   flagChainAKeyName,
+		keyStoreType:              flagChainAKeyStoreType,
+		storePrefix:               flagChainAStorePrefix,
+		defaultGas:                flagChainADefaultGas,
+		maxGas:                    flagChainAMaxGas,
+		gasPrice:                  flagChainAGasPrice,
+		gasMultiplier:             flagChainAGasMultiplier,
+		maxMsgNum:                 flagChainAMaxMsgNum,
+		maxTxSize:                 flagChainAMaxTxSize,
+		clockDrift:                flagChainAClockDrift,
+		maxBlockTime:              flagChainAMaxBlockTime,
+		trustingPeriod:            flagChainATrustingPeriod,
+		trustThresholdNumerator:   flagChainATrustThresholdNumerator,
+		trustThresholdDenominator: flagChainATrustThresholdDenominator,
+		faucet:                    flagChainAFaucet,
+		ccvConsumerChain:          flagChainACCVConsumerChain,
+		trustedNode:               flagChainATrustedNode,
+		memoPrefix:                flagChainAMemoPrefix,
+		chainType:                 flagChainAType,
+		sequentialBatchTx:         flagChainASequentialBatchTx,
+		dynamicGasPrice:           flagChainADynamicGasPrice,
+		dynamicGasMultiplier:      flagChainADynamicGasMultiplier,
+		dynamicGasMax:             flagChainADynamicGasMax,
+		feeGranter:                flagChainAFeeGranter,
+		excludedSequences:         flagChainAExcludedSequences,
+		packetFilterPolicy:        flagChainAPacketFilterPolicy,
+		packetFilter:              flagChainAPacketFilter,
+		compatMode:                flagChainACompatMode,
+	}
+	chainBFlags = chainFlags{
+		prefix:                    "chain-b-",
+		portID:                    flagChainBPortID,
+		eventSourceMode:           flagChainBEventSourceMode,
+		eventSourceURL:            flagChainBEventSourceURL,
+		eventSourceBatchDelay:     flagChainBEventSourceBatchDelay,
+		rpcTimeout:                flagChainBRPCTimeout,
+		accountPrefix:             flagChainBAccountPrefix,
+		addressType:               flagChainBAddressType,
+		keyName:                   flagChainBKeyName,
+		keyStoreType:              flagChainBKeyStoreType,
+		storePrefix:               flagChainBStorePrefix,
+		defaultGas:                flagChainBDefaultGas,
+		maxGas:                    flagChainBMaxGas,
+		gasPrice:                  flagChainBGasPrice,
+		gasMultiplier:             flagChainBGasMultiplier,
+		maxMsgNum:                 flagChainBMaxMsgNum,
+		maxTxSize:                 flagChainBMaxTxSize,
+		clockDrift:                flagChainBClockDrift,
+		maxBlockTime:              flagChainBMaxBlockTime,
+		trustingPeriod:            flagChainBTrustingPeriod,
+		trustThresholdNumerator:   flagChainBTrustThresholdNumerator,
+		trustThresholdDenominator: flagChainBTrustThresholdDenominator,
+		faucet:                    flagChainBFaucet,
+		ccvConsumerChain:          flagChainBCCVConsumerChain,
+		trustedNode:               flagChainBTrustedNode,
+		memoPrefix:                flagChainBMemoPrefix,
+		chainType:                 flagChainBType,
+		sequentialBatchTx:         flagChainBSequentialBatchTx,
+		dynamicGasPrice:           flagChainBDynamicGasPrice,
+		dynamicGasMultiplier:      flagChainBDynamicGasMultiplier,
+		dynamicGasMax:             flagChainBDynamicGasMax,
+		feeGranter:                flagChainBFeeGranter,
+		excludedSequences:         flagChainBExcludedSequences,
+		packetFilterPolicy:        flagChainBPacketFilterPolicy,
+		packetFilter:              flagChainBPacketFilter,
+		compatMode:                flagChainBCompatMode,
+	}
+)
+
+// flagsForChain returns the flag names of the chain by its position in the configure args.
+// The first chain uses the chain A flags and all other chains use the chain B flags.
+func flagsForChain(index int) chainFlags {
+	if index == 0 {
+		return chainAFlags
+	}
+	return chainBFlags
+}
+
+// settings returns the names of the chain flags that can be set by chain with the chain-set
+// flag. The port ID is set by the relayer paths.
+func (n chainFlags) settings() []string {
+	return []string{
+		n.eventSourceMode,
+		n.eventSourceURL,
+		n.eventSourceBatchDelay,
+		n.rpcTimeout,
+		n.accountPrefix,
+		n.addressType,
+		n.keyName,
+		n.keyStoreType,
+		n.storePrefix,
+		n.defaultGas,
+		n.maxGas,
+		n.gasPrice,
+		n.gasMultiplier,
+		n.maxMsgNum,
+		n.maxTxSize,
+		n.clockDrift,
+		n.maxBlockTime,
+		n.trustingPeriod,
+		n.trustThresholdNumerator,
+		n.trustThresholdDenominator,
+		n.faucet,
+		n.ccvConsumerChain,
+		n.trustedNode,
+		n.memoPrefix,
+		n.chainType,
+		n.sequentialBatchTx,
+		n.dynamicGasPrice,
+		n.dynamicGasMultiplier,
+		n.dynamicGasMax,
+		n.feeGranter,
+		n.excludedSequences,
+		n.packetFilterPolicy,
+		n.packetFilter,
+		n.compatMode,
+	}
+}
+
+// chainSettings represents the chain-set flag values by chain ID and setting name.
+type chainSettings map[string]map[string][]string
+
+// parseChainSettings parses the chain-set flag values in the format <chain-id>:<setting>=<value>,
+// where the setting is a chain flag name without the chain-a- or chain-b- prefix, e.g.
+// venus-1:gas-price=0.025uvenus. The values of a repeated setting are appended, like the list
+// flags of the packet filters.
+func parseChainSettings(flags plugin.Flags, chainIDs []string) (chainSettings, error) {
+	values, _ := flags.GetStringSlice(flagChainSet)
+
+	settings := make(chainSettings)
+	for _, v := range values {
+		key, value, ok := strings.Cut(v, "=")
+		sep := strings.LastIndex(key, ":")
+		if !ok || sep <= 0 || sep == len(key)-1 || value == "" {
+			return nil, errors.Errorf("invalid --%s value %s, expected <chain-id>:<setting>=<value>", flagChainSet, v)
+		}
+		chainID, setting := key[:sep], key[sep+1:]
+		if !slices.Contains(chainIDs, chainID) {
+			return nil, errors.Errorf("invalid --%s value %s: chain %s not found in the chain args", flagChainSet, v, chainID)
+		}
+		if !slices.Contains(chainBFlags.settings(), chainBFlags.prefix+setting) {
+			return nil, errors.Errorf("invalid --%s value %s: unknown chain setting %s", flagChainSet, v, setting)
+		}
+		if settings[chainID] == nil {
+			settings[chainID] = make(map[string][]string)
+		}
+		settings[chainID][setting] = append(settings[chainID][setting], value)
+	}
+	return settings, nil
+}
+
+// apply returns the chain flags and command line args with the chain settings replacing the
+// values of the chain A or B flags, so the settings are handled as flags set in the command line.
+func (s chainSettings) apply(flags plugin.Flags, osArgs []string, names chainFlags, chainID string) (plugin.Flags, []string) {
+	settings := s[chainID]
+	if len(settings) == 0 {
+		return flags, osArgs
+	}
+
+	chainFlags := make(plugin.Flags, 0, len(flags))
+	chainArgs := slices.Clone(osArgs)
+	for _, flag := range flags {
+		values, ok := settings[strings.TrimPrefix(flag.Name, names.prefix)]
+		if !ok || !strings.HasPrefix(flag.Name, names.prefix) {
+			chainFlags = append(chainFlags, flag)
+			continue
+		}
+		chainFlags = append(chainFlags, &plugin.Flag{
+			Name:         flag.Name,
+			Type:         flag.Type,
+			DefaultValue: flag.DefaultValue,
+			Value:        strings.Join(values, ","),
+		})
+		chainArgs = append(chainArgs, "--"+flag.Name)
+	}
+	return chainFlags, chainArgs
+}
+
+// flagChanged returns true if the flag is set in the command line args.
+func flagChanged(osArgs []string, name string) bool {
+	for _, arg := range osArgs {
+		if arg == "--"+name || strings.HasPrefix(arg, "--"+name+"=") {
+			return true
+		}
+	}
+	return false
+}
+
 func getConfig(flags plugin.Flags) string {
 	config, _ := flags.GetString(flagConfig)
 	return config
 }
+
+// getConfigPath returns the custom config flag or the config file containing all the chains.
+func getConfigPath(flags plugin.Flags, chainIDs ...string) (string, error) {
+	if customCfg := getConfig(flags); customCfg != "" {
+		return customCfg, nil
+	}
+	return findConfig(chainIDs...)
+}
+
+// findConfig returns the config file containing all the chains, asking for the
+// config flag if more than one config contains the chains.
+func findConfig(chainIDs ...string) (string, error) {
+	cfgPath, err := hermes.FindConfig(chainIDs...)
+	if errors.Is(err, hermes.ErrAmbiguousConfig) {
+		return "", errors.Wrapf(err, "use the --%s flag to select the config", flagConfig)
+	}
+	return cfgPath, err
+}
+
+// newHermes returns the Hermes binary from the --hermes-path or --hermes-version flags,
+// or the embedded binary by default.
+func newHermes(flags plugin.Flags) (*hermes.Hermes, error) {
+	var (
+		path, _    = flags.GetString(flagHermesPath)
+		version, _ = flags.GetString(flagHermesVersion)
+	)
+	if path != "" && version != "" {
+		return nil, errors.Errorf("the --%s and --%s flags cannot be used together", flagHermesPath, flagHermesVersion)
+	}
+	if path != "" {
+		return hermes.New(hermes.WithBinaryPath(path))
+	}
+	return hermes.New(hermes.WithVersion(version))
+}
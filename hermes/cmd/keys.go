@@ -2,34 +2,171 @@ package cmd
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"os"
+	"strings"
 
+	"github.com/cosmos/go-bip39"
+	"github.com/gookit/color"
+	"github.com/ignite/cli/v28/ignite/pkg/cliui"
+	"github.com/ignite/cli/v28/ignite/pkg/cliui/cliquiz"
+	"github.com/ignite/cli/v28/ignite/pkg/cosmosaccount"
+	"github.com/ignite/cli/v28/ignite/pkg/errors"
 	"github.com/ignite/cli/v28/ignite/services/plugin"
 
 	"github.com/ignite/apps/hermes/pkg/hermes"
 )
 
+// maxMnemonicSize is the max size of a mnemonic read from the stdin.
+const maxMnemonicSize = 4096
+
 func KeysAddMnemonicHandler(ctx context.Context, cmd *plugin.ExecutedCommand) error {
-	args := cmd.Args
-	h, err := hermes.New()
-	if err != nil {
-		return err
+	var (
+		args              = cmd.Args
+		flags             = plugin.Flags(cmd.Flags)
+		fromKeyring, _    = flags.GetString(flagFromKeyring)
+		keyringBackend, _ = flags.GetString(flagKeyringBackend)
+		keyringDir, _     = flags.GetString(flagKeyringDir)
+		overwrite, _      = flags.GetBool(flagOverwrite)
+	)
+	if len(args) == 0 {
+		return errors.New("the chain ID is required")
 	}
-	defer h.Cleanup()
+	chainID := args[0]
 
-	return h.AddMnemonic(
-		ctx,
-		args[0],
-		args[1],
+	session := cliui.New()
+	defer session.End()
+
+	options := []hermes.Option{
 		hermes.WithStdIn(os.Stdin),
 		hermes.WithStdOut(os.Stdout),
 		hermes.WithStdErr(os.Stderr),
-	)
+	}
+	cfgPath, err := getConfigPath(flags, chainID)
+	if err == nil {
+		options = append(options, hermes.WithConfigFile(cfgPath))
+	} else if fromKeyring != "" {
+		return err
+	}
+
+	if fromKeyring != "" {
+		if len(args) > 1 {
+			return errors.Errorf("the mnemonic argument cannot be used with the --%s flag", flagFromKeyring)
+		}
+		return importKeyringKey(ctx, session, flags, cfgPath, chainID, fromKeyring, keyringBackend, keyringDir, overwrite)
+	}
+
+	var mnemonic string
+	switch {
+	case len(args) > 1:
+		mnemonic = strings.Join(args[1:], " ")
+		_ = session.Println(color.Yellow.Sprint(
+			"Passing the mnemonic as argument exposes it in the shell history and the process list, " +
+				"prefer the stdin or the prompt",
+		))
+	case isPipe(os.Stdin):
+		data, err := io.ReadAll(io.LimitReader(os.Stdin, maxMnemonicSize))
+		if err != nil {
+			return errors.Wrap(err, "failed to read the mnemonic from the stdin")
+		}
+		mnemonic = string(data)
+	default:
+		if err := session.Ask(cliquiz.NewQuestion(
+			fmt.Sprintf("Type the chain %s key mnemonic:", chainID),
+			&mnemonic,
+			cliquiz.HideAnswer(),
+			cliquiz.Required(),
+		)); err != nil {
+			return err
+		}
+	}
+
+	mnemonic = strings.Join(strings.Fields(mnemonic), " ")
+	if !bip39.IsMnemonicValid(mnemonic) {
+		return errors.New("invalid mnemonic")
+	}
+	if overwrite {
+		options = append(options, hermes.WithFlags(hermes.Flags{hermes.FlagOverwrite: true}))
+	}
+
+	// the stdin was already consumed by the mnemonic.
+	options[0] = hermes.WithStdIn(strings.NewReader(""))
+	h, err := newHermes(cmd.Flags)
+	if err != nil {
+		return err
+	}
+	defer h.Cleanup()
+
+	return h.AddMnemonic(ctx, chainID, mnemonic, options...)
+}
+
+// importKeyringKey imports the key by name from the Ignite or Cosmos keyring as the chain Hermes key
+// and checks Hermes reads it back from its keyring.
+func importKeyringKey(
+	ctx context.Context,
+	session *cliui.Session,
+	flags plugin.Flags,
+	cfgPath,
+	chainID,
+	name,
+	keyringBackend,
+	keyringDir string,
+	overwrite bool,
+) error {
+	cfg, err := hermes.LoadConfig(cfgPath)
+	if err != nil {
+		return err
+	}
+	chain, err := cfg.Chains.Get(chainID)
+	if err != nil {
+		return err
+	}
+
+	keyringOptions := []cosmosaccount.Option{cosmosaccount.WithKeyringBackend(cosmosaccount.KeyringBackend(keyringBackend))}
+	if keyringDir != "" {
+		keyringOptions = append(keyringOptions, cosmosaccount.WithHome(keyringDir))
+	}
+	privKey, err := hermes.ExportKeyringKey(name, keyringOptions...)
+	if err != nil {
+		return errors.Wrapf(err, "failed to export the key %s from the keyring", name)
+	}
+	pair, err := hermes.NewKeyPair(privKey, chain.AccountPrefix)
+	if err != nil {
+		return err
+	}
+	h, err := newHermes(flags)
+	if err != nil {
+		return err
+	}
+	defer h.Cleanup()
+
+	keyPath, err := chain.ImportKey(chain.KeyName, pair, overwrite)
+	if err != nil {
+		return err
+	}
+	keys, err := h.Keys(ctx, chainID, hermes.WithConfigFile(cfgPath))
+	if err != nil {
+		return errors.Wrapf(err, "failed to list the chain %s Hermes keys", chainID)
+	}
+	if key, ok := keys[chain.KeyName]; !ok || key.Account != pair.Account {
+		return errors.Errorf("Hermes cannot read the chain %s key %s imported into %s", chainID, chain.KeyName, keyPath)
+	}
+	return session.Println(color.Green.Sprintf("Chain %s key %s imported from the keyring key %s: %s", chainID, chain.KeyName, name, pair.Account))
+}
+
+// isPipe returns true if the file is not a terminal, e.g. a pipe or a redirected file.
+func isPipe(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice == 0
 }
 
 func KeysAddFileHandler(ctx context.Context, cmd *plugin.ExecutedCommand) error {
 	args := cmd.Args
-	h, err := hermes.New()
+	h, err := newHermes(cmd.Flags)
 	if err != nil {
 		return err
 	}
@@ -47,7 +184,7 @@ func KeysAddFileHandler(ctx context.Context, cmd *plugin.ExecutedCommand) error
 
 func KeysListHandler(ctx context.Context, cmd *plugin.ExecutedCommand) error {
 	args := cmd.Args
-	h, err := hermes.New()
+	h, err := newHermes(cmd.Flags)
 	if err != nil {
 		return err
 	}
@@ -63,7 +200,7 @@ func KeysListHandler(ctx context.Context, cmd *plugin.ExecutedCommand) error {
 }
 
 func KeysDeleteHandler(ctx context.Context, cmd *plugin.ExecutedCommand) error {
-	h, err := hermes.New()
+	h, err := newHermes(cmd.Flags)
 	if err != nil {
 		return err
 	}
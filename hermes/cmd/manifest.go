@@ -0,0 +1,247 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/gookit/color"
+	"github.com/ignite/cli/v28/ignite/pkg/cliui"
+	"github.com/ignite/cli/v28/ignite/pkg/errors"
+	"github.com/ignite/cli/v28/ignite/services/plugin"
+	"github.com/manifoldco/promptui"
+
+	"github.com/ignite/apps/hermes/pkg/hermes"
+)
+
+const (
+	// planActionNone indicates the path already exists on-chain.
+	planActionNone = "none"
+
+	// planNewID is shown for the IBC identifiers not created yet.
+	planNewID = "(new)"
+)
+
+func PlanHandler(ctx context.Context, cmd *plugin.ExecutedCommand) error {
+	session := cliui.New(cliui.StartSpinnerWithText("Loading the relayer manifest"))
+	defer session.End()
+
+	manifest, hermesCfg, err := loadManifest(cmd.Args)
+	if err != nil {
+		return err
+	}
+
+	cfgPath, err := hermesCfg.ConfigPath()
+	if err != nil {
+		return err
+	}
+
+	tmpCfgPath, cleanup, err := saveTempConfig(hermesCfg)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	h, err := newHermes(cmd.Flags)
+	if err != nil {
+		return err
+	}
+	defer h.Cleanup()
+
+	states, err := planPaths(ctx, session, h, tmpCfgPath, manifest.RelayerPaths())
+	if err != nil {
+		return err
+	}
+
+	session.StopSpinner()
+	if _, err := os.Stat(cfgPath); os.IsNotExist(err) {
+		_ = session.Printf("Hermes config will be created at %s\n", cfgPath)
+	} else {
+		_ = session.Printf("Hermes config will be updated at %s\n", cfgPath)
+	}
+	return printPlan(session, states)
+}
+
+func ApplyHandler(ctx context.Context, cmd *plugin.ExecutedCommand) error {
+	var (
+		flags              = plugin.Flags(cmd.Flags)
+		generateWallets, _ = flags.GetBool(flagGenerateWallets)
+		yes, _             = flags.GetBool(flagYes)
+	)
+
+	session := cliui.New(cliui.StartSpinnerWithText("Loading the relayer manifest"))
+	defer session.End()
+
+	manifest, hermesCfg, err := loadManifest(cmd.Args)
+	if err != nil {
+		return err
+	}
+
+	cfgPath, err := hermesCfg.ConfigPath()
+	if err != nil {
+		return err
+	}
+	tmpCfgPath, cleanup, err := saveTempConfig(hermesCfg)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	h, err := newHermes(cmd.Flags)
+	if err != nil {
+		return err
+	}
+	defer h.Cleanup()
+
+	states, err := planPaths(ctx, session, h, tmpCfgPath, manifest.RelayerPaths())
+	if err != nil {
+		return err
+	}
+
+	pending := make([]pathState, 0)
+	for _, state := range states {
+		if len(state.missing()) > 0 {
+			pending = append(pending, state)
+		}
+	}
+
+	session.StopSpinner()
+	if err := printPlan(session, states); err != nil {
+		return err
+	}
+	if len(pending) > 0 && !yes {
+		if err := session.AskConfirm(fmt.Sprintf("Reconcile %d relayer path(s)", len(pending))); err != nil {
+			if errors.Is(err, promptui.ErrAbort) {
+				return errors.New("apply canceled")
+			}
+			return err
+		}
+	}
+
+	// the manifest is the source of truth, so the config is always rewritten.
+	if err := hermesCfg.Save(); err != nil {
+		return err
+	}
+	_ = session.Println(color.Green.Sprintf("Hermes config saved at %s", cfgPath))
+	if len(pending) == 0 {
+		return nil
+	}
+
+	for _, chain := range hermesCfg.Chains {
+		manifestChain, err := manifest.Chain(chain.ID)
+		if err != nil {
+			return err
+		}
+
+		session.StartSpinner(fmt.Sprintf("Verifying chain %s keys", chain.ID))
+		if err := ensureAccount(
+			ctx,
+			session,
+			hermesCfg,
+			h,
+			chain.ID,
+			manifestChain.Faucet,
+			fmt.Sprintf("the chain %s faucet of the manifest", chain.ID),
+			cfgPath,
+			generateWallets,
+		); err != nil {
+			return err
+		}
+	}
+
+	for _, state := range pending {
+		if err := createPath(ctx, session, h, cfgPath, state); err != nil {
+			return errors.Wrapf(err, "failed to create the path %s", state.path)
+		}
+	}
+
+	session.StopSpinner()
+	_ = session.Println(color.Green.Sprintf("Relayer manifest applied, %d path(s) reconciled", len(pending)))
+	return nil
+}
+
+// loadManifest loads the relayer manifest from the command args and creates the Hermes config.
+func loadManifest(args []string) (hermes.Manifest, *hermes.Config, error) {
+	if len(args) != 1 {
+		return hermes.Manifest{}, nil, errors.New("the relayer manifest path is required")
+	}
+	manifest, err := hermes.LoadManifest(args[0])
+	if err != nil {
+		return hermes.Manifest{}, nil, err
+	}
+	hermesCfg, err := manifest.Config()
+	if err != nil {
+		return hermes.Manifest{}, nil, err
+	}
+	return manifest, hermesCfg, nil
+}
+
+// saveTempConfig saves the Hermes config into a temporary file to run the plan queries
+// without touching the current config. The cleanup function removes the file.
+func saveTempConfig(hermesCfg *hermes.Config) (string, func(), error) {
+	tmpDir, err := os.MkdirTemp("", "hermes-plan")
+	if err != nil {
+		return "", nil, err
+	}
+	cleanup := func() { _ = os.RemoveAll(tmpDir) }
+
+	tmpCfgPath := filepath.Join(tmpDir, "config.toml")
+	if err := hermesCfg.SaveFile(tmpCfgPath); err != nil {
+		cleanup()
+		return "", nil, err
+	}
+	return tmpCfgPath, cleanup, nil
+}
+
+// planPaths queries the existing clients, connections and channels of each path to find out what must be created.
+func planPaths(
+	ctx context.Context,
+	session *cliui.Session,
+	h *hermes.Hermes,
+	cfgPath string,
+	paths hermes.Paths,
+) ([]pathState, error) {
+	states := make([]pathState, 0, len(paths))
+	for _, path := range paths {
+		session.StartSpinner(fmt.Sprintf("Querying path %s", path))
+		state, err := queryPathState(ctx, h, cfgPath, path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to query the path %s", path)
+		}
+		states = append(states, state)
+	}
+	return states, nil
+}
+
+// printPlan prints the paths plan table.
+func printPlan(session *cliui.Session, states []pathState) error {
+	entries := make([][]string, 0, len(states))
+	for _, state := range states {
+		entries = append(entries, []string{
+			state.path.String(),
+			planID(state.clientA),
+			planID(state.clientB),
+			planIDs(state.connectionA, state.connectionB),
+			planIDs(state.channelA, state.channelB),
+			pathAction(state),
+		})
+	}
+	return session.PrintTable([]string{"Path", "Client A", "Client B", "Connection", "Channel", "Action"}, entries...)
+}
+
+// planID returns the IBC identifier or planNewID if it does not exist.
+func planID(id string) string {
+	if id == "" {
+		return planNewID
+	}
+	return id
+}
+
+// planIDs returns both IBC identifiers of a connection or channel.
+func planIDs(idA, idB string) string {
+	if idA == "" {
+		return planNewID
+	}
+	return fmt.Sprintf("%s <-> %s", idA, idB)
+}
@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gookit/color"
+	"github.com/ignite/cli/v28/ignite/pkg/cliui"
+	"github.com/ignite/cli/v28/ignite/pkg/errors"
+	"github.com/ignite/cli/v28/ignite/services/plugin"
+
+	"github.com/ignite/apps/hermes/pkg/hermes"
+)
+
+const (
+	// clearScreen is the ANSI sequence to move the cursor home and clear the terminal.
+	clearScreen = "\033[H\033[2J"
+	// hermesComponent is the name of the Hermes relayer into the REST API versions.
+	hermesComponent = "ibc-relayer"
+)
+
+func MetricsHandler(ctx context.Context, cmd *plugin.ExecutedCommand) error {
+	var (
+		flags       = plugin.Flags(cmd.Flags)
+		interval, _ = flags.GetString(flagInterval)
+	)
+
+	refresh, err := time.ParseDuration(interval)
+	if err != nil {
+		return errors.Wrapf(err, "invalid refresh interval %s", interval)
+	}
+
+	if len(cmd.Args) == 0 && getConfig(flags) == "" {
+		return errors.Errorf("the chain IDs or the --%s flag are required", flagConfig)
+	}
+	cfgPath, err := getConfigPath(flags, cmd.Args...)
+	if err != nil {
+		return err
+	}
+	hermesCfg, err := hermes.LoadConfig(cfgPath)
+	if err != nil {
+		return err
+	}
+	if !hermesCfg.Telemetry.Enabled {
+		return errors.Errorf(
+			"the telemetry is disabled into the Hermes config %s, configure the relayer with the --%s flag",
+			cfgPath,
+			flagTelemetryEnabled,
+		)
+	}
+
+	session := cliui.New()
+	defer session.End()
+
+	if refresh == 0 {
+		return printMetrics(ctx, session, hermesCfg)
+	}
+
+	ctx, cancel := signal.NotifyContext(ctx, os.Interrupt)
+	defer cancel()
+	for {
+		fmt.Print(clearScreen)
+		if err := printMetrics(ctx, session, hermesCfg); err != nil {
+			_ = session.Println(color.Red.Sprint(err))
+		}
+		_ = session.Printf("\nUpdated at %s, refreshing every %s (press Ctrl+C to exit)\n", time.Now().Format(time.TimeOnly), refresh)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(refresh):
+		}
+	}
+}
+
+// printMetrics prints the Hermes version and workers from the REST API, if enabled,
+// and the chain metrics scraped from the telemetry endpoint.
+func printMetrics(ctx context.Context, session *cliui.Session, cfg *hermes.Config) error {
+	if cfg.Rest.Enabled {
+		header, err := restHeader(ctx, hermes.NewRESTClient(cfg.RestURL()))
+		if err != nil {
+			_ = session.Println(color.Yellow.Sprint(err))
+		} else {
+			_ = session.Println(header)
+		}
+	}
+
+	metrics, err := hermes.ScrapeMetrics(ctx, cfg.TelemetryURL())
+	if err != nil {
+		return err
+	}
+
+	byChain := make(map[string]hermes.ChainMetrics)
+	for _, m := range metrics {
+		byChain[m.ChainID] = m
+	}
+	entries := make([][]string, 0, len(cfg.Chains))
+	for _, chain := range cfg.Chains {
+		m, ok := byChain[chain.ID]
+		if !ok {
+			m = hermes.ChainMetrics{ChainID: chain.ID}
+		}
+		entries = append(entries, chainMetricsRow(m))
+	}
+	return session.PrintTable(
+		[]string{"Chain", "Wallet balance", "Msgs submitted", "Txs failed", "Relay latency", "Backlog"},
+		entries...,
+	)
+}
+
+// restHeader returns the Hermes version and running workers from the REST API.
+func restHeader(ctx context.Context, client *hermes.RESTClient) (string, error) {
+	versions, err := client.Version(ctx)
+	if err != nil {
+		return "", err
+	}
+	state, err := client.State(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	version := "unknown"
+	for _, v := range versions {
+		if v.Name == hermesComponent {
+			version = v.Version
+		}
+	}
+
+	count := state.WorkersCount()
+	kinds := make([]string, 0, len(count))
+	for kind := range count {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	workers := make([]string, 0, len(kinds))
+	for _, kind := range kinds {
+		workers = append(workers, fmt.Sprintf("%s %d", kind, count[kind]))
+	}
+	if len(workers) == 0 {
+		workers = append(workers, "none")
+	}
+	return fmt.Sprintf("Hermes %s - workers: %s\n", version, strings.Join(workers, ", ")), nil
+}
+
+// chainMetricsRow returns the chain metrics table row, highlighting the failed txs and the backlog.
+func chainMetricsRow(m hermes.ChainMetrics) []string {
+	balances := make([]string, 0, len(m.Balances))
+	for _, b := range m.Balances {
+		balances = append(balances, b.String())
+	}
+	balance := strings.Join(balances, ", ")
+	if balance == "" {
+		balance = "-"
+	}
+
+	failed := fmt.Sprint(m.TxFailed)
+	if m.TxFailed > 0 {
+		failed = color.Red.Sprint(failed)
+	}
+
+	latency := "-"
+	if m.RelayLatency > 0 {
+		latency = m.RelayLatency.Round(time.Millisecond).String()
+	}
+
+	backlog := fmt.Sprint(m.Backlog)
+	if m.Backlog > 0 {
+		backlog = color.Yellow.Sprintf("%d (oldest #%d)", m.Backlog, m.BacklogOldestSequence)
+	}
+
+	return []string{m.ChainID, balance, fmt.Sprint(m.MsgsSubmitted), failed, latency, backlog}
+}
@@ -0,0 +1,207 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gookit/color"
+	"github.com/ignite/cli/v28/ignite/pkg/cliui"
+	"github.com/ignite/cli/v28/ignite/pkg/cosmosclient"
+	"github.com/ignite/cli/v28/ignite/pkg/errors"
+	"github.com/ignite/cli/v28/ignite/services/plugin"
+
+	"github.com/ignite/apps/hermes/pkg/hermes"
+)
+
+const (
+	packetTypePacket = "packet"
+	packetTypeAck    = "ack"
+
+	// packetUnknown is shown for the packet fields not found in the chain tx index.
+	packetUnknown = "-"
+)
+
+// channelEnd represents one end of a channel.
+type channelEnd struct {
+	chainID   string
+	portID    string
+	channelID string
+}
+
+func PacketsPendingHandler(ctx context.Context, cmd *plugin.ExecutedCommand) error {
+	session := cliui.New(cliui.StartSpinnerWithText("Querying pending packets"))
+	defer session.End()
+
+	end, hermesCfg, cfgPath, err := loadChannelEnd(cmd)
+	if err != nil {
+		return err
+	}
+
+	h, err := newHermes(cmd.Flags)
+	if err != nil {
+		return err
+	}
+	defer h.Cleanup()
+
+	counterparty, err := findCounterparty(ctx, h, cfgPath, end)
+	if err != nil {
+		return err
+	}
+
+	pending, err := h.PendingPackets(ctx, end.chainID, end.portID, end.channelID, hermes.WithConfigFile(cfgPath))
+	if err != nil {
+		return err
+	}
+
+	var (
+		now     = time.Now()
+		entries = make([][]string, 0)
+	)
+	// the pending packets and acks of each side belong to the packets sent by the side chain.
+	for _, side := range []struct {
+		end     channelEnd
+		pending hermes.PendingPackets
+	}{
+		{end: end, pending: pending.Src},
+		{end: counterparty, pending: pending.Dst},
+	} {
+		chain, err := hermesCfg.Chains.Get(side.end.chainID)
+		if err != nil {
+			return err
+		}
+		// the packets timeout and age are unknown if the chain node is unreachable.
+		var client *cosmosclient.Client
+		if c, err := chain.NewClient(ctx); err == nil {
+			client = &c
+		}
+		for _, kind := range []struct {
+			packetType string
+			sequences  []uint64
+		}{
+			{packetType: packetTypePacket, sequences: side.pending.UnreceivedPackets},
+			{packetType: packetTypeAck, sequences: side.pending.UnreceivedAcks},
+		} {
+			for _, sequence := range kind.sequences {
+				session.StartSpinner(fmt.Sprintf("Querying %s %s sequence %d", side.end.chainID, kind.packetType, sequence))
+				entries = append(entries, packetRow(ctx, client, side.end, kind.packetType, sequence, now))
+			}
+		}
+	}
+
+	session.StopSpinner()
+	if len(entries) == 0 {
+		_ = session.Println(color.Green.Sprintf(
+			"No pending packets on %s (%s/%s) <-> %s (%s/%s)",
+			end.chainID, end.portID, end.channelID,
+			counterparty.chainID, counterparty.portID, counterparty.channelID,
+		))
+		return nil
+	}
+	return session.PrintTable([]string{"Chain", "Port", "Channel", "Type", "Sequence", "Timeout", "Age"}, entries...)
+}
+
+func PacketsClearHandler(ctx context.Context, cmd *plugin.ExecutedCommand) error {
+	session := cliui.New(cliui.StartSpinnerWithText("Clearing pending packets"))
+	defer session.End()
+
+	end, _, cfgPath, err := loadChannelEnd(cmd)
+	if err != nil {
+		return err
+	}
+
+	h, err := newHermes(cmd.Flags)
+	if err != nil {
+		return err
+	}
+	defer h.Cleanup()
+
+	bufClear := bytes.Buffer{}
+	if err := h.ClearPackets(
+		ctx,
+		end.chainID,
+		end.portID,
+		end.channelID,
+		hermes.WithConfigFile(cfgPath),
+		hermes.WithStdOut(&bufClear),
+		hermes.WithJSONOutput(),
+	); err != nil {
+		return err
+	}
+	if err := hermes.ValidateResult(bufClear.Bytes()); err != nil {
+		return err
+	}
+
+	session.StopSpinner()
+	_ = session.Println(color.Green.Sprintf("Packets cleared on %s (%s/%s)", end.chainID, end.portID, end.channelID))
+	return nil
+}
+
+// loadChannelEnd parses the [chain-id] [port-id] [channel-id] args and loads the chain config.
+func loadChannelEnd(cmd *plugin.ExecutedCommand) (channelEnd, *hermes.Config, string, error) {
+	if len(cmd.Args) != 3 {
+		return channelEnd{}, nil, "", errors.New("the chain, port and channel IDs are required")
+	}
+	end := channelEnd{
+		chainID:   cmd.Args[0],
+		portID:    cmd.Args[1],
+		channelID: cmd.Args[2],
+	}
+
+	cfgPath, err := getConfigPath(cmd.Flags, end.chainID)
+	if err != nil {
+		return channelEnd{}, nil, "", err
+	}
+	hermesCfg, err := hermes.LoadConfig(cfgPath)
+	if err != nil {
+		return channelEnd{}, nil, "", err
+	}
+	return end, hermesCfg, cfgPath, nil
+}
+
+// findCounterparty returns the counterparty end of a channel.
+func findCounterparty(ctx context.Context, h *hermes.Hermes, cfgPath string, end channelEnd) (channelEnd, error) {
+	channels, err := h.Channels(ctx, end.chainID, "", hermes.WithConfigFile(cfgPath))
+	if err != nil {
+		return channelEnd{}, err
+	}
+	for _, channel := range channels {
+		if channel.PortID == end.portID && channel.ChannelID == end.channelID {
+			return channelEnd{
+				chainID:   channel.CounterpartyChainID,
+				portID:    channel.CounterpartyPortID,
+				channelID: channel.CounterpartyChannelID,
+			}, nil
+		}
+	}
+	return channelEnd{}, errors.Errorf("channel %s/%s not found on chain %s", end.portID, end.channelID, end.chainID)
+}
+
+// packetRow returns the pending packet table row. The timeout and age are unknown
+// if the chain client is nil or the packet cannot be found in the chain tx index.
+func packetRow(
+	ctx context.Context,
+	client *cosmosclient.Client,
+	end channelEnd,
+	packetType string,
+	sequence uint64,
+	now time.Time,
+) []string {
+	row := []string{end.chainID, end.portID, end.channelID, packetType, strconv.FormatUint(sequence, 10)}
+
+	if client == nil {
+		return append(row, packetUnknown, packetUnknown)
+	}
+	packet, err := hermes.SentPacket(ctx, *client, end.portID, end.channelID, sequence)
+	if err != nil {
+		return append(row, packetUnknown, packetUnknown)
+	}
+
+	timeout := packet.Timeout()
+	if packet.TimedOut(now) {
+		timeout = color.Red.Sprintf("%s (expired)", timeout)
+	}
+	return append(row, timeout, now.Sub(packet.SentAt).Round(time.Second).String())
+}
@@ -0,0 +1,258 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ignite/cli/v28/ignite/pkg/cliui"
+
+	"github.com/ignite/apps/hermes/pkg/hermes"
+)
+
+const (
+	pathClientA    = "client A"
+	pathClientB    = "client B"
+	pathConnection = "connection"
+	pathChannel    = "channel"
+)
+
+// pathState represents the IBC clients, connection and channel of a relayer path
+// already found on-chain. Empty identifiers must be created.
+type pathState struct {
+	path        hermes.Path
+	clientA     string
+	clientB     string
+	connectionA string
+	connectionB string
+	channelA    string
+	channelB    string
+}
+
+// missing returns the path pieces not found on-chain.
+func (s pathState) missing() []string {
+	missing := make([]string, 0)
+	if s.clientA == "" {
+		missing = append(missing, pathClientA)
+	}
+	if s.clientB == "" {
+		missing = append(missing, pathClientB)
+	}
+	if s.connectionA == "" {
+		missing = append(missing, pathConnection)
+	}
+	if s.channelA == "" {
+		missing = append(missing, pathChannel)
+	}
+	return missing
+}
+
+// existing returns a description of the path pieces found on-chain.
+func (s pathState) existing() []string {
+	existing := make([]string, 0)
+	if s.clientA != "" {
+		existing = append(existing, fmt.Sprintf("%s '%s' (%s -> %s)", pathClientA, s.clientA, s.path.ChainA, s.path.ChainB))
+	}
+	if s.clientB != "" {
+		existing = append(existing, fmt.Sprintf("%s '%s' (%s -> %s)", pathClientB, s.clientB, s.path.ChainB, s.path.ChainA))
+	}
+	if s.connectionA != "" {
+		existing = append(existing, fmt.Sprintf("%s '%s <-> %s'", pathConnection, s.connectionA, s.connectionB))
+	}
+	if s.channelA != "" {
+		existing = append(existing, fmt.Sprintf("%s '%s <-> %s'", pathChannel, s.channelA, s.channelB))
+	}
+	return existing
+}
+
+// queryPathState queries the existing channel, connection and clients between the path chains.
+// The clients and connection of an existing channel or connection are reused as well. Closed or
+// half-open channels and connections and inactive clients are skipped, so they are created again.
+func queryPathState(ctx context.Context, h *hermes.Hermes, cfgPath string, path hermes.Path) (pathState, error) {
+	state := pathState{path: path}
+
+	channel, err := findChannel(ctx, h, cfgPath, path)
+	if err != nil {
+		return pathState{}, err
+	}
+	if channel != nil {
+		state.clientA, state.clientB = channel.ClientID, channel.CounterpartyClientID
+		state.connectionA, state.connectionB = channel.ConnectionID, channel.CounterpartyConnectionID
+		state.channelA, state.channelB = channel.ChannelID, channel.CounterpartyChannelID
+		return state, nil
+	}
+
+	connection, err := findConnection(ctx, h, cfgPath, path)
+	if err != nil {
+		return pathState{}, err
+	}
+	if connection != nil {
+		state.clientA, state.clientB = connection.ConnectionEnd.ClientID, connection.ConnectionEnd.Counterparty.ClientID
+		state.connectionA, state.connectionB = connection.ConnectionID, connection.ConnectionEnd.Counterparty.ConnectionID
+		return state, nil
+	}
+
+	if state.clientA, err = findClient(ctx, h, cfgPath, path.ChainA, path.ChainB); err != nil {
+		return pathState{}, err
+	}
+	if state.clientB, err = findClient(ctx, h, cfgPath, path.ChainB, path.ChainA); err != nil {
+		return pathState{}, err
+	}
+	return state, nil
+}
+
+// findChannel returns the latest open channel between the path ends or nil if it does not exist.
+// The channel is only reused if both channel and connection ends are open and both clients are active.
+func findChannel(ctx context.Context, h *hermes.Hermes, cfgPath string, path hermes.Path) (*hermes.ChannelEndsResult, error) {
+	channels, err := h.Channels(ctx, path.ChainA, path.ChainB, hermes.WithConfigFile(cfgPath))
+	if err != nil {
+		return nil, err
+	}
+	for i := len(channels) - 1; i >= 0; i-- {
+		channel := channels[i]
+		if channel.PortID != path.PortA ||
+			channel.CounterpartyPortID != path.PortB ||
+			channel.CounterpartyChannelID == "" {
+			continue
+		}
+		open, err := channelOpen(ctx, h, cfgPath, path, channel)
+		if err != nil {
+			return nil, err
+		}
+		if open {
+			return &channel, nil
+		}
+	}
+	return nil, nil
+}
+
+// findConnection returns the latest open connection between the path chains or nil if it does not exist.
+// The connection is only reused if both connection ends are open and both clients are active.
+func findConnection(ctx context.Context, h *hermes.Hermes, cfgPath string, path hermes.Path) (*hermes.ConnectionEndsResult, error) {
+	connections, err := h.Connections(ctx, path.ChainA, path.ChainB, hermes.WithConfigFile(cfgPath))
+	if err != nil {
+		return nil, err
+	}
+	for i := len(connections) - 1; i >= 0; i-- {
+		connection := connections[i]
+		if connection.ConnectionEnd.State != hermes.StateOpen ||
+			connection.ConnectionEnd.Counterparty.ConnectionID == "" {
+			continue
+		}
+		open, err := connectionOpen(
+			ctx,
+			h,
+			cfgPath,
+			path,
+			connection.ConnectionID,
+			connection.ConnectionEnd.Counterparty.ConnectionID,
+			connection.ConnectionEnd.ClientID,
+			connection.ConnectionEnd.Counterparty.ClientID,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if open {
+			return &connection, nil
+		}
+	}
+	return nil, nil
+}
+
+// channelOpen returns true if both channel ends are open, as well as their connection and clients.
+func channelOpen(ctx context.Context, h *hermes.Hermes, cfgPath string, path hermes.Path, channel hermes.ChannelEndsResult) (bool, error) {
+	ends := []struct{ chain, portID, channelID string }{
+		{chain: path.ChainA, portID: channel.PortID, channelID: channel.ChannelID},
+		{chain: path.ChainB, portID: channel.CounterpartyPortID, channelID: channel.CounterpartyChannelID},
+	}
+	for _, end := range ends {
+		channelEnd, err := h.ChannelEnd(ctx, end.chain, end.portID, end.channelID, hermes.WithConfigFile(cfgPath))
+		if err != nil {
+			return false, err
+		}
+		if channelEnd.State != hermes.StateOpen {
+			return false, nil
+		}
+	}
+	return connectionOpen(
+		ctx,
+		h,
+		cfgPath,
+		path,
+		channel.ConnectionID,
+		channel.CounterpartyConnectionID,
+		channel.ClientID,
+		channel.CounterpartyClientID,
+	)
+}
+
+// connectionOpen returns true if both connection ends are open and both clients are active,
+// not expired or frozen.
+func connectionOpen(
+	ctx context.Context,
+	h *hermes.Hermes,
+	cfgPath string,
+	path hermes.Path,
+	connectionA,
+	connectionB,
+	clientA,
+	clientB string,
+) (bool, error) {
+	ends := []struct{ chain, connectionID, clientID string }{
+		{chain: path.ChainA, connectionID: connectionA, clientID: clientA},
+		{chain: path.ChainB, connectionID: connectionB, clientID: clientB},
+	}
+	for _, end := range ends {
+		connectionEnd, err := h.ConnectionEnd(ctx, end.chain, end.connectionID, hermes.WithConfigFile(cfgPath))
+		if err != nil {
+			return false, err
+		}
+		if connectionEnd.State != hermes.StateOpen || connectionEnd.ClientID != end.clientID {
+			return false, nil
+		}
+		status, err := h.ClientStatus(ctx, end.chain, end.clientID, hermes.WithConfigFile(cfgPath))
+		if err != nil {
+			return false, err
+		}
+		if status != hermes.ClientStatusActive {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// findClient returns the latest active client hosted by the host chain tracking the reference
+// chain or an empty string if it does not exist.
+func findClient(ctx context.Context, h *hermes.Hermes, cfgPath, hostChain, referenceChain string) (string, error) {
+	clients, err := h.Clients(ctx, hostChain, referenceChain, hermes.WithConfigFile(cfgPath))
+	if err != nil {
+		return "", err
+	}
+	for i := len(clients) - 1; i >= 0; i-- {
+		status, err := h.ClientStatus(ctx, hostChain, clients[i].ClientID, hermes.WithConfigFile(cfgPath))
+		if err != nil {
+			return "", err
+		}
+		if status == hermes.ClientStatusActive {
+			return clients[i].ClientID, nil
+		}
+	}
+	return "", nil
+}
+
+// printPathState prints the path pieces found on-chain.
+func printPathState(session *cliui.Session, state pathState) {
+	_ = session.Printf("Found on-chain for the path %s:\n", state.path)
+	for _, existing := range state.existing() {
+		_ = session.Printf("  - %s\n", existing)
+	}
+}
+
+// pathAction returns the action required to reconcile the path.
+func pathAction(state pathState) string {
+	missing := state.missing()
+	if len(missing) == 0 {
+		return planActionNone
+	}
+	return fmt.Sprintf("create %s", strings.Join(missing, ", "))
+}
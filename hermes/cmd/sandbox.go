@@ -0,0 +1,358 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	sdkmath "cosmossdk.io/math"
+	"github.com/cosmos/go-bip39"
+	"github.com/gookit/color"
+	"github.com/ignite/cli/v28/ignite/pkg/availableport"
+	"github.com/ignite/cli/v28/ignite/pkg/cliui"
+	cmdexec "github.com/ignite/cli/v28/ignite/pkg/cmdrunner/exec"
+	"github.com/ignite/cli/v28/ignite/pkg/errors"
+	"github.com/ignite/cli/v28/ignite/services/plugin"
+
+	"github.com/ignite/apps/hermes/pkg/daemon"
+	"github.com/ignite/apps/hermes/pkg/hermes"
+	"github.com/ignite/apps/hermes/pkg/sandbox"
+)
+
+const (
+	// sandboxDirName is the directory name of the sandbox chains into the config dir.
+	sandboxDirName = "sandbox"
+	// sandboxPort is the port of the sandbox transfer channel.
+	sandboxPort = "transfer"
+	// sandboxStartTimeout is the time to wait for a sandbox chain to produce blocks.
+	sandboxStartTimeout = 2 * time.Minute
+	// sandboxLogLines is the number of chain log lines shown when a chain fails to start.
+	sandboxLogLines = 20
+	// sandboxConfigPrefix is the name prefix of the sandbox Hermes config, so it never
+	// replaces a saved config of the same chains.
+	sandboxConfigPrefix = "sandbox-"
+	// sandboxKeyName is the Hermes key name of the sandbox relayer accounts, so the keys
+	// of a saved config of the same chains are never replaced.
+	sandboxKeyName = "sandbox-relayer"
+)
+
+// sandboxGasPrice is the relayer gas price of the sandbox chains without a node minimum gas price.
+var sandboxGasPrice = sdkmath.LegacyMustNewDecFromStr("0.01")
+
+func SandboxHandler(ctx context.Context, cmd *plugin.ExecutedCommand) error {
+	var (
+		flags       = plugin.Flags(cmd.Flags)
+		chainAID, _ = flags.GetString(flagChainAID)
+		chainBID, _ = flags.GetString(flagChainBID)
+	)
+	if len(cmd.Args) != 2 {
+		return errors.Errorf("expected the two app paths, got %d args", len(cmd.Args))
+	}
+	if chainAID == chainBID {
+		return errors.Errorf("the sandbox chains must have different IDs, got %s", chainAID)
+	}
+
+	dir, err := sandboxDir()
+	if err != nil {
+		return err
+	}
+	if _, err := sandbox.LoadState(dir); err == nil {
+		return errors.New("a sandbox already exists, tear it down first with the sandbox teardown command")
+	} else if !errors.Is(err, sandbox.ErrNotFound) {
+		return err
+	}
+
+	session := cliui.New(cliui.StartSpinnerWithText("Starting the IBC sandbox"))
+	defer session.End()
+
+	h, err := newHermes(flags)
+	if err != nil {
+		return err
+	}
+	defer h.Cleanup()
+
+	if err := runSandbox(ctx, session, h, dir, cmd.Args, []string{chainAID, chainBID}); err != nil {
+		return errors.Wrap(err, "failed to start the sandbox, clean it up with the sandbox teardown command")
+	}
+	return nil
+}
+
+// runSandbox starts the chains from the app paths, configures Hermes with a transfer
+// channel between them and starts the relayer daemon. The sandbox state is saved
+// after each step, so a failed sandbox can be torn down.
+func runSandbox(
+	ctx context.Context,
+	session *cliui.Session,
+	h *hermes.Hermes,
+	dir string,
+	appPaths,
+	chainIDs []string,
+) error {
+	ignite, err := exec.LookPath("ignite")
+	if err != nil {
+		return errors.Wrap(err, "ignite binary not found")
+	}
+	ports, err := availableport.Find(uint(sandbox.PortsPerChain * len(appPaths)))
+	if err != nil {
+		return err
+	}
+
+	var (
+		state     sandbox.State
+		mnemonics = make([]string, len(appPaths))
+	)
+	for i, appPath := range appPaths {
+		chainID := chainIDs[i]
+		if mnemonics[i], err = newMnemonic(); err != nil {
+			return err
+		}
+
+		session.StartSpinner(fmt.Sprintf("Initializing chain %s from %s", chainID, appPath))
+		chain, err := sandbox.PrepareChain(
+			appPath,
+			chainID,
+			filepath.Join(dir, chainID),
+			mnemonics[i],
+			ports[i*sandbox.PortsPerChain:(i+1)*sandbox.PortsPerChain],
+		)
+		if err != nil {
+			return err
+		}
+		state.Chains = append(state.Chains, chain)
+		if err := sandbox.SaveState(dir, state); err != nil {
+			return err
+		}
+
+		if err := cmdexec.Exec(ctx, []string{
+			ignite, "chain", "init",
+			"--path", chain.AppPath,
+			"--config", chain.ConfigPath,
+			"--home", chain.Home,
+			"--skip-proto",
+			"--yes",
+		}, cmdexec.IncludeStdLogsToError()); err != nil {
+			return errors.Wrapf(err, "failed to initialize the chain %s", chainID)
+		}
+		if chain.Binary, err = sandbox.FindBinary(chain); err != nil {
+			return err
+		}
+		state.Chains[i] = chain
+		if err := sandbox.SaveState(dir, state); err != nil {
+			return err
+		}
+
+		session.StartSpinner(fmt.Sprintf("Starting chain %s", chainID))
+		d := daemon.New(chain.DaemonDir())
+		if _, err := d.Start(chain.StartCommand()); err != nil {
+			return errors.Wrapf(err, "failed to start the chain %s", chainID)
+		}
+		if err := sandbox.WaitBlock(ctx, chain.RPCAddr, 2, sandboxStartTimeout); err != nil {
+			session.StopSpinner()
+			var logs bytes.Buffer
+			_ = d.Tail(&logs, sandboxLogLines)
+			_ = session.Printf("%s\n%s", color.Red.Sprintf("Chain %s logs:", chainID), logs.String())
+			return err
+		}
+
+		session.StopSpinner()
+		_ = session.Println(color.Green.Sprintf("Chain %s started (RPC %s, gRPC %s)", chainID, chain.RPCAddr, chain.GRPCAddr))
+	}
+
+	session.StartSpinner("Generating Hermes config")
+	hermesCfg := hermes.DefaultConfig()
+	for _, chain := range state.Chains {
+		params, err := hermes.DiscoverChainParams(ctx, chain.RPCAddr, chain.GRPCAddr)
+		if err != nil {
+			return errors.Wrapf(err, "failed to discover the chain %s params", chain.ID)
+		}
+		if _, err := hermesCfg.AddChain(
+			chain.ID,
+			chain.RPCAddr,
+			chain.GRPCAddr,
+			hermes.WithChainAccountPrefix(params.AccountPrefix),
+			hermes.WithChainGasPrice(params.GasPrice(sandboxGasPrice)),
+			hermes.WithChainTrustingPeriod(params.TrustingPeriod()),
+			hermes.WithChainMaxBlockTime(params.MaxBlockTime()),
+			hermes.WithChainKeyName(sandboxKeyName),
+		); err != nil {
+			return err
+		}
+	}
+	cfgName, err := hermesCfg.ConfigName()
+	if err != nil {
+		return err
+	}
+	cfgPath, err := hermes.ConfigPath(sandboxConfigPrefix + cfgName)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(cfgPath); err == nil {
+		return errors.Errorf("Hermes config %s already exists, remove it first", cfgPath)
+	}
+	if err := hermesCfg.SaveFile(cfgPath); err != nil {
+		return err
+	}
+	state.HermesConfig = cfgPath
+	if err := sandbox.SaveState(dir, state); err != nil {
+		return err
+	}
+	session.StopSpinner()
+	_ = session.Println(color.Green.Sprintf("Hermes config created at %s", cfgPath))
+
+	// the relayer keys are funded by the genesis accounts created from the same mnemonics.
+	for i, chain := range state.Chains {
+		session.StartSpinner(fmt.Sprintf("Adding chain %s relayer key", chain.ID))
+		var buf bytes.Buffer
+		if err := h.AddMnemonic(
+			ctx,
+			chain.ID,
+			mnemonics[i],
+			hermes.WithConfigFile(cfgPath),
+			hermes.WithFlags(hermes.Flags{hermes.FlagOverwrite: true}),
+			hermes.WithStdOut(&buf),
+			hermes.WithJSONOutput(),
+		); err != nil {
+			return err
+		}
+		if err := hermes.ValidateResult(buf.Bytes()); err != nil {
+			return err
+		}
+	}
+
+	path := hermes.Path{
+		ChainA: state.Chains[0].ID,
+		PortA:  sandboxPort,
+		ChainB: state.Chains[1].ID,
+		PortB:  sandboxPort,
+	}
+	if err := createPath(ctx, session, h, cfgPath, pathState{path: path}); err != nil {
+		return errors.Wrapf(err, "failed to create the path %s", path)
+	}
+
+	d, cfgName, err := newDaemon(cfgPath)
+	if err != nil {
+		return err
+	}
+	session.StopSpinner()
+	if err := startDaemon(h, d, cfgName, cfgPath); err != nil {
+		return err
+	}
+
+	entries := make([][]string, 0, len(state.Chains))
+	for _, chain := range state.Chains {
+		entries = append(entries, []string{chain.ID, chain.RPCAddr, chain.GRPCAddr, chain.Home})
+	}
+	if err := session.PrintTable([]string{"Chain", "RPC", "gRPC", "Home"}, entries...); err != nil {
+		return err
+	}
+	return session.Println(color.Yellow.Sprint("Tear down the sandbox with the sandbox teardown command"))
+}
+
+func SandboxTeardownHandler(ctx context.Context, cmd *plugin.ExecutedCommand) error {
+	session := cliui.New(cliui.StartSpinnerWithText("Tearing down the IBC sandbox"))
+	defer session.End()
+
+	dir, err := sandboxDir()
+	if err != nil {
+		return err
+	}
+	state, err := sandbox.LoadState(dir)
+	if errors.Is(err, sandbox.ErrNotFound) {
+		return errors.New("no sandbox found")
+	} else if err != nil {
+		return err
+	}
+
+	// only the Hermes config created by the sandbox is removed with its relayer and keys.
+	if state.HermesConfig != "" && !isSandboxConfig(state.HermesConfig) {
+		session.StopSpinner()
+		_ = session.Println(color.Yellow.Sprintf("Skipping the Hermes config %s not created by the sandbox", state.HermesConfig))
+	} else if state.HermesConfig != "" {
+		session.StartSpinner("Stopping the Hermes relayer")
+		d, _, err := newDaemon(state.HermesConfig)
+		if err != nil {
+			return err
+		}
+		if err := d.Stop(ctx, daemonStopTimeout); err != nil && !errors.Is(err, daemon.ErrNotRunning) {
+			return err
+		}
+
+		h, err := newHermes(cmd.Flags)
+		if err != nil {
+			return err
+		}
+		defer h.Cleanup()
+
+		if _, err := os.Stat(state.HermesConfig); err == nil {
+			hermesCfg, err := hermes.LoadConfig(state.HermesConfig)
+			if err != nil {
+				return err
+			}
+			for _, chain := range hermesCfg.Chains {
+				if chain.KeyName != sandboxKeyName {
+					continue
+				}
+				session.StartSpinner(fmt.Sprintf("Deleting chain %s relayer key", chain.ID))
+				if err := h.DeleteKey(
+					ctx,
+					chain.ID,
+					chain.KeyName,
+					hermes.WithConfigFile(state.HermesConfig),
+					hermes.WithStdOut(&bytes.Buffer{}),
+					hermes.WithJSONOutput(),
+				); err != nil {
+					session.StopSpinner()
+					_ = session.Println(color.Yellow.Sprintf("Failed to delete the chain %s relayer key: %s", chain.ID, err))
+				}
+			}
+		}
+		if err := os.RemoveAll(d.Dir()); err != nil {
+			return err
+		}
+		if err := os.Remove(state.HermesConfig); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	for _, chain := range state.Chains {
+		session.StartSpinner(fmt.Sprintf("Stopping chain %s", chain.ID))
+		err := daemon.New(chain.DaemonDir()).Stop(ctx, daemonStopTimeout)
+		if err != nil && !errors.Is(err, daemon.ErrNotRunning) {
+			return errors.Wrapf(err, "failed to stop the chain %s", chain.ID)
+		}
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return err
+	}
+
+	session.StopSpinner()
+	return session.Println(color.Green.Sprint("IBC sandbox removed"))
+}
+
+// isSandboxConfig returns true if the Hermes config path was created by the sandbox.
+func isSandboxConfig(cfgPath string) bool {
+	return strings.HasPrefix(filepath.Base(cfgPath), sandboxConfigPrefix)
+}
+
+// sandboxDir returns the directory of the sandbox chains.
+func sandboxDir() (string, error) {
+	configDir, err := hermes.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, sandboxDirName), nil
+}
+
+// newMnemonic generates a new key mnemonic.
+func newMnemonic() (string, error) {
+	entropySeed, err := bip39.NewEntropy(mnemonicEntropySize)
+	if err != nil {
+		return "", err
+	}
+	return bip39.NewMnemonic(entropySeed)
+}
@@ -13,7 +13,7 @@ import (
 
 func StartHandler(ctx context.Context, cmd *plugin.ExecutedCommand) (err error) {
 	var (
-		flags     = cmd.Flags
+		flags     = plugin.Flags(cmd.Flags)
 		args      = cmd.Args
 		customCfg = getConfig(flags)
 		cfgName   = strings.Join(args, hermes.ConfigNameSeparator)
@@ -31,12 +31,20 @@ func StartHandler(ctx context.Context, cmd *plugin.ExecutedCommand) (err error)
 		return errors.Errorf("config file (%s) not exist, try to configure you relayer first", cfgPath)
 	}
 
-	h, err := hermes.New()
+	h, err := newHermes(cmd.Flags)
 	if err != nil {
 		return err
 	}
 	defer h.Cleanup()
 
+	if detach, _ := flags.GetBool(flagDetach); detach {
+		d, cfgName, err := newDaemon(cfgPath)
+		if err != nil {
+			return err
+		}
+		return startDaemon(h, d, cfgName, cfgPath)
+	}
+
 	return h.Start(
 		ctx,
 		hermes.WithConfigFile(cfgPath),
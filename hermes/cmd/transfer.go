@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	sdkmath "cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/gookit/color"
+	"github.com/ignite/cli/v28/ignite/pkg/cliui"
+	"github.com/ignite/cli/v28/ignite/pkg/errors"
+	"github.com/ignite/cli/v28/ignite/services/plugin"
+
+	"github.com/ignite/apps/hermes/pkg/hermes"
+)
+
+// transferPollInterval is the interval to check if the test transfer was relayed.
+const transferPollInterval = time.Second
+
+func TestTransferHandler(ctx context.Context, cmd *plugin.ExecutedCommand) error {
+	var (
+		flags          = plugin.Flags(cmd.Flags)
+		channelID, _   = flags.GetString(flagChannel)
+		portID, _      = flags.GetString(flagPort)
+		amountFlag, _  = flags.GetString(flagAmount)
+		timeoutFlag, _ = flags.GetString(flagTimeout)
+	)
+
+	timeout, err := time.ParseDuration(timeoutFlag)
+	if err != nil {
+		return errors.Wrapf(err, "invalid timeout %s", timeoutFlag)
+	}
+
+	if len(cmd.Args) == 0 && getConfig(flags) == "" {
+		return errors.Errorf("the chain IDs or the --%s flag are required", flagConfig)
+	}
+	cfgPath, err := getConfigPath(flags, cmd.Args...)
+	if err != nil {
+		return err
+	}
+	hermesCfg, err := hermes.LoadConfig(cfgPath)
+	if err != nil {
+		return err
+	}
+
+	chainIDs := cmd.Args
+	if len(chainIDs) == 0 {
+		chainIDs = hermesCfg.Chains.IDs()
+	}
+	if len(chainIDs) < 2 {
+		return errors.Errorf("expected the source and destination chains, got %v", chainIDs)
+	}
+	chainA, err := hermesCfg.Chains.Get(chainIDs[0])
+	if err != nil {
+		return err
+	}
+	chainB, err := hermesCfg.Chains.Get(chainIDs[1])
+	if err != nil {
+		return err
+	}
+
+	amount := sdk.NewCoin(chainA.GasPrice.Denom, sdkmath.OneInt())
+	if amountFlag != "" {
+		if amount, err = sdk.ParseCoinNormalized(amountFlag); err != nil {
+			return errors.Wrapf(err, "invalid amount %s", amountFlag)
+		}
+	}
+
+	session := cliui.New(cliui.StartSpinnerWithText("Looking for the transfer channel"))
+	defer session.End()
+
+	h, err := newHermes(cmd.Flags)
+	if err != nil {
+		return err
+	}
+	defer h.Cleanup()
+
+	channel, err := transferChannel(ctx, h, cfgPath, chainA.ID, chainB.ID, portID, channelID)
+	if err != nil {
+		return err
+	}
+	receiver, err := relayerAddress(ctx, h, chainB.ID, cfgPath)
+	if err != nil {
+		return err
+	}
+
+	ibcDenom := hermes.IBCDenom(channel.PortB, channel.ChannelB, amount.Denom)
+	balance, err := chainB.Balance(ctx, receiver)
+	if err != nil {
+		return err
+	}
+	expected := balance.AmountOf(ibcDenom).Add(amount.Amount)
+
+	session.StartSpinner(fmt.Sprintf("Sending %s from %s (%s) to %s", amount, chainA.ID, channel.ChannelA, receiver))
+	start := time.Now()
+	seq, err := h.Transfer(ctx, channel, amount, receiver, timeout, hermes.WithConfigFile(cfgPath))
+	if err != nil {
+		return errors.Wrap(err, "failed to send the transfer")
+	}
+	sent := time.Since(start)
+
+	session.StartSpinner(fmt.Sprintf("Waiting for the packet %d to be received on %s", seq, chainB.ID))
+	if err := waitUntil(ctx, timeout, func() (bool, error) {
+		balance, err = chainB.Balance(ctx, receiver)
+		if err != nil {
+			return false, err
+		}
+		return balance.AmountOf(ibcDenom).GTE(expected), nil
+	}); err != nil {
+		return errors.Wrapf(err, "packet %d not received on %s, is the relayer running", seq, chainB.ID)
+	}
+	received := time.Since(start)
+
+	session.StartSpinner(fmt.Sprintf("Waiting for the packet %d acknowledgment on %s", seq, chainA.ID))
+	if err := waitUntil(ctx, timeout, func() (bool, error) {
+		commitments, err := h.PacketCommitments(ctx, chainA.ID, channel.PortA, channel.ChannelA, hermes.WithConfigFile(cfgPath))
+		if err != nil {
+			return false, err
+		}
+		return !slices.Contains(commitments.Seqs, seq), nil
+	}); err != nil {
+		return errors.Wrapf(err, "packet %d not acknowledged on %s, is the relayer running", seq, chainA.ID)
+	}
+	acknowledged := time.Since(start)
+
+	session.StopSpinner()
+	_ = session.Println(color.Green.Sprintf(
+		"Transfer %s/%s sequence %d relayed, %s balance: %s",
+		channel.PortA,
+		channel.ChannelA,
+		seq,
+		receiver,
+		sdk.NewCoin(ibcDenom, balance.AmountOf(ibcDenom)),
+	))
+	return session.PrintTable(
+		[]string{"Step", "Chain", "Time"},
+		[]string{"Sent", chainA.ID, sent.Round(time.Millisecond).String()},
+		[]string{"Received", chainB.ID, received.Round(time.Millisecond).String()},
+		[]string{"Acknowledged", chainA.ID, acknowledged.Round(time.Millisecond).String()},
+	)
+}
+
+// transferChannel returns the channel from chain A to chain B with the port, by channel ID
+// or the first channel with a counterparty if the channel ID is empty.
+func transferChannel(
+	ctx context.Context,
+	h *hermes.Hermes,
+	cfgPath,
+	chainA,
+	chainB,
+	portID,
+	channelID string,
+) (hermes.ChannelResult, error) {
+	channels, err := h.Channels(ctx, chainA, chainB, hermes.WithConfigFile(cfgPath))
+	if err != nil {
+		return hermes.ChannelResult{}, err
+	}
+	for _, channel := range channels {
+		if channel.PortID != portID || channel.CounterpartyChannelID == "" {
+			continue
+		}
+		if channelID != "" && channel.ChannelID != channelID {
+			continue
+		}
+		return hermes.ChannelResult{
+			ChainIDA: chainA,
+			ChainIDB: chainB,
+			ChannelA: channel.ChannelID,
+			ChannelB: channel.CounterpartyChannelID,
+			PortA:    channel.PortID,
+			PortB:    channel.CounterpartyPortID,
+		}, nil
+	}
+	if channelID != "" {
+		return hermes.ChannelResult{}, errors.Errorf("channel %s/%s not found between %s and %s", portID, channelID, chainA, chainB)
+	}
+	return hermes.ChannelResult{}, errors.Errorf("no %s channel found between %s and %s", portID, chainA, chainB)
+}
+
+// waitUntil checks the condition at each poll interval until it is true or the timeout expires.
+func waitUntil(ctx context.Context, timeout time.Duration, condition func() (bool, error)) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(transferPollInterval)
+	defer ticker.Stop()
+	for {
+		done, err := condition()
+		if err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return errors.Errorf("timeout after %s", timeout)
+		case <-ticker.C:
+		}
+	}
+}
@@ -2,12 +2,15 @@ package main
 
 import (
 	"context"
+	"fmt"
+	"os"
 
 	hplugin "github.com/hashicorp/go-plugin"
 	"github.com/ignite/cli/v28/ignite/pkg/errors"
 	"github.com/ignite/cli/v28/ignite/services/plugin"
 
 	"github.com/ignite/apps/hermes/cmd"
+	"github.com/ignite/apps/hermes/pkg/daemon"
 )
 
 type app struct{}
@@ -29,6 +32,73 @@ func (app) Execute(ctx context.Context, c *plugin.ExecutedCommand, _ plugin.Clie
 		return cmd.ExecuteHandler(ctx, c)
 	case "start":
 		return cmd.StartHandler(ctx, c)
+	case "stop":
+		return cmd.StopHandler(ctx, c)
+	case "status":
+		return cmd.StatusHandler(ctx, c)
+	case "logs":
+		return cmd.LogsHandler(ctx, c)
+	case "metrics":
+		return cmd.MetricsHandler(ctx, c)
+	case "balance":
+		return cmd.BalanceHandler(ctx, c)
+	case "test-transfer":
+		return cmd.TestTransferHandler(ctx, c)
+	case "sandbox":
+		if len(args) > 1 && args[1] == "teardown" {
+			return cmd.SandboxTeardownHandler(ctx, c)
+		}
+		return cmd.SandboxHandler(ctx, c)
+	case "plan":
+		return cmd.PlanHandler(ctx, c)
+	case "apply":
+		return cmd.ApplyHandler(ctx, c)
+	case "packets":
+		switch args[1] {
+		case "pending":
+			return cmd.PacketsPendingHandler(ctx, c)
+		case "clear":
+			return cmd.PacketsClearHandler(ctx, c)
+		default:
+			return errors.Errorf("unknown packets command: %s", args[1])
+		}
+	case "clients":
+		switch args[1] {
+		case "status":
+			return cmd.ClientsStatusHandler(ctx, c)
+		default:
+			return errors.Errorf("unknown clients command: %s", args[1])
+		}
+	case "config":
+		switch args[1] {
+		case "validate":
+			return cmd.ConfigValidateHandler(ctx, c)
+		case "migrate":
+			return cmd.ConfigMigrateHandler(ctx, c)
+		case "list":
+			return cmd.ConfigListHandler(ctx, c)
+		case "show":
+			return cmd.ConfigShowHandler(ctx, c)
+		case "remove":
+			return cmd.ConfigRemoveHandler(ctx, c)
+		case "export":
+			return cmd.ConfigExportHandler(ctx, c)
+		case "import":
+			return cmd.ConfigImportHandler(ctx, c)
+		default:
+			return errors.Errorf("unknown config command: %s", args[1])
+		}
+	case "filter":
+		switch args[1] {
+		case "allow":
+			return cmd.FilterAllowHandler(ctx, c)
+		case "deny":
+			return cmd.FilterDenyHandler(ctx, c)
+		case "list":
+			return cmd.FilterListHandler(ctx, c)
+		default:
+			return errors.Errorf("unknown filter command: %s", args[1])
+		}
 	case "keys":
 		switch args[1] {
 		case "add":
@@ -60,6 +130,15 @@ func (app) ExecuteHookCleanUp(context.Context, *plugin.ExecutedHook, plugin.Clie
 }
 
 func main() {
+	// the relayer daemon runs the app binary as the hermes process supervisor.
+	if len(os.Args) > 1 && os.Args[1] == daemon.SuperviseCommand {
+		if err := daemon.RunSupervisor(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	hplugin.Serve(&hplugin.ServeConfig{
 		HandshakeConfig: plugin.HandshakeConfig(),
 		Plugins: map[string]hplugin.Plugin{
@@ -0,0 +1,364 @@
+// Package daemon runs a command as a supervised background process with a PID file,
+// a rotated log file and automatic restart on crash.
+package daemon
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ignite/cli/v28/ignite/pkg/errors"
+)
+
+const (
+	// SuperviseCommand is the command argument to run the current executable as a supervisor.
+	SuperviseCommand = "supervise"
+
+	pidFile    = "supervisor.pid"
+	lockFile   = "start.lock"
+	stateFile  = "state.json"
+	logFile    = "daemon.log"
+	binaryFile = "bin"
+
+	// stopPollInterval is the interval to check if the supervisor stopped.
+	stopPollInterval = 100 * time.Millisecond
+	// startTimeout is the max time to wait for the supervisor to start the process.
+	startTimeout = 10 * time.Second
+	// followPollInterval is the interval to check for new log lines.
+	followPollInterval = 500 * time.Millisecond
+)
+
+var (
+	// ErrRunning indicates the daemon is already running.
+	ErrRunning = errors.New("daemon is already running")
+	// ErrNotRunning indicates the daemon is not running.
+	ErrNotRunning = errors.New("daemon is not running")
+
+	// errLocked indicates the daemon start lock is held by another process.
+	errLocked = errors.New("daemon start is locked")
+)
+
+type (
+	// Daemon represents a supervised background process with its files stored into a directory.
+	Daemon struct {
+		dir          string
+		restartDelay time.Duration
+	}
+
+	// Option configures the daemon.
+	Option func(*Daemon)
+
+	// State represents the supervised process state written by the supervisor.
+	State struct {
+		SupervisorPID int       `json:"supervisor_pid"`
+		PID           int       `json:"pid"`
+		StartedAt     time.Time `json:"started_at"`
+		LastStart     time.Time `json:"last_start"`
+		Restarts      int       `json:"restarts"`
+		LastExitError string    `json:"last_exit_error,omitempty"`
+	}
+
+	// Status represents the daemon status.
+	Status struct {
+		Running bool
+		State   State
+	}
+)
+
+// WithRestartDelay sets the first restart delay after a crash, doubled after each
+// consecutive crash.
+func WithRestartDelay(delay time.Duration) Option {
+	return func(d *Daemon) {
+		d.restartDelay = delay
+	}
+}
+
+// New creates a new daemon storing the PID, state and log files into the directory.
+func New(dir string, options ...Option) *Daemon {
+	d := &Daemon{dir: dir, restartDelay: minRestartDelay}
+	for _, apply := range options {
+		apply(d)
+	}
+	return d
+}
+
+// Dir returns the daemon directory.
+func (d *Daemon) Dir() string {
+	return d.dir
+}
+
+// LogPath returns the daemon log file path.
+func (d *Daemon) LogPath() string {
+	return filepath.Join(d.dir, logFile)
+}
+
+func (d *Daemon) pidPath() string {
+	return filepath.Join(d.dir, pidFile)
+}
+
+func (d *Daemon) lockPath() string {
+	return filepath.Join(d.dir, lockFile)
+}
+
+func (d *Daemon) statePath() string {
+	return filepath.Join(d.dir, stateFile)
+}
+
+// Start copies the command binary into the daemon directory, so it outlives temporary
+// binaries, and starts the current executable as a detached supervisor of the command.
+// The start is locked so concurrent starts cannot spawn two supervisors, and it waits
+// for the supervisor to start the command, reporting the startup failures.
+func (d *Daemon) Start(cmd []string) (int, error) {
+	if len(cmd) == 0 {
+		return 0, errors.New("empty daemon command")
+	}
+	if err := os.MkdirAll(d.dir, 0o755); err != nil {
+		return 0, err
+	}
+
+	unlock, err := lock(d.lockPath())
+	if errors.Is(err, errLocked) {
+		return 0, ErrRunning
+	} else if err != nil {
+		return 0, errors.Wrap(err, "failed to lock the daemon start")
+	}
+	defer unlock()
+
+	if _, running := d.pid(); running {
+		return 0, ErrRunning
+	}
+	// remove the files of a previous supervisor, so only the new supervisor state is awaited.
+	for _, path := range []string{d.pidPath(), d.statePath()} {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return 0, err
+		}
+	}
+
+	binaryPath := filepath.Join(d.dir, binaryFile)
+	if err := copyFile(cmd[0], binaryPath, 0o755); err != nil {
+		return 0, errors.Wrap(err, "failed to copy the daemon binary")
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		return 0, err
+	}
+
+	args := append([]string{SuperviseCommand, d.dir, "--", binaryPath}, cmd[1:]...)
+	supervisor := exec.Command(executable, args...)
+	supervisor.Dir = d.dir
+	supervisor.SysProcAttr = detachedProcAttr()
+	if err := supervisor.Start(); err != nil {
+		return 0, errors.Wrap(err, "failed to start the supervisor")
+	}
+	pid := supervisor.Process.Pid
+
+	exited := make(chan error, 1)
+	go func() {
+		exited <- supervisor.Wait()
+	}()
+	if err := d.waitStarted(pid, exited); err != nil {
+		return 0, err
+	}
+	return pid, nil
+}
+
+// waitStarted waits for the supervisor to write the state of the started process. The
+// supervisor is stopped if the process fails to start or the start timeout is reached.
+func (d *Daemon) waitStarted(pid int, exited <-chan error) error {
+	timeout := time.NewTimer(startTimeout)
+	defer timeout.Stop()
+
+	ticker := time.NewTicker(stopPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case err := <-exited:
+			if err == nil {
+				err = errors.New("exit status 0")
+			}
+			return errors.Errorf("supervisor exited at startup (%v), see the logs at %s", err, d.LogPath())
+		case <-timeout.C:
+			_ = terminate(pid)
+			return errors.Errorf("supervisor (PID %d) did not start the process within %s", pid, startTimeout)
+		case <-ticker.C:
+			status, err := d.Status()
+			if err != nil || status.State.SupervisorPID != pid {
+				continue
+			}
+			if status.State.PID != 0 {
+				return nil
+			}
+			if status.State.LastExitError != "" {
+				_ = terminate(pid)
+				return errors.Errorf("process failed at startup (%s), see the logs at %s", status.State.LastExitError, d.LogPath())
+			}
+		}
+	}
+}
+
+// Stop stops the supervisor and the supervised process, waiting up to the timeout.
+func (d *Daemon) Stop(ctx context.Context, timeout time.Duration) error {
+	pid, running := d.pid()
+	if !running {
+		return ErrNotRunning
+	}
+	if err := terminate(pid); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(stopPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return errors.Errorf("daemon (PID %d) did not stop within %s", pid, timeout)
+		case <-ticker.C:
+			if !processRunning(pid) {
+				_ = os.Remove(d.pidPath())
+				return nil
+			}
+		}
+	}
+}
+
+// Status returns the daemon status and the last state written by the supervisor.
+func (d *Daemon) Status() (Status, error) {
+	_, running := d.pid()
+	status := Status{Running: running}
+
+	data, err := os.ReadFile(d.statePath())
+	if os.IsNotExist(err) {
+		return status, nil
+	} else if err != nil {
+		return status, err
+	}
+	return status, json.Unmarshal(data, &status.State)
+}
+
+// Tail writes the last lines of the log file.
+func (d *Daemon) Tail(w io.Writer, lines int) error {
+	data, err := os.ReadFile(d.LogPath())
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	data = bytes.TrimRight(data, "\n")
+	if len(data) == 0 {
+		return nil
+	}
+	all := bytes.Split(data, []byte("\n"))
+	if lines > 0 && len(all) > lines {
+		all = all[len(all)-lines:]
+	}
+	_, err = w.Write(append(bytes.Join(all, []byte("\n")), '\n'))
+	return err
+}
+
+// Follow writes the new log lines until the context is canceled. The log file
+// is read again from the start when it is rotated.
+func (d *Daemon) Follow(ctx context.Context, w io.Writer) error {
+	var offset int64
+	if info, err := os.Stat(d.LogPath()); err == nil {
+		offset = info.Size()
+	}
+
+	ticker := time.NewTicker(followPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			info, err := os.Stat(d.LogPath())
+			if os.IsNotExist(err) {
+				continue
+			} else if err != nil {
+				return err
+			}
+			if info.Size() < offset {
+				offset = 0
+			}
+			if info.Size() == offset {
+				continue
+			}
+
+			n, err := copyFrom(d.LogPath(), offset, w)
+			if err != nil {
+				return err
+			}
+			offset += n
+		}
+	}
+}
+
+// pid returns the supervisor PID and if it is running. A stale PID reused by another
+// process after a crash or a reboot is not reported as running, the process command
+// line must be the supervisor of the daemon directory.
+func (d *Daemon) pid() (int, bool) {
+	data, err := os.ReadFile(d.pidPath())
+	if err != nil {
+		return 0, false
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return 0, false
+	}
+	if !processRunning(pid) {
+		return pid, false
+	}
+	if cmdline, ok := processCommandLine(pid); ok && !strings.Contains(cmdline, SuperviseCommand+" "+d.dir+" ") {
+		return pid, false
+	}
+	return pid, true
+}
+
+// copyFrom copies the file content from the offset into the writer.
+func copyFrom(path string, offset int64, w io.Writer) (int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return 0, err
+	}
+	return io.Copy(w, f)
+}
+
+// copyFile copies the src file to dst with the file mode.
+func copyFile(src, dst string, mode os.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	// write to a temporary file first to not break a running binary.
+	tmp := dst + ".tmp"
+	out, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return err
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, dst)
+}
@@ -0,0 +1,170 @@
+//go:build !windows
+
+package daemon
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestMain runs the test binary as the daemon supervisor, like the app binary.
+func TestMain(m *testing.M) {
+	if len(os.Args) > 1 && os.Args[1] == SuperviseCommand {
+		if err := RunSupervisor(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+func TestRotateWriter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.log")
+	w, err := NewRotateWriter(path, 10, 2)
+	require.NoError(t, err)
+
+	for _, line := range []string{"line 1\n", "line 2\n", "line 3\n", "line 4\n"} {
+		_, err := w.Write([]byte(line))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+
+	for file, want := range map[string]string{
+		path:                "line 4\n",
+		backupPath(path, 1): "line 3\n",
+		backupPath(path, 2): "line 2\n",
+	} {
+		got, err := os.ReadFile(file)
+		require.NoError(t, err)
+		require.Equal(t, want, string(got))
+	}
+	require.NoFileExists(t, backupPath(path, 3))
+}
+
+func TestSupervise(t *testing.T) {
+	d := New(t.TempDir(), WithRestartDelay(10*time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// the command crashes right away and is restarted after the restart delay.
+	done := make(chan error, 1)
+	go func() {
+		done <- d.Supervise(ctx, []string{"sh", "-c", "echo relaying; exit 1"})
+	}()
+	require.Eventually(t, func() bool {
+		status, err := d.Status()
+		return err == nil && status.State.Restarts >= 1 && status.State.PID == 0
+	}, 5*time.Second, 10*time.Millisecond)
+	cancel()
+	require.NoError(t, <-done)
+	require.NoFileExists(t, d.pidPath())
+
+	status, err := d.Status()
+	require.NoError(t, err)
+	require.False(t, status.Running)
+	require.GreaterOrEqual(t, status.State.Restarts, 1)
+	require.Equal(t, "exit status 1", status.State.LastExitError)
+
+	var out bytes.Buffer
+	require.NoError(t, d.Tail(&out, 0))
+	require.GreaterOrEqual(t, strings.Count(out.String(), "relaying\n"), 2)
+	require.Contains(t, out.String(), "[supervisor] supervisor stopped")
+
+	out.Reset()
+	require.NoError(t, d.Tail(&out, 1))
+	require.Equal(t, 1, strings.Count(out.String(), "\n"))
+	require.Contains(t, out.String(), "supervisor stopped")
+}
+
+func TestStart(t *testing.T) {
+	d := New(t.TempDir())
+
+	// concurrent starts spawn a single supervisor.
+	var (
+		wg      sync.WaitGroup
+		started atomic.Int32
+		errs    = make(chan error, 3)
+	)
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := d.Start([]string{"/bin/sh", "-c", "sleep 60"}); err != nil {
+				errs <- err
+				return
+			}
+			started.Add(1)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	require.EqualValues(t, 1, started.Load())
+	for err := range errs {
+		require.ErrorIs(t, err, ErrRunning)
+	}
+	defer d.Stop(context.Background(), 5*time.Second) //nolint:errcheck
+
+	status, err := d.Status()
+	require.NoError(t, err)
+	require.True(t, status.Running)
+	require.NotZero(t, status.State.PID)
+
+	_, err = d.Start([]string{"/bin/sh", "-c", "sleep 60"})
+	require.ErrorIs(t, err, ErrRunning)
+
+	require.NoError(t, d.Stop(context.Background(), 5*time.Second))
+	status, err = d.Status()
+	require.NoError(t, err)
+	require.False(t, status.Running)
+}
+
+func TestStartFailure(t *testing.T) {
+	var (
+		dir    = t.TempDir()
+		d      = New(filepath.Join(dir, "daemon"))
+		binary = filepath.Join(dir, "hermes")
+	)
+	require.NoError(t, os.WriteFile(binary, []byte("not an executable"), 0o644))
+
+	_, err := d.Start([]string{binary, "start"})
+	require.ErrorContains(t, err, "process failed at startup")
+
+	require.Eventually(t, func() bool {
+		status, err := d.Status()
+		return err == nil && !status.Running
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestStalePID(t *testing.T) {
+	d := New(t.TempDir())
+
+	// the PID of a running process which is not the daemon supervisor.
+	require.NoError(t, os.WriteFile(d.pidPath(), []byte(strconv.Itoa(os.Getpid())), 0o644))
+
+	status, err := d.Status()
+	require.NoError(t, err)
+	require.False(t, status.Running)
+	require.ErrorIs(t, d.Stop(context.Background(), time.Second), ErrNotRunning)
+}
+
+func TestStopNotRunning(t *testing.T) {
+	d := New(t.TempDir())
+	require.ErrorIs(t, d.Stop(context.Background(), time.Second), ErrNotRunning)
+
+	status, err := d.Status()
+	require.NoError(t, err)
+	require.False(t, status.Running)
+}
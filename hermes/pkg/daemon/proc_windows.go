@@ -0,0 +1,62 @@
+//go:build windows
+
+package daemon
+
+import (
+	"os"
+	"syscall"
+)
+
+// detachedProcAttr returns the attributes to start a process detached from the console.
+func detachedProcAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{CreationFlags: syscall.CREATE_NEW_PROCESS_GROUP}
+}
+
+// processRunning returns true if the process exists.
+func processRunning(pid int) bool {
+	p, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	_ = p.Release()
+	return true
+}
+
+// terminate kills the process.
+func terminate(pid int) error {
+	p, err := os.FindProcess(pid)
+	if err != nil {
+		return err
+	}
+	return p.Kill()
+}
+
+// groupProcAttr returns the attributes to start a process in its own process group.
+func groupProcAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{CreationFlags: syscall.CREATE_NEW_PROCESS_GROUP}
+}
+
+// stopGroup kills the process.
+func stopGroup(p *os.Process) error {
+	return p.Kill()
+}
+
+// processCommandLine is not supported on windows, the PID is trusted.
+func processCommandLine(int) (string, bool) {
+	return "", false
+}
+
+// lock creates the lock file, removed by the returned unlock function.
+// It returns errLocked if the lock file already exists.
+func lock(path string) (func(), error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_RDWR, 0o644)
+	if os.IsExist(err) {
+		return nil, errLocked
+	} else if err != nil {
+		return nil, err
+	}
+	_ = f.Close()
+	return func() {
+		_ = os.Remove(path)
+	}, nil
+}
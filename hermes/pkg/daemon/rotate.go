@@ -0,0 +1,90 @@
+package daemon
+
+import (
+	"fmt"
+	"os"
+	"sync"
+)
+
+// RotateWriter is a file writer that rotates the file when it reaches the max size,
+// keeping the max number of backups named <path>.1 (newest) to <path>.<max> (oldest).
+type RotateWriter struct {
+	mu         sync.Mutex
+	path       string
+	maxSize    int64
+	maxBackups int
+	file       *os.File
+	size       int64
+}
+
+// NewRotateWriter opens the file for appending with rotation.
+func NewRotateWriter(path string, maxSize int64, maxBackups int) (*RotateWriter, error) {
+	w := &RotateWriter{
+		path:       path,
+		maxSize:    maxSize,
+		maxBackups: maxBackups,
+	}
+	return w, w.open()
+}
+
+// Write writes the data into the file, rotating it first if the max size would be exceeded.
+func (w *RotateWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.size > 0 && w.size+int64(len(p)) > w.maxSize {
+		if err := w.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+// Close closes the file.
+func (w *RotateWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.file.Close()
+}
+
+func (w *RotateWriter) open() error {
+	file, err := os.OpenFile(w.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+	w.file, w.size = file, info.Size()
+	return nil
+}
+
+func (w *RotateWriter) rotate() error {
+	if err := w.file.Close(); err != nil {
+		return err
+	}
+
+	_ = os.Remove(backupPath(w.path, w.maxBackups))
+	for i := w.maxBackups - 1; i > 0; i-- {
+		if err := os.Rename(backupPath(w.path, i), backupPath(w.path, i+1)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	if w.maxBackups > 0 {
+		if err := os.Rename(w.path, backupPath(w.path, 1)); err != nil {
+			return err
+		}
+	} else if err := os.Remove(w.path); err != nil {
+		return err
+	}
+	return w.open()
+}
+
+// backupPath returns the path of the rotated file by index.
+func backupPath(path string, index int) string {
+	return fmt.Sprintf("%s.%d", path, index)
+}
@@ -0,0 +1,138 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/ignite/cli/v28/ignite/pkg/errors"
+)
+
+const (
+	// DefaultMaxLogSize is the log file size that triggers a rotation.
+	DefaultMaxLogSize = 10 << 20
+	// DefaultMaxLogBackups is the number of rotated log files kept.
+	DefaultMaxLogBackups = 5
+
+	// minRestartDelay is the first restart delay after a crash.
+	minRestartDelay = time.Second
+	// maxRestartDelay is the maximum restart delay after consecutive crashes.
+	maxRestartDelay = time.Minute
+	// stableRunTime is the run time after which the restart delay is reset.
+	stableRunTime = time.Minute
+	// stopWaitDelay is the time to wait for the process to exit after the stop signal.
+	stopWaitDelay = 10 * time.Second
+)
+
+// RunSupervisor runs the supervisor from the command args in the format <dir> -- <command...>
+// until it receives a termination signal.
+func RunSupervisor(args []string) error {
+	if len(args) < 3 || args[1] != "--" {
+		return errors.Errorf("invalid supervisor args %v, expected <dir> -- <command...>", args)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	return New(args[0]).Supervise(ctx, args[2:])
+}
+
+// Supervise runs the command and restarts it on crash until the context is canceled.
+// The command output is written to the rotated log file.
+func (d *Daemon) Supervise(ctx context.Context, cmd []string) error {
+	if err := os.MkdirAll(d.dir, 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(d.pidPath(), []byte(strconv.Itoa(os.Getpid())), 0o644); err != nil {
+		return err
+	}
+	defer os.Remove(d.pidPath())
+
+	log, err := NewRotateWriter(d.LogPath(), DefaultMaxLogSize, DefaultMaxLogBackups)
+	if err != nil {
+		return err
+	}
+	defer log.Close()
+
+	var (
+		state = State{SupervisorPID: os.Getpid(), StartedAt: time.Now()}
+		delay = d.restartDelay
+	)
+	for {
+		state.LastStart = time.Now()
+		err := d.run(ctx, cmd, log, &state)
+		if ctx.Err() != nil {
+			logf(log, "supervisor stopped")
+			return nil
+		}
+
+		state.PID = 0
+		state.LastExitError = err.Error()
+		if err := d.writeState(state); err != nil {
+			logf(log, "failed to write the state: %v", err)
+		}
+		if time.Since(state.LastStart) >= stableRunTime {
+			delay = d.restartDelay
+		}
+		logf(log, "process exited (%v), restarting in %s", err, delay)
+
+		select {
+		case <-ctx.Done():
+			logf(log, "supervisor stopped")
+			return nil
+		case <-time.After(delay):
+		}
+
+		state.Restarts++
+		delay *= 2
+		if delay > maxRestartDelay {
+			delay = maxRestartDelay
+		}
+	}
+}
+
+// run runs the command once, writing the state with the process PID.
+func (d *Daemon) run(ctx context.Context, cmd []string, log io.Writer, state *State) error {
+	c := exec.CommandContext(ctx, cmd[0], cmd[1:]...)
+	c.Dir = d.dir
+	c.Stdout = log
+	c.Stderr = log
+	c.SysProcAttr = groupProcAttr()
+	c.Cancel = func() error { return stopGroup(c.Process) }
+	c.WaitDelay = stopWaitDelay
+
+	if err := c.Start(); err != nil {
+		return err
+	}
+	state.PID = c.Process.Pid
+	logf(log, "process started with PID %d", state.PID)
+	if err := d.writeState(*state); err != nil {
+		logf(log, "failed to write the state: %v", err)
+	}
+
+	if err := c.Wait(); err != nil {
+		return err
+	}
+	return errors.New("exit status 0")
+}
+
+// writeState writes the supervisor state file.
+func (d *Daemon) writeState(state State) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(d.statePath(), data, 0o644)
+}
+
+// logf writes a supervisor log line.
+func logf(w io.Writer, format string, args ...interface{}) {
+	_, _ = fmt.Fprintf(w, "%s [supervisor] %s\n", time.Now().UTC().Format(time.RFC3339), fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,85 @@
+package hermes
+
+import (
+	"context"
+	"math"
+	"strconv"
+
+	sdkmath "cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ignite/cli/v28/ignite/pkg/cosmosclient"
+	"github.com/ignite/cli/v28/ignite/pkg/errors"
+)
+
+// defaultThresholdTxs is the number of txs at the max gas the default balance threshold pays for.
+const defaultThresholdTxs = 10
+
+// BalanceStatus represents the relayer wallet balance compared to the threshold.
+type BalanceStatus struct {
+	ChainID   string
+	Address   string
+	Balance   sdk.Coins
+	Threshold sdk.Coin
+}
+
+// Low returns true if the balance of the threshold denom is below the threshold.
+func (s BalanceStatus) Low() bool {
+	return s.Balance.AmountOf(s.Threshold.Denom).LT(s.Threshold.Amount)
+}
+
+// DefaultBalanceThreshold returns the balance to pay the fees of ten txs at the chain max gas and gas price.
+func (c *Chain) DefaultBalanceThreshold() sdk.Coin {
+	amount := math.Ceil(float64(c.MaxGas) * c.GasPrice.Price * defaultThresholdTxs)
+	return sdk.NewCoin(c.GasPrice.Denom, sdkmath.NewInt(int64(amount)))
+}
+
+// GasPrices returns the chain gas price in the SDK coin format.
+func (c *Chain) GasPrices() string {
+	return strconv.FormatFloat(c.GasPrice.Price, 'f', -1, 64) + c.GasPrice.Denom
+}
+
+// CheckBalance returns the account balance compared to the threshold.
+func (c *Chain) CheckBalance(ctx context.Context, addr string, threshold sdk.Coin) (BalanceStatus, error) {
+	balance, err := c.Balance(ctx, addr)
+	if err != nil {
+		return BalanceStatus{}, err
+	}
+	return BalanceStatus{
+		ChainID:   c.ID,
+		Address:   addr,
+		Balance:   balance,
+		Threshold: threshold,
+	}, nil
+}
+
+// Fund sends the amount from the funding key of the keyring to the account and returns the new balance.
+func (c *Chain) Fund(
+	ctx context.Context,
+	fundingKey,
+	addr string,
+	amount sdk.Coins,
+	options ...cosmosclient.Option,
+) (sdk.Coins, error) {
+	options = append([]cosmosclient.Option{
+		cosmosclient.WithNodeAddress(c.RPCAddr),
+		cosmosclient.WithAddressPrefix(c.AccountPrefix),
+		cosmosclient.WithGasPrices(c.GasPrices()),
+	}, options...)
+	client, err := cosmosclient.New(ctx, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := client.Account(fundingKey)
+	if err != nil {
+		return nil, errors.Wrapf(err, "funding key %s not found", fundingKey)
+	}
+	tx, err := client.BankSendTx(ctx, account, addr, amount)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tx.Broadcast(ctx); err != nil {
+		return nil, errors.Wrapf(err, "failed to send %s from %s to %s", amount, fundingKey, addr)
+	}
+	return c.Balance(ctx, addr)
+}
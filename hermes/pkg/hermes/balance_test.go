@@ -0,0 +1,63 @@
+package hermes
+
+import (
+	"testing"
+
+	sdkmath "cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultBalanceThreshold(t *testing.T) {
+	c := DefaultConfig()
+	chain, err := c.AddChain("mars-1", "http://localhost:26657", "http://localhost:9090")
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewCoin("stake", sdkmath.NewInt(10000)), chain.DefaultBalanceThreshold())
+	require.Equal(t, "0.01stake", chain.GasPrices())
+
+	chain, err = c.AddChain(
+		"venus-1",
+		"http://localhost:26667",
+		"http://localhost:9100",
+		WithChainGasPrice(sdk.NewDecCoinFromDec("token", sdkmath.LegacyMustNewDecFromStr("0.025"))),
+		WithChainMaxGas(400000),
+	)
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewCoin("token", sdkmath.NewInt(100000)), chain.DefaultBalanceThreshold())
+}
+
+func TestBalanceStatusLow(t *testing.T) {
+	threshold := sdk.NewCoin("stake", sdkmath.NewInt(1000))
+	tests := []struct {
+		name    string
+		balance sdk.Coins
+		want    bool
+	}{
+		{
+			name:    "empty balance",
+			balance: sdk.NewCoins(),
+			want:    true,
+		},
+		{
+			name:    "other denom only",
+			balance: sdk.NewCoins(sdk.NewCoin("token", sdkmath.NewInt(5000))),
+			want:    true,
+		},
+		{
+			name:    "below threshold",
+			balance: sdk.NewCoins(sdk.NewCoin("stake", sdkmath.NewInt(999))),
+			want:    true,
+		},
+		{
+			name:    "equal to threshold",
+			balance: sdk.NewCoins(sdk.NewCoin("stake", sdkmath.NewInt(1000))),
+			want:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := BalanceStatus{Balance: tt.balance, Threshold: threshold}
+			require.Equal(t, tt.want, s.Low())
+		})
+	}
+}
@@ -0,0 +1,269 @@
+package hermes
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/ignite/cli/v28/ignite/pkg/errors"
+	"github.com/ignite/ignite-files/hermes"
+	"golang.org/x/mod/semver"
+)
+
+const (
+	// EmbeddedVersion is the version of the Hermes binary embedded into the app.
+	EmbeddedVersion = "1.10.0"
+	// DefaultReleaseURL is the URL of the Hermes release downloads.
+	DefaultReleaseURL = "https://github.com/informalsystems/hermes/releases/download"
+
+	binaryName = "hermes"
+	binDirName = "bin"
+
+	// downloadTimeout is the timeout to download a Hermes release.
+	downloadTimeout = 5 * time.Minute
+	// checksumExtension is the extension of the SHA-256 checksum file published with each release archive.
+	checksumExtension = ".sha256"
+)
+
+// ErrChecksumMismatch indicates the downloaded release archive does not match its published checksum.
+var ErrChecksumMismatch = errors.New("checksum mismatch")
+
+type (
+	// BinaryOption configures the Hermes binary source.
+	BinaryOption func(*binaryConfig)
+
+	// binaryConfig holds the Hermes binary source configs.
+	binaryConfig struct {
+		path       string
+		version    string
+		cacheDir   string
+		releaseURL string
+	}
+)
+
+// WithBinaryPath uses a system installed Hermes binary by path or name in the PATH.
+func WithBinaryPath(path string) BinaryOption {
+	return func(c *binaryConfig) {
+		c.path = path
+	}
+}
+
+// WithVersion selects the Hermes version, downloaded from the Hermes releases
+// if it is not the embedded version.
+func WithVersion(version string) BinaryOption {
+	return func(c *binaryConfig) {
+		c.version = strings.TrimPrefix(version, "v")
+	}
+}
+
+// WithBinaryCacheDir sets the directory of the extracted and downloaded Hermes binaries.
+func WithBinaryCacheDir(dir string) BinaryOption {
+	return func(c *binaryConfig) {
+		c.cacheDir = dir
+	}
+}
+
+// WithReleaseURL sets the URL of the Hermes release downloads.
+func WithReleaseURL(url string) BinaryOption {
+	return func(c *binaryConfig) {
+		c.releaseURL = url
+	}
+}
+
+// New returns the Hermes binary executable. By default, the embedded binary is extracted
+// once into the cache dir and reused between runs.
+func New(options ...BinaryOption) (*Hermes, error) {
+	c := binaryConfig{
+		version:    EmbeddedVersion,
+		releaseURL: DefaultReleaseURL,
+	}
+	for _, o := range options {
+		o(&c)
+	}
+
+	if c.path != "" {
+		path, err := exec.LookPath(c.path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Hermes binary %s not found", c.path)
+		}
+		return &Hermes{path: path}, nil
+	}
+
+	if c.cacheDir == "" {
+		configDir, err := ConfigDir()
+		if err != nil {
+			return nil, err
+		}
+		c.cacheDir = filepath.Join(configDir, binDirName)
+	}
+	if c.version == "" {
+		c.version = EmbeddedVersion
+	}
+	// the version is part of the cache path, so it must be a full semantic version.
+	if semver.Canonical("v"+c.version) != "v"+c.version {
+		return nil, errors.Errorf("invalid Hermes version %s", c.version)
+	}
+
+	path := filepath.Join(c.cacheDir, c.version, binaryName)
+	if _, err := os.Stat(path); err == nil {
+		return &Hermes{path: path}, nil
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	archive := hermes.Binary()
+	if c.version != EmbeddedVersion {
+		var err error
+		if archive, err = downloadRelease(c.releaseURL, c.version); err != nil {
+			return nil, err
+		}
+	}
+	if err := extractBinary(archive, path); err != nil {
+		return nil, errors.Wrapf(err, "failed to extract the Hermes %s binary", c.version)
+	}
+	return &Hermes{path: path}, nil
+}
+
+// Path returns the Hermes binary path.
+func (h *Hermes) Path() string {
+	return h.path
+}
+
+// downloadRelease downloads the Hermes release archive of the version for the current platform
+// and verifies it against the SHA-256 checksum published with the release.
+func downloadRelease(releaseURL, version string) ([]byte, error) {
+	target, err := releaseTarget()
+	if err != nil {
+		return nil, err
+	}
+	url := fmt.Sprintf("%s/v%[2]s/hermes-v%[2]s-%[3]s.tar.gz", releaseURL, version, target)
+
+	ctx, cancel := context.WithTimeout(context.Background(), downloadTimeout)
+	defer cancel()
+
+	archive, err := download(ctx, url)
+	if errors.Is(err, errNotFound) {
+		return nil, errors.Errorf("Hermes release %s not found for %s", version, target)
+	} else if err != nil {
+		return nil, errors.Wrapf(err, "failed to download the Hermes %s release", version)
+	}
+
+	checksum, err := download(ctx, url+checksumExtension)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to download the Hermes %s release checksum", version)
+	}
+	fields := strings.Fields(string(checksum))
+	if len(fields) == 0 {
+		return nil, errors.Errorf("empty Hermes %s release checksum", version)
+	}
+	sum := sha256.Sum256(archive)
+	if got := hex.EncodeToString(sum[:]); !strings.EqualFold(got, fields[0]) {
+		return nil, errors.Wrapf(ErrChecksumMismatch, "Hermes %s release: expected %s, got %s", version, fields[0], got)
+	}
+	return archive, nil
+}
+
+// errNotFound indicates the downloaded file does not exist.
+var errNotFound = errors.New("not found")
+
+// download returns the content of the url.
+func download(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case http.StatusOK:
+		return io.ReadAll(res.Body)
+	case http.StatusNotFound:
+		return nil, errors.Wrap(errNotFound, url)
+	default:
+		return nil, errors.Errorf("%s: %s", url, res.Status)
+	}
+}
+
+// releaseTarget returns the Hermes release target of the current platform.
+func releaseTarget() (string, error) {
+	var arch string
+	switch runtime.GOARCH {
+	case "amd64":
+		arch = "x86_64"
+	case "arm64":
+		arch = "aarch64"
+	default:
+		return "", errors.Errorf("no Hermes release for the %s architecture", runtime.GOARCH)
+	}
+
+	switch runtime.GOOS {
+	case "linux":
+		return arch + "-unknown-linux-gnu", nil
+	case "darwin":
+		return arch + "-apple-darwin", nil
+	default:
+		return "", errors.Errorf("no Hermes release for the %s system", runtime.GOOS)
+	}
+}
+
+// extractBinary extracts the hermes binary from the tar.gz archive into the path.
+// The binary is written to a temporary file first, so concurrent runs never
+// execute a partially written binary.
+func extractBinary(archive []byte, path string) error {
+	gzr, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return err
+	}
+	defer gzr.Close()
+
+	tr := tar.NewReader(gzr)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return errors.New("hermes binary not found in the archive")
+		} else if err != nil {
+			return err
+		}
+		if header.Typeflag == tar.TypeReg && filepath.Base(header.Name) == binaryName {
+			break
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), binaryName+"-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, tr); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(0o755); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,131 @@
+package hermes
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// testArchive returns a tar.gz archive with a hermes file.
+func testArchive(t *testing.T, content string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gzw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gzw)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "README.md", Mode: 0o644, Size: 2, Typeflag: tar.TypeReg}))
+	_, err := tw.Write([]byte("hi"))
+	require.NoError(t, err)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "hermes", Mode: 0o755, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+	_, err = tw.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+	require.NoError(t, gzw.Close())
+	return buf.Bytes()
+}
+
+func TestNewEmbedded(t *testing.T) {
+	cacheDir := t.TempDir()
+
+	h, err := New(WithBinaryCacheDir(cacheDir))
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(cacheDir, EmbeddedVersion, "hermes"), h.Path())
+	info, err := os.Stat(h.Path())
+	require.NoError(t, err)
+	require.NotZero(t, info.Mode()&0o100)
+
+	// the cached binary is reused.
+	h, err = New(WithBinaryCacheDir(cacheDir))
+	require.NoError(t, err)
+	cached, err := os.Stat(h.Path())
+	require.NoError(t, err)
+	require.Equal(t, info.ModTime(), cached.ModTime())
+}
+
+func TestNewDownload(t *testing.T) {
+	target, err := releaseTarget()
+	require.NoError(t, err)
+
+	var (
+		downloads int
+		archive   = testArchive(t, "#!/bin/sh\n")
+		sum       = sha256.Sum256(archive)
+		checksum  = hex.EncodeToString(sum[:])
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case fmt.Sprintf("/v1.8.2/hermes-v1.8.2-%s.tar.gz", target), fmt.Sprintf("/v1.9.0/hermes-v1.9.0-%s.tar.gz", target):
+			downloads++
+			_, _ = w.Write(archive)
+		case fmt.Sprintf("/v1.8.2/hermes-v1.8.2-%s.tar.gz.sha256", target):
+			fmt.Fprintf(w, "%s  hermes-v1.8.2-%s.tar.gz\n", checksum, target)
+		case fmt.Sprintf("/v1.9.0/hermes-v1.9.0-%s.tar.gz.sha256", target):
+			fmt.Fprintf(w, "%064d  hermes-v1.9.0-%s.tar.gz\n", 0, target)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	cacheDir := t.TempDir()
+	for i := 0; i < 2; i++ {
+		h, err := New(WithBinaryCacheDir(cacheDir), WithReleaseURL(server.URL), WithVersion("v1.8.2"))
+		require.NoError(t, err)
+		require.Equal(t, filepath.Join(cacheDir, "1.8.2", "hermes"), h.Path())
+
+		content, err := os.ReadFile(h.Path())
+		require.NoError(t, err)
+		require.Equal(t, "#!/bin/sh\n", string(content))
+	}
+	require.Equal(t, 1, downloads)
+
+	_, err = New(WithBinaryCacheDir(cacheDir), WithReleaseURL(server.URL), WithVersion("0.0.1"))
+	require.ErrorContains(t, err, "not found")
+
+	// the archive must match the published checksum.
+	_, err = New(WithBinaryCacheDir(cacheDir), WithReleaseURL(server.URL), WithVersion("1.9.0"))
+	require.ErrorIs(t, err, ErrChecksumMismatch)
+	require.NoFileExists(t, filepath.Join(cacheDir, "1.9.0", "hermes"))
+
+	// the version cannot escape the cache dir.
+	for _, version := range []string{"../../x", "1.8.2/../../x", "latest", "1.8"} {
+		_, err = New(WithBinaryCacheDir(cacheDir), WithReleaseURL(server.URL), WithVersion(version))
+		require.ErrorContains(t, err, "invalid Hermes version", version)
+	}
+}
+
+func TestNewBinaryPath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hermes")
+	require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n"), 0o755))
+
+	h, err := New(WithBinaryPath(path))
+	require.NoError(t, err)
+	require.Equal(t, path, h.Path())
+
+	_, err = New(WithBinaryPath(filepath.Join(t.TempDir(), "missing")))
+	require.Error(t, err)
+}
+
+func TestExtractBinary(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bin", "hermes")
+	require.NoError(t, extractBinary(testArchive(t, "binary"), path))
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "binary", string(content))
+
+	var buf bytes.Buffer
+	gzw := gzip.NewWriter(&buf)
+	require.NoError(t, tar.NewWriter(gzw).Close())
+	require.NoError(t, gzw.Close())
+	require.Error(t, extractBinary(buf.Bytes(), path))
+}
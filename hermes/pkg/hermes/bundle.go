@@ -0,0 +1,140 @@
+package hermes
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ignite/cli/v28/ignite/pkg/errors"
+	"github.com/pelletier/go-toml/v2"
+)
+
+// maxBundleConfigSize is the max size of a config file into a bundle.
+const maxBundleConfigSize = 1 << 20
+
+// ImportResult represents the configs imported from a bundle.
+type ImportResult struct {
+	Imported []string
+	Skipped  []string
+}
+
+// ExportConfigs writes the config files into a tar.gz bundle. The chain keys are never exported.
+func ExportConfigs(w io.Writer, cfgPaths ...string) error {
+	gzw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gzw)
+	for _, cfgPath := range cfgPaths {
+		data, err := os.ReadFile(cfgPath)
+		if err != nil {
+			return err
+		}
+		if err := tw.WriteHeader(&tar.Header{
+			Name:     filepath.Base(cfgPath),
+			Mode:     0o644,
+			Size:     int64(len(data)),
+			ModTime:  time.Now(),
+			Typeflag: tar.TypeReg,
+		}); err != nil {
+			return err
+		}
+		if _, err := tw.Write(data); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gzw.Close()
+}
+
+// ImportConfigs imports the configs of a tar.gz bundle, or a single TOML config named after
+// its chains, into the dir. The existing configs are skipped unless overwrite is true.
+func ImportConfigs(r io.Reader, dir string, overwrite bool) (ImportResult, error) {
+	var result ImportResult
+	br := bufio.NewReader(r)
+
+	// gzip magic number.
+	if magic, err := br.Peek(2); err != nil || !bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+		data, err := io.ReadAll(io.LimitReader(br, maxBundleConfigSize))
+		if err != nil {
+			return result, err
+		}
+		cfg, err := parseBundleConfig(data)
+		if err != nil {
+			return result, err
+		}
+		name, err := cfg.ConfigName()
+		if err != nil {
+			return result, err
+		}
+		return result, importConfig(&result, dir, name, data, overwrite)
+	}
+
+	gzr, err := gzip.NewReader(br)
+	if err != nil {
+		return result, err
+	}
+	defer gzr.Close()
+
+	tr := tar.NewReader(gzr)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return result, nil
+		} else if err != nil {
+			return result, err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		name := header.Name
+		if name != filepath.Base(name) || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+			return result, errors.Errorf("invalid config name %s into the bundle", name)
+		}
+		data, err := io.ReadAll(io.LimitReader(tr, maxBundleConfigSize))
+		if err != nil {
+			return result, err
+		}
+		if _, err := parseBundleConfig(data); err != nil {
+			return result, errors.Wrapf(err, "invalid bundle config %s", name)
+		}
+		if err := importConfig(&result, dir, name, data, overwrite); err != nil {
+			return result, err
+		}
+	}
+}
+
+// importConfig writes the config data into the dir if it doesn't exist or overwrite is true.
+func importConfig(result *ImportResult, dir, name string, data []byte, overwrite bool) error {
+	cfgPath := filepath.Join(dir, name)
+	if _, err := os.Stat(cfgPath); err == nil && !overwrite {
+		result.Skipped = append(result.Skipped, name)
+		return nil
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(cfgPath, data, 0o644); err != nil {
+		return err
+	}
+	result.Imported = append(result.Imported, name)
+	return nil
+}
+
+// parseBundleConfig parses the config data, which must contain the chains to relay.
+func parseBundleConfig(data []byte) (*Config, error) {
+	var cfg Config
+	if err := toml.Unmarshal(data, &cfg); err != nil {
+		return nil, errors.Wrap(err, "failed to parse the Hermes config")
+	}
+	if len(cfg.Chains) < 2 {
+		return nil, errors.New("the Hermes config has no chains to relay")
+	}
+	return &cfg, nil
+}
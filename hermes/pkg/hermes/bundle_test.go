@@ -0,0 +1,92 @@
+package hermes
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportImportConfigs(t *testing.T) {
+	srcDir := t.TempDir()
+	cfgPaths := make([]string, 0)
+	for _, chainIDs := range [][]string{{"mars-1", "venus-1"}, {"earth-1", "venus-1"}} {
+		c := DefaultConfig()
+		for _, chainID := range chainIDs {
+			_, err := c.AddChain(chainID, "http://localhost:26657", "http://localhost:9090")
+			require.NoError(t, err)
+		}
+		name, err := c.ConfigName()
+		require.NoError(t, err)
+		cfgPath := filepath.Join(srcDir, name)
+		require.NoError(t, c.SaveFile(cfgPath))
+		cfgPaths = append(cfgPaths, cfgPath)
+	}
+
+	var bundle bytes.Buffer
+	require.NoError(t, ExportConfigs(&bundle, cfgPaths...))
+
+	dstDir := t.TempDir()
+	result, err := ImportConfigs(bytes.NewReader(bundle.Bytes()), dstDir, false)
+	require.NoError(t, err)
+	require.Equal(t, []string{"mars-1_venus-1", "earth-1_venus-1"}, result.Imported)
+	require.Empty(t, result.Skipped)
+	for _, cfgPath := range cfgPaths {
+		want, err := os.ReadFile(cfgPath)
+		require.NoError(t, err)
+		got, err := os.ReadFile(filepath.Join(dstDir, filepath.Base(cfgPath)))
+		require.NoError(t, err)
+		require.Equal(t, want, got)
+	}
+
+	// the existing configs are skipped unless overwrite is set.
+	result, err = ImportConfigs(bytes.NewReader(bundle.Bytes()), dstDir, false)
+	require.NoError(t, err)
+	require.Empty(t, result.Imported)
+	require.Len(t, result.Skipped, 2)
+	result, err = ImportConfigs(bytes.NewReader(bundle.Bytes()), dstDir, true)
+	require.NoError(t, err)
+	require.Len(t, result.Imported, 2)
+}
+
+func TestImportConfigsTOML(t *testing.T) {
+	c := DefaultConfig()
+	for _, chainID := range []string{"mars-1", "venus-1"} {
+		_, err := c.AddChain(chainID, "http://localhost:26657", "http://localhost:9090")
+		require.NoError(t, err)
+	}
+	cfgPath := filepath.Join(t.TempDir(), "config.toml")
+	require.NoError(t, c.SaveFile(cfgPath))
+	data, err := os.ReadFile(cfgPath)
+	require.NoError(t, err)
+
+	dstDir := t.TempDir()
+	result, err := ImportConfigs(bytes.NewReader(data), dstDir, false)
+	require.NoError(t, err)
+	require.Equal(t, []string{"mars-1_venus-1"}, result.Imported)
+
+	_, err = ImportConfigs(bytes.NewReader([]byte("[global]\nlog_level = 'info'\n")), dstDir, false)
+	require.Error(t, err)
+}
+
+func TestImportConfigsInvalidName(t *testing.T) {
+	var buf bytes.Buffer
+	gzw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gzw)
+	data := []byte("[[chains]]\nid = 'a'\n[[chains]]\nid = 'b'\n")
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "../escape", Mode: 0o644, Size: int64(len(data)), Typeflag: tar.TypeReg}))
+	_, err := tw.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+	require.NoError(t, gzw.Close())
+
+	dir := t.TempDir()
+	_, err = ImportConfigs(&buf, filepath.Join(dir, "configs"), false)
+	require.ErrorContains(t, err, "invalid config name")
+	_, err = os.Stat(filepath.Join(dir, "escape"))
+	require.True(t, os.IsNotExist(err))
+}
@@ -21,8 +21,16 @@ import (
 const (
 	// ConfigNameSeparator config file chain name separator.
 	ConfigNameSeparator = "_"
+
+	// PacketFilterAllow is the packet filter policy relaying only the listed channels.
+	PacketFilterAllow = "allow"
+	// PacketFilterDeny is the packet filter policy relaying all channels except the listed ones.
+	PacketFilterDeny = "deny"
 )
 
+// ErrAmbiguousConfig indicates more than one saved config contains the chains.
+var ErrAmbiguousConfig = errors.New("ambiguous Hermes config")
+
 type (
 	// Config represents the Hermes config struct.
 	Config struct {
@@ -97,32 +105,59 @@ type (
 
 	// Chain represents the chain into the Hermes config struct.
 	Chain struct {
-		ID                string         `toml:"id" json:"id"`
-		ChainType         string         `toml:"type" json:"type"`
-		CCVConsumerChain  bool           `toml:"ccv_consumer_chain" json:"ccv_consumer_chain"`
-		RPCAddr           string         `toml:"rpc_addr" json:"rpc_addr"`
-		GRPCAddr          string         `toml:"grpc_addr" json:"grpc_addr"`
-		EventSource       EventSource    `toml:"event_source,inline" json:"event_source"`
-		RPCTimeout        string         `toml:"rpc_timeout" json:"rpc_timeout"`
-		TrustedNode       bool           `toml:"trusted_node" json:"trusted_node"`
-		AccountPrefix     string         `toml:"account_prefix" json:"account_prefix"`
-		KeyName           string         `toml:"key_name" json:"key_name"`
-		AddressType       AddressType    `toml:"address_type,inline" json:"address_type"`
-		KeyStoreType      string         `toml:"key_store_type" json:"key_store_type"`
-		StorePrefix       string         `toml:"store_prefix" json:"store_prefix"`
-		DefaultGas        uint64         `toml:"default_gas" json:"default_gas"`
-		MaxGas            uint64         `toml:"max_gas" json:"max_gas"`
-		GasPrice          GasPrice       `toml:"gas_price,inline" json:"gas_price"`
-		GasMultiplier     float64        `toml:"gas_multiplier" json:"gas_multiplier"`
-		MaxMsgNum         uint64         `toml:"max_msg_num" json:"max_msg_num"`
-		MaxTxSize         uint64         `toml:"max_tx_size" json:"max_tx_size"`
-		ClockDrift        string         `toml:"clock_drift" json:"clock_drift"`
-		MaxBlockTime      string         `toml:"max_block_time" json:"max_block_time"`
-		TrustingPeriod    string         `toml:"trusting_period" json:"trusting_period"`
-		TrustThreshold    TrustThreshold `toml:"trust_threshold,inline" json:"trust_threshold"`
-		MemoPrefix        string         `toml:"memo_prefix" json:"memo_prefix"`
-		SequentialBatchTx bool           `toml:"sequential_batch_tx" json:"sequential_batch_tx"`
-	}
+		ID                string            `toml:"id" json:"id"`
+		ChainType         string            `toml:"type" json:"type"`
+		CCVConsumerChain  bool              `toml:"ccv_consumer_chain" json:"ccv_consumer_chain"`
+		RPCAddr           string            `toml:"rpc_addr" json:"rpc_addr"`
+		GRPCAddr          string            `toml:"grpc_addr" json:"grpc_addr"`
+		EventSource       EventSource       `toml:"event_source,inline" json:"event_source"`
+		RPCTimeout        string            `toml:"rpc_timeout" json:"rpc_timeout"`
+		TrustedNode       bool              `toml:"trusted_node" json:"trusted_node"`
+		AccountPrefix     string            `toml:"account_prefix" json:"account_prefix"`
+		KeyName           string            `toml:"key_name" json:"key_name"`
+		AddressType       AddressType       `toml:"address_type,inline" json:"address_type"`
+		KeyStoreType      string            `toml:"key_store_type" json:"key_store_type"`
+		KeyStoreFolder    string            `toml:"key_store_folder,omitempty" json:"key_store_folder,omitempty"`
+		StorePrefix       string            `toml:"store_prefix" json:"store_prefix"`
+		DefaultGas        uint64            `toml:"default_gas" json:"default_gas"`
+		MaxGas            uint64            `toml:"max_gas" json:"max_gas"`
+		GasPrice          GasPrice          `toml:"gas_price,inline" json:"gas_price"`
+		GasMultiplier     float64           `toml:"gas_multiplier" json:"gas_multiplier"`
+		MaxMsgNum         uint64            `toml:"max_msg_num" json:"max_msg_num"`
+		MaxTxSize         uint64            `toml:"max_tx_size" json:"max_tx_size"`
+		ClockDrift        string            `toml:"clock_drift" json:"clock_drift"`
+		MaxBlockTime      string            `toml:"max_block_time" json:"max_block_time"`
+		TrustingPeriod    string            `toml:"trusting_period" json:"trusting_period"`
+		TrustThreshold    TrustThreshold    `toml:"trust_threshold,inline" json:"trust_threshold"`
+		MemoPrefix        string            `toml:"memo_prefix" json:"memo_prefix"`
+		SequentialBatchTx bool              `toml:"sequential_batch_tx" json:"sequential_batch_tx"`
+		DynamicGasPrice   DynamicGasPrice   `toml:"dynamic_gas_price,inline,omitempty" json:"dynamic_gas_price,omitempty"`
+		FeeGranter        string            `toml:"fee_granter,omitempty" json:"fee_granter,omitempty"`
+		ExcludedSequences ExcludedSequences `toml:"excluded_sequences,inline,omitempty" json:"excluded_sequences,omitempty"`
+		PacketFilter      PacketFilter      `toml:"packet_filter,inline,omitempty" json:"packet_filter,omitempty"`
+		CompatMode        string            `toml:"compat_mode,omitempty" json:"compat_mode,omitempty"`
+	}
+
+	// DynamicGasPrice represents the chain dynamic gas price into the Hermes config struct,
+	// queried from the chain fee market module instead of the static gas price.
+	DynamicGasPrice struct {
+		Enabled    bool    `toml:"enabled" json:"enabled"`
+		Multiplier float64 `toml:"multiplier" json:"multiplier"`
+		Max        float64 `toml:"max" json:"max"`
+	}
+
+	// ExcludedSequences represents the packet sequences by channel ID the relayer never clears.
+	ExcludedSequences map[string][]uint64
+
+	// PacketFilter represents the chain packet filter into the Hermes config struct.
+	PacketFilter struct {
+		Policy string       `toml:"policy" json:"policy"`
+		List   []PortFilter `toml:"list" json:"list"`
+	}
+
+	// PortFilter represents a port and channel ID pair of the packet filter, each one may
+	// contain * wildcards.
+	PortFilter [2]string
 
 	// EventSource represents the chain event source into the Hermes config struct.
 	EventSource struct {
@@ -148,12 +183,85 @@ type (
 		Derivation string `toml:"derivation" json:"derivation"`
 	}
 
+	// SavedConfig represents a Hermes config file saved into the config dir.
+	SavedConfig struct {
+		Name   string
+		Path   string
+		Config *Config
+	}
+
 	// ChainOption configures chain hermes configs.
 	ChainOption func(*Chain)
 	// ConfigOption configures hermes configs.
 	ConfigOption func(*Config)
 )
 
+// Port returns the port ID pattern of the filter.
+func (f PortFilter) Port() string {
+	return f[0]
+}
+
+// Channel returns the channel ID pattern of the filter.
+func (f PortFilter) Channel() string {
+	return f[1]
+}
+
+// ParsePortFilter parses a packet filter in the format <port-id>/<channel-id>, each one may
+// contain * wildcards.
+func ParsePortFilter(value string) (PortFilter, error) {
+	port, channel, ok := strings.Cut(value, "/")
+	if !ok || port == "" || channel == "" {
+		return PortFilter{}, errors.Errorf("invalid packet filter %s, expected <port-id>/<channel-id>", value)
+	}
+	filter := PortFilter{port, channel}
+	return filter, filter.Validate()
+}
+
+// maxExcludedSequences is the max number of excluded sequences of a channel, Hermes
+// lists each excluded sequence into the config.
+const maxExcludedSequences = 10000
+
+// ParseExcludedSequences parses the excluded sequences in the format <channel-id>=<sequence>
+// or <channel-id>=<from>-<to> for a sequence range.
+func ParseExcludedSequences(values []string) (ExcludedSequences, error) {
+	sequences := make(ExcludedSequences)
+	for _, value := range values {
+		channelID, seq, ok := strings.Cut(value, "=")
+		if !ok || channelID == "" || seq == "" {
+			return nil, errors.Errorf("invalid excluded sequence %s, expected <channel-id>=<sequence>", value)
+		}
+
+		from, to, isRange := strings.Cut(seq, "-")
+		start, err := strconv.ParseUint(from, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid excluded sequence %s", value)
+		}
+		end := start
+		if isRange {
+			if end, err = strconv.ParseUint(to, 10, 64); err != nil {
+				return nil, errors.Wrapf(err, "invalid excluded sequence %s", value)
+			}
+			if end < start {
+				return nil, errors.Errorf("invalid excluded sequence range %s", value)
+			}
+		}
+		if end-start >= maxExcludedSequences || len(sequences[channelID])+int(end-start)+1 > maxExcludedSequences {
+			return nil, errors.Errorf(
+				"too many excluded sequences for %s, the max is %d",
+				channelID,
+				maxExcludedSequences,
+			)
+		}
+		for s := start; ; s++ {
+			sequences[channelID] = append(sequences[channelID], s)
+			if s == end {
+				break
+			}
+		}
+	}
+	return sequences, nil
+}
+
 // Get returns the chain by chain id.
 func (c Chains) Get(chainID string) (Chain, error) {
 	for _, chain := range c {
@@ -179,7 +287,11 @@ func (c *Config) Save() error {
 	if err != nil {
 		return err
 	}
+	return c.SaveFile(configPath)
+}
 
+// SaveFile saves the Hermes config into a specific file path.
+func (c *Config) SaveFile(configPath string) error {
 	if err := os.MkdirAll(filepath.Dir(configPath), 0o755); err != nil {
 		return err
 	}
@@ -216,6 +328,15 @@ func (c *Config) ConfigPath() (string, error) {
 
 // ConfigPath generates a config file path.
 func ConfigPath(cfgName string) (string, error) {
+	configDir, err := ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, cfgName), nil
+}
+
+// ConfigDir returns the directory of the Hermes config files.
+func ConfigDir() (string, error) {
 	userHomeDir, err := os.UserHomeDir()
 	if err != nil {
 		return "", err
@@ -225,18 +346,92 @@ func ConfigPath(cfgName string) (string, error) {
 		".ignite",
 		"relayer",
 		"hermes",
-		cfgName,
 	), nil
 }
 
+// FindConfig returns the path of the config file containing all the chains. It returns
+// ErrAmbiguousConfig listing the candidates if more than one config contains the chains.
+func FindConfig(chainIDs ...string) (string, error) {
+	configs, err := ListConfigs()
+	if err != nil {
+		return "", err
+	}
+	matches := make([]SavedConfig, 0)
+	for _, saved := range configs {
+		if saved.Config.HasChains(chainIDs...) {
+			matches = append(matches, saved)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return "", errors.Errorf("no Hermes config found for the chains %s", strings.Join(chainIDs, ", "))
+	case 1:
+		return matches[0].Path, nil
+	}
+
+	candidates := make([]string, 0, len(matches))
+	for _, saved := range matches {
+		candidates = append(candidates, saved.Name)
+	}
+	return "", errors.Wrapf(
+		ErrAmbiguousConfig,
+		"the chains %s are relayed by the configs %s",
+		strings.Join(chainIDs, ", "),
+		strings.Join(candidates, ", "),
+	)
+}
+
+// ListConfigs returns the Hermes configs saved into the config dir, skipping the invalid files.
+func ListConfigs() ([]SavedConfig, error) {
+	configDir, err := ConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(configDir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	configs := make([]SavedConfig, 0)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		cfgPath := filepath.Join(configDir, entry.Name())
+		cfg, err := LoadConfig(cfgPath)
+		if err != nil {
+			continue
+		}
+		configs = append(configs, SavedConfig{
+			Name:   entry.Name(),
+			Path:   cfgPath,
+			Config: cfg,
+		})
+	}
+	return configs, nil
+}
+
+// HasChains returns true if the config contains all the chains.
+func (c *Config) HasChains(chainIDs ...string) bool {
+	for _, chainID := range chainIDs {
+		if _, err := c.Chains.Get(chainID); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
 // LoadConfig loads a config from the path.
 func LoadConfig(cfgPath string) (*Config, error) {
 	cfgBytes, err := os.ReadFile(cfgPath)
 	if err != nil {
 		return nil, err
 	}
-	var cfg *Config
-	return cfg, toml.Unmarshal(cfgBytes, cfg)
+	var cfg Config
+	if err := toml.Unmarshal(cfgBytes, &cfg); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse the Hermes config %s", cfgPath)
+	}
+	return &cfg, nil
 }
 
 // DefaultConfigPath returns the default Hermes config path.
@@ -477,6 +672,13 @@ func WithChainKeyStoreType(keyStoreType string) ChainOption {
 	}
 }
 
+// WithChainKeyStoreFolder set the chain key store folder into the Hermes config.
+func WithChainKeyStoreFolder(folder string) ChainOption {
+	return func(c *Chain) {
+		c.KeyStoreFolder = folder
+	}
+}
+
 // WithChainStorePrefix set the chain store prefix into the Hermes config.
 func WithChainStorePrefix(prefix string) ChainOption {
 	return func(c *Chain) {
@@ -567,6 +769,48 @@ func WithChainAddressType(derivation string) ChainOption {
 	}
 }
 
+// WithChainDynamicGasPrice set the chain dynamic gas price into the Hermes config.
+func WithChainDynamicGasPrice(enabled bool, multiplier, maxPrice float64) ChainOption {
+	return func(c *Chain) {
+		c.DynamicGasPrice = DynamicGasPrice{
+			Enabled:    enabled,
+			Multiplier: multiplier,
+			Max:        maxPrice,
+		}
+	}
+}
+
+// WithChainFeeGranter set the chain fee granter address into the Hermes config.
+func WithChainFeeGranter(feeGranter string) ChainOption {
+	return func(c *Chain) {
+		c.FeeGranter = feeGranter
+	}
+}
+
+// WithChainExcludedSequences set the chain packet sequences excluded from clearing into the Hermes config.
+func WithChainExcludedSequences(sequences ExcludedSequences) ChainOption {
+	return func(c *Chain) {
+		c.ExcludedSequences = sequences
+	}
+}
+
+// WithChainPacketFilter set the chain packet filter policy and port channel list into the Hermes config.
+func WithChainPacketFilter(policy string, list ...PortFilter) ChainOption {
+	return func(c *Chain) {
+		c.PacketFilter = PacketFilter{
+			Policy: policy,
+			List:   list,
+		}
+	}
+}
+
+// WithChainCompatMode set the chain CometBFT compatibility mode into the Hermes config.
+func WithChainCompatMode(compatMode string) ChainOption {
+	return func(c *Chain) {
+		c.CompatMode = compatMode
+	}
+}
+
 // AddChain adds a new chain into the Hermes config.
 func (c *Config) AddChain(chainID, rpcAddr, grpcAddr string, options ...ChainOption) (Chain, error) {
 	rpcURL, err := url.Parse(rpcAddr)
@@ -0,0 +1,144 @@
+package hermes
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindConfig(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	_, err := FindConfig("mars-1")
+	require.EqualError(t, err, "no Hermes config found for the chains mars-1")
+
+	for _, chainIDs := range [][]string{{"mars-1", "venus-1"}, {"earth-1", "venus-1"}} {
+		c := DefaultConfig()
+		for _, chainID := range chainIDs {
+			_, err := c.AddChain(chainID, "http://localhost:26657", "http://localhost:9090")
+			require.NoError(t, err)
+		}
+		require.NoError(t, c.Save())
+	}
+
+	// invalid config files are ignored.
+	configDir, err := ConfigDir()
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, "invalid"), []byte("chains = ["), 0o644))
+
+	got, err := FindConfig("earth-1")
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(configDir, "earth-1_venus-1"), got)
+
+	got, err = FindConfig("venus-1", "mars-1")
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(configDir, "mars-1_venus-1"), got)
+
+	_, err = FindConfig("mars-1", "earth-1")
+	require.EqualError(t, err, "no Hermes config found for the chains mars-1, earth-1")
+
+	// the chains relayed by many configs must be selected by config.
+	_, err = FindConfig("venus-1")
+	require.ErrorIs(t, err, ErrAmbiguousConfig)
+	require.EqualError(t, err, "the chains venus-1 are relayed by the configs earth-1_venus-1, mars-1_venus-1: ambiguous Hermes config")
+}
+
+func TestConfigChainSections(t *testing.T) {
+	c := DefaultConfig()
+	_, err := c.AddChain("mars-1", "http://localhost:26657", "http://localhost:9090")
+	require.NoError(t, err)
+	_, err = c.AddChain(
+		"venus-1",
+		"http://localhost:26667",
+		"http://localhost:9100",
+		WithChainDynamicGasPrice(true, 1.2, 0.6),
+		WithChainFeeGranter("cosmos1granter"),
+		WithChainExcludedSequences(ExcludedSequences{"channel-0": {1, 2}}),
+		WithChainPacketFilter(PacketFilterAllow, PortFilter{"transfer", "channel-*"}),
+		WithChainCompatMode("0.37"),
+	)
+	require.NoError(t, err)
+
+	cfgPath := filepath.Join(t.TempDir(), "config.toml")
+	require.NoError(t, c.SaveFile(cfgPath))
+	data, err := os.ReadFile(cfgPath)
+	require.NoError(t, err)
+	require.Contains(t, string(data), "packet_filter = {policy = 'allow', list = [['transfer', 'channel-*']]}")
+	require.Contains(t, string(data), "excluded_sequences = {channel-0 = [1, 2]}")
+
+	got, err := LoadConfig(cfgPath)
+	require.NoError(t, err)
+	require.Equal(t, c, got)
+
+	// the optional sections are omitted when not set.
+	require.Equal(t, 1, strings.Count(string(data), "dynamic_gas_price"))
+	require.Equal(t, 1, strings.Count(string(data), "compat_mode"))
+}
+
+func TestParsePortFilter(t *testing.T) {
+	got, err := ParsePortFilter("transfer/channel-*")
+	require.NoError(t, err)
+	require.Equal(t, "transfer", got.Port())
+	require.Equal(t, "channel-*", got.Channel())
+
+	for _, value := range []string{"transfer", "transfer/", "/channel-0", "transfer/channel-?", "transfer/channel-[0-9]"} {
+		_, err := ParsePortFilter(value)
+		require.Error(t, err, value)
+	}
+}
+
+func TestParseExcludedSequences(t *testing.T) {
+	got, err := ParseExcludedSequences([]string{"channel-0=1", "channel-0=5-7", "channel-1=2"})
+	require.NoError(t, err)
+	require.Equal(t, ExcludedSequences{"channel-0": {1, 5, 6, 7}, "channel-1": {2}}, got)
+
+	for _, value := range []string{"channel-0", "channel-0=", "=1", "channel-0=a", "channel-0=7-5", "channel-0=1-b"} {
+		_, err := ParseExcludedSequences([]string{value})
+		require.Error(t, err, value)
+	}
+
+	got, err = ParseExcludedSequences([]string{"channel-0=18446744073709551614-18446744073709551615"})
+	require.NoError(t, err)
+	require.Equal(t, ExcludedSequences{"channel-0": {18446744073709551614, 18446744073709551615}}, got)
+
+	for _, values := range [][]string{
+		{"channel-0=1-18446744073709551615"},
+		{"channel-0=1-1000000000"},
+		{"channel-0=1-10001"},
+		{"channel-0=1-9999", "channel-0=20000-20001"},
+	} {
+		_, err := ParseExcludedSequences(values)
+		require.ErrorContains(t, err, "too many excluded sequences for channel-0", values)
+	}
+}
+
+func TestListConfigs(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	configs, err := ListConfigs()
+	require.NoError(t, err)
+	require.Empty(t, configs)
+
+	c := DefaultConfig()
+	for _, chainID := range []string{"mars-1", "venus-1"} {
+		_, err := c.AddChain(chainID, "http://localhost:26657", "http://localhost:9090")
+		require.NoError(t, err)
+	}
+	require.NoError(t, c.Save())
+
+	// the sub directories and invalid files are skipped.
+	configDir, err := ConfigDir()
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Join(configDir, "daemon"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, "invalid"), []byte("chains = ["), 0o644))
+
+	configs, err = ListConfigs()
+	require.NoError(t, err)
+	require.Len(t, configs, 1)
+	require.Equal(t, "mars-1_venus-1", configs[0].Name)
+	require.Equal(t, filepath.Join(configDir, "mars-1_venus-1"), configs[0].Path)
+	require.Equal(t, []string{"mars-1", "venus-1"}, configs[0].Config.Chains.IDs())
+}
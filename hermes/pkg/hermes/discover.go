@@ -0,0 +1,155 @@
+package hermes
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	sdkmath "cosmossdk.io/math"
+	"github.com/cosmos/cosmos-sdk/client/grpc/cmtservice"
+	"github.com/cosmos/cosmos-sdk/client/grpc/node"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/ignite/cli/v28/ignite/pkg/cosmosclient"
+	"github.com/ignite/cli/v28/ignite/pkg/errors"
+)
+
+const (
+	// blockTimeSamples is the number of recent blocks used to compute the block time.
+	blockTimeSamples = 20
+	// maxBlockTimeFactor is the max block time in block times.
+	maxBlockTimeFactor = 3
+)
+
+// ChainParams represents the chain parameters discovered from the chain node.
+type ChainParams struct {
+	ChainID         string
+	AccountPrefix   string
+	UnbondingPeriod time.Duration
+	// FeeDenom is the denom of the node minimum gas price, or the bond denom if not set.
+	FeeDenom string
+	// MinGasPrice is the node minimum gas price of the fee denom, zero if not set.
+	MinGasPrice sdk.DecCoin
+	// BlockTime is the average time between the recent blocks.
+	BlockTime  time.Duration
+	SDKVersion string
+}
+
+// TrustingPeriod returns two thirds of the unbonding period, the default Hermes trusting period.
+func (p ChainParams) TrustingPeriod() string {
+	return FormatDuration(p.UnbondingPeriod * 2 / 3)
+}
+
+// MaxBlockTime returns the max block time as three block times, rounded up to the second.
+func (p ChainParams) MaxBlockTime() string {
+	maxBlockTime := (p.BlockTime*maxBlockTimeFactor + time.Second - 1).Truncate(time.Second)
+	return FormatDuration(maxBlockTime)
+}
+
+// GasPrice returns the node minimum gas price or, if not set, the default price in the fee denom.
+func (p ChainParams) GasPrice(defaultPrice sdkmath.LegacyDec) sdk.DecCoin {
+	if p.MinGasPrice.Amount.IsNil() || p.MinGasPrice.Amount.IsZero() {
+		return sdk.NewDecCoinFromDec(p.FeeDenom, defaultPrice)
+	}
+	return p.MinGasPrice
+}
+
+// DiscoverChainParams queries the chain parameters from the chain RPC and gRPC endpoints.
+func DiscoverChainParams(ctx context.Context, rpcAddr, grpcAddr string) (ChainParams, error) {
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+
+	var params ChainParams
+	client, err := cosmosclient.New(ctx, cosmosclient.WithNodeAddress(rpcAddr))
+	if err != nil {
+		return params, errors.Wrapf(err, "unreachable RPC %s", rpcAddr)
+	}
+	status, err := client.Status(ctx)
+	if err != nil {
+		return params, err
+	}
+	params.ChainID = status.NodeInfo.Network
+
+	if params.BlockTime, err = blockTime(ctx, client, status.SyncInfo.LatestBlockHeight); err != nil {
+		return params, err
+	}
+
+	conn, err := grpcConn(grpcAddr)
+	if err != nil {
+		return params, err
+	}
+	defer conn.Close()
+
+	prefix, err := authtypes.NewQueryClient(conn).Bech32Prefix(ctx, &authtypes.Bech32PrefixRequest{})
+	if err != nil {
+		return params, errors.Wrapf(err, "failed to query the %s account prefix", params.ChainID)
+	}
+	params.AccountPrefix = prefix.Bech32Prefix
+
+	staking, err := stakingtypes.NewQueryClient(conn).Params(ctx, &stakingtypes.QueryParamsRequest{})
+	if err != nil {
+		return params, errors.Wrapf(err, "failed to query the %s staking params", params.ChainID)
+	}
+	params.UnbondingPeriod = staking.Params.UnbondingTime
+	params.FeeDenom = staking.Params.BondDenom
+
+	// the node config query is not available before the SDK v0.46.
+	if config, err := node.NewServiceClient(conn).Config(ctx, &node.ConfigRequest{}); err == nil && config.MinimumGasPrice != "" {
+		prices, err := sdk.ParseDecCoins(config.MinimumGasPrice)
+		if err != nil {
+			return params, errors.Wrapf(err, "invalid %s minimum gas price %s", params.ChainID, config.MinimumGasPrice)
+		}
+		if len(prices) > 0 {
+			params.MinGasPrice = prices[0]
+			if amount := prices.AmountOf(params.FeeDenom); amount.IsPositive() {
+				params.MinGasPrice = sdk.NewDecCoinFromDec(params.FeeDenom, amount)
+			}
+			params.FeeDenom = params.MinGasPrice.Denom
+		}
+	}
+
+	nodeInfo, err := cmtservice.NewServiceClient(conn).GetNodeInfo(ctx, &cmtservice.GetNodeInfoRequest{})
+	if err != nil {
+		return params, errors.Wrapf(err, "failed to query the %s node info", params.ChainID)
+	}
+	if nodeInfo.ApplicationVersion != nil {
+		params.SDKVersion = nodeInfo.ApplicationVersion.CosmosSdkVersion
+	}
+	return params, nil
+}
+
+// blockTime returns the average time between the recent blocks.
+func blockTime(ctx context.Context, client cosmosclient.Client, latestHeight int64) (time.Duration, error) {
+	if latestHeight < 2 {
+		return 0, errors.New("not enough blocks to compute the block time")
+	}
+	minHeight := latestHeight - blockTimeSamples + 1
+	if minHeight < 1 {
+		minHeight = 1
+	}
+	info, err := client.RPC.BlockchainInfo(ctx, minHeight, latestHeight)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to query the recent blocks")
+	}
+	metas := info.BlockMetas
+	if len(metas) < 2 {
+		return 0, errors.New("not enough blocks to compute the block time")
+	}
+
+	// the block metas are sorted from the newest to the oldest.
+	var (
+		newest = metas[0].Header
+		oldest = metas[len(metas)-1].Header
+	)
+	return newest.Time.Sub(oldest.Time) / time.Duration(newest.Height-oldest.Height), nil
+}
+
+// FormatDuration formats a duration in the humantime format of the Hermes config,
+// in days if it is a whole number of days, otherwise in seconds.
+func FormatDuration(d time.Duration) string {
+	if d%day == 0 {
+		return fmt.Sprintf("%ddays", d/day)
+	}
+	return fmt.Sprintf("%ds", d/time.Second)
+}
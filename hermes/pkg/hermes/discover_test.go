@@ -0,0 +1,44 @@
+package hermes
+
+import (
+	"testing"
+	"time"
+
+	sdkmath "cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChainParams(t *testing.T) {
+	params := ChainParams{
+		UnbondingPeriod: 21 * 24 * time.Hour,
+		BlockTime:       5200 * time.Millisecond,
+	}
+	require.Equal(t, "14days", params.TrustingPeriod())
+	require.Equal(t, "16s", params.MaxBlockTime())
+
+	params = ChainParams{
+		UnbondingPeriod: 30 * time.Minute,
+		BlockTime:       time.Second,
+	}
+	require.Equal(t, "1200s", params.TrustingPeriod())
+	require.Equal(t, "3s", params.MaxBlockTime())
+}
+
+func TestChainParamsGasPrice(t *testing.T) {
+	defaultPrice := sdkmath.LegacyMustNewDecFromStr("0.01")
+
+	params := ChainParams{FeeDenom: "stake"}
+	require.Equal(t, "0.010000000000000000stake", params.GasPrice(defaultPrice).String())
+
+	params.MinGasPrice = sdk.NewDecCoinFromDec("uatom", sdkmath.LegacyMustNewDecFromStr("0.025"))
+	require.Equal(t, "0.025000000000000000uatom", params.GasPrice(defaultPrice).String())
+}
+
+func TestFormatDuration(t *testing.T) {
+	for _, d := range []time.Duration{14 * 24 * time.Hour, 90 * time.Minute, 15 * time.Second} {
+		parsed, err := ParseDuration(FormatDuration(d))
+		require.NoError(t, err)
+		require.Equal(t, d, parsed)
+	}
+}
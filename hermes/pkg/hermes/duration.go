@@ -0,0 +1,67 @@
+package hermes
+
+import (
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/ignite/cli/v28/ignite/pkg/errors"
+)
+
+const (
+	day   = 24 * time.Hour
+	week  = 7 * day
+	month = time.Duration(30.44 * float64(day))
+	year  = time.Duration(365.25 * float64(day))
+)
+
+// durationUnits are the duration units of the Hermes config, using the Rust humantime format.
+var durationUnits = map[string]time.Duration{
+	"nsec": time.Nanosecond, "ns": time.Nanosecond,
+	"usec": time.Microsecond, "us": time.Microsecond,
+	"msec": time.Millisecond, "ms": time.Millisecond,
+	"seconds": time.Second, "second": time.Second, "sec": time.Second, "secs": time.Second, "s": time.Second,
+	"minutes": time.Minute, "minute": time.Minute, "min": time.Minute, "mins": time.Minute, "m": time.Minute,
+	"hours": time.Hour, "hour": time.Hour, "hr": time.Hour, "hrs": time.Hour, "h": time.Hour,
+	"days": day, "day": day, "d": day,
+	"weeks": week, "week": week, "w": week,
+	"months": month, "month": month, "M": month,
+	"years": year, "year": year, "y": year,
+}
+
+// ParseDuration parses a duration of the Hermes config in the humantime format, e.g. "14days" or "1h 30m".
+func ParseDuration(s string) (time.Duration, error) {
+	rest := strings.TrimSpace(s)
+	if rest == "" {
+		return 0, errors.New("empty duration")
+	}
+
+	var total time.Duration
+	for rest != "" {
+		end := strings.IndexFunc(rest, func(r rune) bool { return !unicode.IsDigit(r) })
+		if end == 0 {
+			return 0, errors.Errorf("invalid duration %s: expected a number", s)
+		}
+		if end < 0 {
+			return 0, errors.Errorf("invalid duration %s: missing unit", s)
+		}
+		value, err := strconv.ParseUint(rest[:end], 10, 64)
+		if err != nil {
+			return 0, errors.Errorf("invalid duration %s: %s", s, err)
+		}
+
+		rest = strings.TrimLeftFunc(rest[end:], unicode.IsSpace)
+		end = strings.IndexFunc(rest, func(r rune) bool { return unicode.IsDigit(r) || unicode.IsSpace(r) })
+		if end < 0 {
+			end = len(rest)
+		}
+		unit, ok := durationUnits[rest[:end]]
+		if !ok {
+			return 0, errors.Errorf("invalid duration %s: unknown unit %q", s, rest[:end])
+		}
+		total += time.Duration(value) * unit
+		rest = strings.TrimSpace(rest[end:])
+	}
+	return total, nil
+}
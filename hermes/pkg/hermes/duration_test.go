@@ -0,0 +1,39 @@
+package hermes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  time.Duration
+		err   string
+	}{
+		{name: "milliseconds", value: "500ms", want: 500 * time.Millisecond},
+		{name: "seconds", value: "15s", want: 15 * time.Second},
+		{name: "days", value: "14days", want: 14 * 24 * time.Hour},
+		{name: "combined", value: "1h 30m", want: 90 * time.Minute},
+		{name: "combined without space", value: "1d12h", want: 36 * time.Hour},
+		{name: "empty", value: "", err: "empty duration"},
+		{name: "missing unit", value: "10", err: "invalid duration 10: missing unit"},
+		{name: "space before unit", value: "10 days", want: 10 * 24 * time.Hour},
+		{name: "unknown unit", value: "10 parsecs", err: `invalid duration 10 parsecs: unknown unit "parsecs"`},
+		{name: "missing number", value: "days", err: "invalid duration days: expected a number"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDuration(tt.value)
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package hermes
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/ignite/cli/v28/ignite/pkg/errors"
+)
+
+// IsWildcard returns true if the port or the channel ID of the filter is a pattern.
+func (f PortFilter) IsWildcard() bool {
+	return strings.Contains(f.Port()+f.Channel(), "*")
+}
+
+// Match returns true if the port and channel IDs match the filter patterns.
+func (f PortFilter) Match(portID, channelID string) bool {
+	return matchWildcard(f.Port(), portID) && matchWildcard(f.Channel(), channelID)
+}
+
+// Validate checks the filter has the port and channel IDs and only uses the * wildcard,
+// the only wildcard supported by the Hermes packet filters.
+func (f PortFilter) Validate() error {
+	if f.Port() == "" || f.Channel() == "" {
+		return errors.Errorf("invalid packet filter %s, the port and channel are required", f)
+	}
+	if strings.ContainsAny(f.String(), "?[]") {
+		return errors.Errorf("invalid packet filter %s, only the * wildcard is supported", f)
+	}
+	return nil
+}
+
+// String returns the filter in the <port-id>/<channel-id> format.
+func (f PortFilter) String() string {
+	return f.Port() + "/" + f.Channel()
+}
+
+// IsEmpty returns true if the packet filter is not set, so all the channels are relayed.
+func (p PacketFilter) IsEmpty() bool {
+	return p.Policy == "" && len(p.List) == 0
+}
+
+// Allows returns true if the packets of the channel are relayed by the filter policy.
+func (p PacketFilter) Allows(portID, channelID string) bool {
+	if p.IsEmpty() {
+		return true
+	}
+	matched := slices.ContainsFunc(p.List, func(f PortFilter) bool {
+		return f.Match(portID, channelID)
+	})
+	if p.Policy == PacketFilterDeny {
+		return !matched
+	}
+	return matched
+}
+
+// Allow relays the packets of the filter channels, adding them to the allow list or removing
+// them from the deny list. An empty filter becomes an allow list, restricting the relayer to
+// the filter channels. It returns false if the filter channels are already allowed.
+func (p *PacketFilter) Allow(filter PortFilter) (bool, error) {
+	return p.set(filter, PacketFilterAllow, PacketFilterDeny)
+}
+
+// Deny stops relaying the packets of the filter channels, adding them to the deny list or
+// removing them from the allow list. It returns false if the filter channels are already denied.
+func (p *PacketFilter) Deny(filter PortFilter) (bool, error) {
+	return p.set(filter, PacketFilterDeny, PacketFilterAllow)
+}
+
+// set adds the filter to the list of the policy, or removes it from the list of the opposite policy.
+func (p *PacketFilter) set(filter PortFilter, policy, opposite string) (bool, error) {
+	if p.IsEmpty() {
+		p.Policy = policy
+	}
+
+	if p.Policy == policy {
+		if slices.Contains(p.List, filter) {
+			return false, nil
+		}
+		p.List = append(p.List, filter)
+		return true, nil
+	}
+
+	if i := slices.Index(p.List, filter); i >= 0 {
+		p.List = slices.Delete(p.List, i, i+1)
+		if p.Policy == PacketFilterDeny && len(p.List) == 0 {
+			*p = PacketFilter{}
+		}
+		return true, nil
+	}
+	for _, f := range p.List {
+		if f.Match(filter.Port(), filter.Channel()) {
+			return false, errors.Errorf(
+				"%s matches the %s filter %s, remove it before changing the filter policy",
+				filter,
+				opposite,
+				f,
+			)
+		}
+	}
+	return false, nil
+}
+
+// matchWildcard returns true if the value matches the pattern, where each * matches any
+// sequence of characters and all the other characters match themselves.
+func matchWildcard(pattern, value string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == value
+	}
+	if !strings.HasPrefix(value, parts[0]) {
+		return false
+	}
+	value = value[len(parts[0]):]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(value, part)
+		if i < 0 {
+			return false
+		}
+		value = value[i+len(part):]
+	}
+	return strings.HasSuffix(value, parts[len(parts)-1])
+}
+
+// Set replaces the chain with the same ID.
+func (c Chains) Set(chain Chain) error {
+	for i := range c {
+		if c[i].ID == chain.ID {
+			c[i] = chain
+			return nil
+		}
+	}
+	return errors.Errorf("chain %s not exist", chain.ID)
+}
@@ -0,0 +1,97 @@
+package hermes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPortFilterMatch(t *testing.T) {
+	tests := []struct {
+		filter    PortFilter
+		portID    string
+		channelID string
+		want      bool
+	}{
+		{filter: PortFilter{"transfer", "channel-0"}, portID: "transfer", channelID: "channel-0", want: true},
+		{filter: PortFilter{"transfer", "channel-0"}, portID: "transfer", channelID: "channel-1", want: false},
+		{filter: PortFilter{"transfer", "channel-*"}, portID: "transfer", channelID: "channel-12", want: true},
+		{filter: PortFilter{"ica*", "*"}, portID: "icahost", channelID: "channel-3", want: true},
+		{filter: PortFilter{"ica*", "*"}, portID: "transfer", channelID: "channel-3", want: false},
+		{filter: PortFilter{"*host", "channel-*-*"}, portID: "icahost", channelID: "channel-1-2", want: true},
+		{filter: PortFilter{"*host", "channel-*-*"}, portID: "icahost", channelID: "channel-1", want: false},
+		{filter: PortFilter{"transfer", "channel-?"}, portID: "transfer", channelID: "channel-1", want: false},
+		{filter: PortFilter{"transfer", "channel-[0-9]"}, portID: "transfer", channelID: "channel-1", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filter.String(), func(t *testing.T) {
+			require.Equal(t, tt.want, tt.filter.Match(tt.portID, tt.channelID))
+		})
+	}
+	require.True(t, PortFilter{"transfer", "channel-*"}.IsWildcard())
+	require.False(t, PortFilter{"transfer", "channel-0"}.IsWildcard())
+	require.False(t, PortFilter{"transfer", "channel-?"}.IsWildcard())
+}
+
+func TestPortFilterValidate(t *testing.T) {
+	require.NoError(t, PortFilter{"transfer", "channel-*"}.Validate())
+	for _, filter := range []PortFilter{
+		{"transfer", ""},
+		{"", "channel-0"},
+		{"transfer", "channel-?"},
+		{"transfer", "channel-[0-9]"},
+		{"ica]", "channel-0"},
+	} {
+		require.Error(t, filter.Validate(), filter.String())
+	}
+}
+
+func TestPacketFilter(t *testing.T) {
+	var filter PacketFilter
+	require.True(t, filter.Allows("transfer", "channel-0"))
+
+	// allowing a channel restricts the relayer to it.
+	changed, err := filter.Allow(PortFilter{"transfer", "channel-0"})
+	require.NoError(t, err)
+	require.True(t, changed)
+	require.Equal(t, PacketFilterAllow, filter.Policy)
+	require.True(t, filter.Allows("transfer", "channel-0"))
+	require.False(t, filter.Allows("transfer", "channel-1"))
+
+	changed, err = filter.Allow(PortFilter{"transfer", "channel-0"})
+	require.NoError(t, err)
+	require.False(t, changed)
+
+	changed, err = filter.Deny(PortFilter{"transfer", "channel-1"})
+	require.NoError(t, err)
+	require.False(t, changed)
+
+	changed, err = filter.Deny(PortFilter{"transfer", "channel-0"})
+	require.NoError(t, err)
+	require.True(t, changed)
+	require.Empty(t, filter.List)
+	require.False(t, filter.Allows("transfer", "channel-0"))
+
+	// a deny list relays all the other channels and is cleared when empty.
+	filter = PacketFilter{}
+	changed, err = filter.Deny(PortFilter{"transfer", "channel-*"})
+	require.NoError(t, err)
+	require.True(t, changed)
+	require.False(t, filter.Allows("transfer", "channel-4"))
+	require.True(t, filter.Allows("icahost", "channel-4"))
+
+	_, err = filter.Allow(PortFilter{"transfer", "channel-4"})
+	require.ErrorContains(t, err, "matches the deny filter transfer/channel-*")
+
+	changed, err = filter.Allow(PortFilter{"transfer", "channel-*"})
+	require.NoError(t, err)
+	require.True(t, changed)
+	require.True(t, filter.IsEmpty())
+}
+
+func TestChainsSet(t *testing.T) {
+	chains := Chains{{ID: "mars-1"}, {ID: "venus-1"}}
+	require.NoError(t, chains.Set(Chain{ID: "venus-1", KeyName: "relayer"}))
+	require.Equal(t, "relayer", chains[1].KeyName)
+	require.Error(t, chains.Set(Chain{ID: "earth-1"}))
+}
@@ -0,0 +1,64 @@
+package hermes
+
+import (
+	"context"
+	"time"
+)
+
+// ClientHealth represents the expiry state of a client.
+type ClientHealth struct {
+	HostChain      string
+	ClientID       string
+	ReferenceChain string
+	Status         string
+	TrustingPeriod time.Duration
+	LatestHeight   ConsensusHeight
+	LastUpdate     time.Time
+}
+
+// ExpiresAt returns the time the client expires if it is not updated.
+func (c ClientHealth) ExpiresAt() time.Time {
+	return c.LastUpdate.Add(c.TrustingPeriod)
+}
+
+// TimeToExpiry returns the time left until the client expires.
+func (c ClientHealth) TimeToExpiry(now time.Time) time.Duration {
+	return c.ExpiresAt().Sub(now)
+}
+
+// NearExpiry returns true if the client expires within the threshold. A zero
+// threshold uses a third of the trusting period, after which Hermes refreshes the client.
+func (c ClientHealth) NearExpiry(now time.Time, threshold time.Duration) bool {
+	if threshold == 0 {
+		threshold = c.TrustingPeriod / 3
+	}
+	return c.TimeToExpiry(now) < threshold
+}
+
+// ClientHealth returns the client status, trusting period and last update.
+func (h *Hermes) ClientHealth(ctx context.Context, hostChain, clientID string, options ...Option) (ClientHealth, error) {
+	state, err := h.ClientState(ctx, hostChain, clientID, options...)
+	if err != nil {
+		return ClientHealth{}, err
+	}
+	status, err := h.ClientStatus(ctx, hostChain, clientID, options...)
+	if err != nil {
+		return ClientHealth{}, err
+	}
+
+	latestHeight := state.Tendermint.LatestHeight
+	consensus, err := h.ConsensusState(ctx, hostChain, clientID, uint64(latestHeight.RevisionHeight), options...)
+	if err != nil {
+		return ClientHealth{}, err
+	}
+
+	return ClientHealth{
+		HostChain:      hostChain,
+		ClientID:       clientID,
+		ReferenceChain: state.Tendermint.ChainID,
+		Status:         status,
+		TrustingPeriod: state.Tendermint.TrustingPeriod.Duration(),
+		LatestHeight:   latestHeight,
+		LastUpdate:     consensus.Tendermint.Timestamp,
+	}, nil
+}
@@ -0,0 +1,66 @@
+package hermes
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientHealth(t *testing.T) {
+	h := newFakeHermes(t)
+
+	health, err := h.ClientHealth(context.Background(), "mars-1", "07-tendermint-0")
+	require.NoError(t, err)
+	require.Equal(t, ClientHealth{
+		HostChain:      "mars-1",
+		ClientID:       "07-tendermint-0",
+		ReferenceChain: "venus-1",
+		Status:         ClientStatusActive,
+		TrustingPeriod: 14 * 24 * time.Hour,
+		LatestHeight:   ConsensusHeight{RevisionNumber: 1, RevisionHeight: 42},
+		LastUpdate:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}, health)
+	require.Equal(t, time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), health.ExpiresAt())
+}
+
+func TestClientHealthNearExpiry(t *testing.T) {
+	health := ClientHealth{
+		TrustingPeriod: 12 * 24 * time.Hour,
+		LastUpdate:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	tests := []struct {
+		name      string
+		now       time.Time
+		threshold time.Duration
+		want      bool
+	}{
+		{
+			name: "recently updated",
+			now:  time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+			want: false,
+		},
+		{
+			name: "within a third of the trusting period",
+			now:  time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC),
+			want: true,
+		},
+		{
+			name:      "outside a custom threshold",
+			now:       time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC),
+			threshold: 24 * time.Hour,
+			want:      false,
+		},
+		{
+			name: "expired",
+			now:  time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, health.NearExpiry(tt.now, tt.threshold))
+		})
+	}
+}
@@ -1,37 +1,50 @@
 package hermes
 
 import (
-	"archive/tar"
 	"bytes"
-	"compress/gzip"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"os"
+	"time"
 
 	"github.com/ignite/cli/v28/ignite/pkg/cmdrunner/exec"
 	"github.com/ignite/cli/v28/ignite/pkg/cmdrunner/step"
 	"github.com/ignite/cli/v28/ignite/pkg/errors"
-	"github.com/ignite/cli/v28/ignite/pkg/localfs"
-	"github.com/ignite/ignite-files/hermes"
 )
 
 const (
-	FlagHostChain        = "host-chain"
-	FlagReferenceChain   = "reference-chain"
-	FlagChainA           = "a-chain"
-	FlagClientA          = "a-client"
-	FlagClientB          = "b-client"
-	FlagConnectionA      = "a-connection"
-	FlagPortA            = "a-port"
-	FlagPortB            = "b-port"
-	FlagShowCounterparty = "show-counterparty"
-	FlagChain            = "chain"
-	FlagMnemonicFile     = "mnemonic-file"
-	FlagKeyName          = "key-name"
-	FlagConfig           = "config"
-	FlagFullScan         = "full-scan"
+	FlagHostChain         = "host-chain"
+	FlagReferenceChain    = "reference-chain"
+	FlagChainA            = "a-chain"
+	FlagClientA           = "a-client"
+	FlagClientB           = "b-client"
+	FlagConnectionA       = "a-connection"
+	FlagPortA             = "a-port"
+	FlagPortB             = "b-port"
+	FlagShowCounterparty  = "show-counterparty"
+	FlagCounterpartyChain = "counterparty-chain"
+	FlagChain             = "chain"
+	FlagClient            = "client"
+	FlagConsensusHeight   = "consensus-height"
+	FlagPort              = "port"
+	FlagChannel           = "channel"
+	FlagConnection        = "connection"
+	FlagVerbose           = "verbose"
+	FlagMnemonicFile      = "mnemonic-file"
+	FlagKeyName           = "key-name"
+	FlagConfig            = "config"
+	FlagFullScan          = "full-scan"
+	FlagOverwrite         = "overwrite"
+	FlagSrcChain          = "src-chain"
+	FlagDstChain          = "dst-chain"
+	FlagSrcPort           = "src-port"
+	FlagSrcChannel        = "src-channel"
+	FlagAmount            = "amount"
+	FlagDenom             = "denom"
+	FlagReceiver          = "receiver"
+	FlagTimeoutSeconds    = "timeout-seconds"
 )
 
 const (
@@ -47,6 +60,15 @@ const (
 	// CommandStart is the Hermes start command.
 	cmdStart cmdName = "start"
 
+	// cmdClear is the Hermes clear command.
+	cmdClear cmdName = "clear"
+
+	// cmdUpdate is the Hermes update command.
+	cmdUpdate cmdName = "update"
+
+	// cmdTx is the Hermes tx command.
+	cmdTx cmdName = "tx"
+
 	// CommandClient is the Hermes create client command.
 	cmdClient subCmd = "client"
 
@@ -59,6 +81,48 @@ const (
 	// CommandChannels  is the Hermes query channels command.
 	cmdChannels subCmd = "channels"
 
+	// cmdClients is the Hermes query clients command.
+	cmdClients subCmd = "clients"
+
+	// cmdConnections is the Hermes query connections command.
+	cmdConnections subCmd = "connections"
+
+	// cmdStatus is the Hermes query client status command.
+	cmdStatus subCmd = "status"
+
+	// cmdState is the Hermes query client state command.
+	cmdState subCmd = "state"
+
+	// cmdConsensus is the Hermes query client consensus command.
+	cmdConsensus subCmd = "consensus"
+
+	// cmdPacket is the Hermes query packet command.
+	cmdPacket subCmd = "packet"
+
+	// cmdCommitments is the Hermes query packet commitments command.
+	cmdCommitments subCmd = "commitments"
+
+	// cmdAcknowledgments is the Hermes query packet acknowledgments command.
+	cmdAcknowledgments subCmd = "acknowledgments"
+
+	// cmdPending is the Hermes query packet pending command.
+	cmdPending subCmd = "pending"
+
+	// cmdUnreceivedPackets is the Hermes query packet unreceived-packets command.
+	cmdUnreceivedPackets subCmd = "unreceived-packets"
+
+	// cmdUnreceivedAcks is the Hermes query packet unreceived-acks command.
+	cmdUnreceivedAcks subCmd = "unreceived-acks"
+
+	// cmdPackets is the Hermes clear packets command.
+	cmdPackets subCmd = "packets"
+
+	// cmdEnd is the Hermes query connection and channel end command.
+	cmdEnd subCmd = "end"
+
+	// cmdFtTransfer is the Hermes tx ft-transfer command.
+	cmdFtTransfer subCmd = "ft-transfer"
+
 	// CommandKeysAdd is the Hermes keys add command.
 	cmdKeysAdd subCmd = "add"
 
@@ -73,6 +137,12 @@ const (
 
 	// ResultError is the api result status error.
 	ResultError = "error"
+
+	// StateOpen is the open state of connections and channels.
+	StateOpen = "Open"
+
+	// ClientStatusActive is the status of an active client.
+	ClientStatusActive = "Active"
 )
 
 // ErrResult indicates that Hermes binary returned an error.
@@ -170,6 +240,117 @@ type (
 		Secs  int `json:"secs"`
 	}
 
+	// ClientChainResult represents a client from the result of the query clients command.
+	ClientChainResult struct {
+		ClientID string `json:"client_id"`
+		ChainID  string `json:"chain_id"`
+	}
+
+	// ConnectionEndsResult represents a connection from the result of the verbose query connections command.
+	ConnectionEndsResult struct {
+		ConnectionID  string        `json:"connection_id"`
+		ConnectionEnd ConnectionEnd `json:"connection_end"`
+	}
+
+	// ConnectionEnd represents a connection end.
+	ConnectionEnd struct {
+		ClientID     string                 `json:"client_id"`
+		Counterparty ConnectionCounterparty `json:"counterparty"`
+		DelayPeriod  Time                   `json:"delay_period"`
+		State        string                 `json:"state"`
+	}
+
+	// ConnectionCounterparty represents the counterparty of a connection end.
+	ConnectionCounterparty struct {
+		ClientID     string `json:"client_id"`
+		ConnectionID string `json:"connection_id"`
+	}
+
+	// ChannelEnd represents the result of the query channel end command.
+	ChannelEnd struct {
+		State          ChannelState        `json:"state"`
+		Ordering       string              `json:"ordering"`
+		Remote         ChannelCounterparty `json:"remote"`
+		ConnectionHops []string            `json:"connection_hops"`
+	}
+
+	// ChannelCounterparty represents the counterparty of a channel end.
+	ChannelCounterparty struct {
+		PortID    string `json:"port_id"`
+		ChannelID string `json:"channel_id"`
+	}
+
+	// ChannelState represents the state of a channel end. Hermes serializes the open state
+	// of the channels supporting upgrades with their upgrade state, e.g. {"Open":"NotUpgrading"},
+	// only the state name is kept.
+	ChannelState string
+
+	// ChannelEndsResult represents the result of the query channels command with counterparty.
+	ChannelEndsResult struct {
+		ChainID                  string `json:"chain_id"`
+		ClientID                 string `json:"client_id"`
+		ConnectionID             string `json:"connection_id"`
+		ChannelID                string `json:"channel_id"`
+		PortID                   string `json:"port_id"`
+		CounterpartyChainID      string `json:"counterparty_chain_id"`
+		CounterpartyClientID     string `json:"counterparty_client_id"`
+		CounterpartyConnectionID string `json:"counterparty_connection_id"`
+		CounterpartyChannelID    string `json:"counterparty_channel_id"`
+		CounterpartyPortID       string `json:"counterparty_port_id"`
+	}
+
+	// ClientStateResult represents the result of the query client state command.
+	ClientStateResult struct {
+		Tendermint TendermintClientState `json:"Tendermint"`
+	}
+
+	// TendermintClientState represents a Tendermint light client state.
+	TendermintClientState struct {
+		ChainID         string           `json:"chain_id"`
+		TrustThreshold  Fraction         `json:"trust_threshold"`
+		TrustingPeriod  Time             `json:"trusting_period"`
+		UnbondingPeriod Time             `json:"unbonding_period"`
+		MaxClockDrift   Time             `json:"max_clock_drift"`
+		LatestHeight    ConsensusHeight  `json:"latest_height"`
+		FrozenHeight    *ConsensusHeight `json:"frozen_height"`
+	}
+
+	// ConsensusStateResult represents the result of the query client consensus command at a height.
+	ConsensusStateResult struct {
+		Tendermint TendermintConsensusState `json:"Tendermint"`
+	}
+
+	// TendermintConsensusState represents a Tendermint light client consensus state.
+	TendermintConsensusState struct {
+		Timestamp time.Time `json:"timestamp"`
+	}
+
+	// Fraction represents a fraction like the client trust threshold.
+	Fraction struct {
+		Numerator   json.Number `json:"numerator"`
+		Denominator json.Number `json:"denominator"`
+	}
+
+	// PacketSeqsResult represents the result of the query packet commitments and acknowledgments commands.
+	PacketSeqsResult struct {
+		Height ConsensusHeight `json:"height"`
+		Seqs   []uint64        `json:"seqs"`
+	}
+
+	// PendingPacketsResult represents the result of the query packet pending command.
+	PendingPacketsResult struct {
+		SrcChain string         `json:"src_chain"`
+		DstChain string         `json:"dst_chain"`
+		Src      PendingPackets `json:"src"`
+		Dst      PendingPackets `json:"dst"`
+	}
+
+	// PendingPackets represents the packets and acknowledgments not received by the counterparty chain.
+	PendingPackets struct {
+		UnreceivedPackets []uint64 `json:"unreceived_packets"`
+		UnreceivedAcks    []uint64 `json:"unreceived_acks"`
+	}
+
 	// ChannelResult represents the result of the create channel command.
 	ChannelResult struct {
 		ChainIDA string `json:"chain_id_a"`
@@ -242,41 +423,9 @@ func WithStdErr(stderr io.Writer) Option {
 	}
 }
 
-// New returns the hermes binary executable.
-func New() (*Hermes, error) {
-	// untar the binary.
-	gzr, err := gzip.NewReader(bytes.NewReader(hermes.Binary()))
-	if err != nil {
-		panic(err)
-	}
-	defer gzr.Close()
-
-	tr := tar.NewReader(gzr)
-
-	if _, err := tr.Next(); err != nil {
-		return nil, err
-	}
-
-	binary, err := io.ReadAll(tr)
-	if err != nil {
-		return nil, err
-	}
-
-	path, cleanup, err := localfs.SaveBytesTemp(binary, "hermes", 0o755)
-	if err != nil {
-		return nil, err
-	}
-
-	return &Hermes{
-		path:    path,
-		cleanup: cleanup,
-	}, nil
-}
-
-// Cleanup clean the temporary Hermes binary.
+// Cleanup is a no-op kept for compatibility, the Hermes binary is cached on disk between runs.
 func (h *Hermes) Cleanup() error {
-	h.cleanup()
-	return os.RemoveAll(h.path)
+	return nil
 }
 
 // AddKey adds a new key file into the Hermes.
@@ -293,22 +442,19 @@ func (h *Hermes) AddKey(ctx context.Context, chainID, keyfile string, options ..
 }
 
 // AddMnemonic creates a new temporary key file based on the mnemonic and add into the Hermes.
+// The key file is only readable by the user and is overwritten before being removed.
 func (h *Hermes) AddMnemonic(ctx context.Context, chainID, mnemonic string, options ...Option) error {
-	f, err := os.CreateTemp("", "hermes-key")
+	keyfile, cleanup, err := writeSecretFile([]byte(mnemonic))
 	if err != nil {
 		return err
 	}
-	defer os.Remove(f.Name())
-
-	if _, err := f.Write([]byte(mnemonic)); err != nil {
-		return err
-	}
+	defer cleanup()
 
 	options = append(
 		options,
 		WithFlags(Flags{
 			FlagChain:        chainID,
-			FlagMnemonicFile: f.Name(),
+			FlagMnemonicFile: keyfile,
 		}),
 		WithArgs(string(cmdKeys), string(cmdKeysAdd)),
 	)
@@ -389,19 +535,54 @@ func (h *Hermes) QueryChannels(ctx context.Context, showCounterparty bool, chain
 	return h.Run(ctx, options...)
 }
 
+// ClearPackets clears the pending packets and acknowledgments on both ends of a channel.
+func (h *Hermes) ClearPackets(ctx context.Context, chain, portID, channelID string, options ...Option) error {
+	options = append(options, WithFlags(
+		Flags{
+			FlagChain:   chain,
+			FlagPort:    portID,
+			FlagChannel: channelID,
+		},
+	))
+	options = append(options, WithArgs(string(cmdClear), string(cmdPackets)))
+	return h.Run(ctx, options...)
+}
+
+// UpdateClient updates a client with the latest header of the reference chain.
+func (h *Hermes) UpdateClient(ctx context.Context, hostChain, clientID string, options ...Option) error {
+	options = append(options, WithFlags(
+		Flags{
+			FlagHostChain: hostChain,
+			FlagClient:    clientID,
+		},
+	))
+	options = append(options, WithArgs(string(cmdUpdate), string(cmdClient)))
+	return h.Run(ctx, options...)
+}
+
 // Start starts the Hermes relayer.
 func (h *Hermes) Start(ctx context.Context, options ...Option) error {
 	options = append(options, WithArgs(string(cmdStart)), WithFlags(Flags{FlagFullScan: true}))
 	return h.Run(ctx, options...)
 }
 
-// Run runs a Hermes command using the options.
-func (h *Hermes) Run(ctx context.Context, options ...Option) error {
+// StartCommand returns the command line to start the Hermes relayer.
+func (h *Hermes) StartCommand(options ...Option) []string {
+	options = append(options, WithArgs(string(cmdStart)), WithFlags(Flags{FlagFullScan: true}))
+	return h.Command(options...)
+}
+
+// Command returns the Hermes command line built from the options.
+func (h *Hermes) Command(options ...Option) []string {
 	c := defaultConfig()
 	for _, o := range options {
 		o(&c)
 	}
+	return h.command(c)
+}
 
+// command returns the Hermes command line built from the configs.
+func (h *Hermes) command(c configs) []string {
 	cmd := []string{h.path}
 
 	// the config and json flag should be added before the hermes subcommands
@@ -420,6 +601,16 @@ func (h *Hermes) Run(ctx context.Context, options ...Option) error {
 			cmd = append(cmd, fmt.Sprintf("--%s=%s", flag, value))
 		}
 	}
+	return cmd
+}
+
+// Run runs a Hermes command using the options.
+func (h *Hermes) Run(ctx context.Context, options ...Option) error {
+	c := defaultConfig()
+	for _, o := range options {
+		o(&c)
+	}
+	cmd := h.command(c)
 
 	stdin := c.stdin
 	if stdin == nil {
@@ -469,6 +660,27 @@ func UnmarshalResult(data []byte, v any) error {
 	return json.Unmarshal(r.Result, v)
 }
 
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *ChannelState) UnmarshalJSON(data []byte) error {
+	var state string
+	if err := json.Unmarshal(data, &state); err == nil {
+		*s = ChannelState(state)
+		return nil
+	}
+
+	var upgrade map[string]json.RawMessage
+	if err := json.Unmarshal(data, &upgrade); err != nil {
+		return err
+	}
+	if len(upgrade) != 1 {
+		return errors.Errorf("invalid channel state %s", string(data))
+	}
+	for state := range upgrade {
+		*s = ChannelState(state)
+	}
+	return nil
+}
+
 // ValidateResult validate if the cmd result is success.
 func ValidateResult(data []byte) error {
 	var r Result
@@ -476,7 +688,7 @@ func ValidateResult(data []byte) error {
 		return err
 	}
 	if r.Status != ResultSuccess {
-		return errors.Errorf("%s: %v", ErrResult, string(r.Result))
+		return errors.Errorf("%w: %v", ErrResult, string(r.Result))
 	}
 	return nil
 }
@@ -486,10 +698,8 @@ func ValidateResult(data []byte) error {
 // contain the final error message returned by Hermes. Previous lines
 // might contain standard logging entries.
 func parseErrFromOutput(out []byte) error {
-	out = bytes.TrimSpace(out)
-	if len(out) > 0 {
-		lines := bytes.Split(out, []byte("\n"))
-		err := ValidateResult(lines[len(lines)-1])
+	if len(bytes.TrimSpace(out)) > 0 {
+		err := ValidateResult(lastLine(out))
 		if errors.Is(err, ErrResult) {
 			return err
 		}
@@ -34,6 +34,30 @@ func TestUnmarshalResult(t *testing.T) {
 			v:    &ClientResult{},
 			want: &ClientResult{},
 		},
+		{
+			name: "query clients result",
+			data: []byte(`{"status":"success","result":[{"chain_id":"venus-1","client_id":"07-tendermint-0"},{"chain_id":"venus-1","client_id":"07-tendermint-3"}]}`),
+			v:    &[]ClientChainResult{},
+			want: &[]ClientChainResult{
+				{ClientID: "07-tendermint-0", ChainID: "venus-1"},
+				{ClientID: "07-tendermint-3", ChainID: "venus-1"},
+			},
+		},
+		{
+			name: "query connections result",
+			data: []byte(`{"status":"success","result":[{"connection_end":{"client_id":"07-tendermint-0","counterparty":{"client_id":"07-tendermint-1","connection_id":"connection-2","prefix":"ibc"},"delay_period":{"nanos":0,"secs":0},"state":"Open","versions":[{"features":["ORDER_ORDERED","ORDER_UNORDERED"],"identifier":"1"}]},"connection_id":"connection-0"}]}`),
+			v:    &[]ConnectionEndsResult{},
+			want: &[]ConnectionEndsResult{
+				{
+					ConnectionID: "connection-0",
+					ConnectionEnd: ConnectionEnd{
+						ClientID:     "07-tendermint-0",
+						Counterparty: ConnectionCounterparty{ClientID: "07-tendermint-1", ConnectionID: "connection-2"},
+						State:        StateOpen,
+					},
+				},
+			},
+		},
 		{
 			name: "error result",
 			data: []byte(`{"status": "error", "result": {"wallet":{"account":"cosmos139asl6de8mzxedvvxatp2wdna2n6vy3af62srg","address_type":"Cosmos"}}}`),
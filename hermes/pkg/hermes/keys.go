@@ -0,0 +1,191 @@
+package hermes
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/crypto"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/ignite/cli/v28/ignite/pkg/cosmosaccount"
+	"github.com/ignite/cli/v28/ignite/pkg/errors"
+)
+
+const (
+	// keyStoreDir is the Hermes keyring directory into the user home.
+	keyStoreDir = ".hermes/keys"
+	// keyStoreTestDir is the directory of the Hermes test keyring backend.
+	keyStoreTestDir = "keyring-test"
+	// addressTypeCosmos is the Hermes address type of the cosmos secp256k1 keys.
+	addressTypeCosmos = "Cosmos"
+)
+
+// ErrKeyExists indicates the Hermes key already exists.
+var ErrKeyExists = errors.New("key already exists")
+
+// KeyPair represents a secp256k1 key pair in the Hermes keyring file format.
+type KeyPair struct {
+	PrivateKey  string   `json:"private_key"`
+	PublicKey   string   `json:"public_key"`
+	Address     [20]byte `json:"address"`
+	AddressType string   `json:"address_type"`
+	Account     string   `json:"account"`
+}
+
+// NewKeyPair creates the Hermes key pair of the secp256k1 private key with the account prefix.
+func NewKeyPair(privKey *secp256k1.PrivKey, accountPrefix string) (KeyPair, error) {
+	pubKey := privKey.PubKey()
+	account, err := bech32.ConvertAndEncode(accountPrefix, pubKey.Address())
+	if err != nil {
+		return KeyPair{}, err
+	}
+
+	pair := KeyPair{
+		PrivateKey:  hex.EncodeToString(privKey.Bytes()),
+		PublicKey:   hex.EncodeToString(pubKey.Bytes()),
+		AddressType: addressTypeCosmos,
+		Account:     account,
+	}
+	copy(pair.Address[:], pubKey.Address())
+	return pair, nil
+}
+
+// ExportKeyringKey exports the secp256k1 private key by name from the Ignite or Cosmos keyring.
+func ExportKeyringKey(name string, options ...cosmosaccount.Option) (*secp256k1.PrivKey, error) {
+	registry, err := cosmosaccount.New(options...)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := registry.GetByName(name); err != nil {
+		return nil, err
+	}
+
+	// the armor passphrase only protects the key in memory until it is decrypted.
+	passphrase := make([]byte, 32)
+	if _, err := rand.Read(passphrase); err != nil {
+		return nil, err
+	}
+	armor, err := registry.Keyring.ExportPrivKeyArmor(name, hex.EncodeToString(passphrase))
+	if err != nil {
+		return nil, err
+	}
+	privKey, algo, err := crypto.UnarmorDecryptPrivKey(armor, hex.EncodeToString(passphrase))
+	if err != nil {
+		return nil, err
+	}
+	key, ok := privKey.(*secp256k1.PrivKey)
+	if !ok {
+		return nil, errors.Errorf("unsupported key %s algorithm %s, only secp256k1 keys are supported", name, algo)
+	}
+	return key, nil
+}
+
+// KeyPath returns the Hermes keyring file path of the chain key, into the chain
+// key_store_folder if set or the Hermes keys dir of the user home otherwise.
+func (c *Chain) KeyPath(keyName string) (string, error) {
+	if c.KeyStoreType != "" && !strings.EqualFold(c.KeyStoreType, "test") {
+		return "", errors.Errorf("unsupported chain %s key store type %s", c.ID, c.KeyStoreType)
+	}
+	folder := c.KeyStoreFolder
+	if folder == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		folder = filepath.Join(home, keyStoreDir)
+	}
+	return filepath.Join(folder, c.ID, keyStoreTestDir, keyName+".json"), nil
+}
+
+// ImportKey writes the key pair into the Hermes keyring of the chain, readable only by the user.
+// Hermes can only add keys from a mnemonic, which the Cosmos keyring does not keep, so the key
+// file is written in the Hermes keyring format and the caller should check Hermes reads it back
+// with the Keys query.
+func (c *Chain) ImportKey(keyName string, pair KeyPair, overwrite bool) (string, error) {
+	if c.AddressType.Derivation != "" && c.AddressType.Derivation != "cosmos" {
+		return "", errors.Errorf("unsupported chain %s address type %s", c.ID, c.AddressType.Derivation)
+	}
+	path, err := c.KeyPath(keyName)
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(path); err == nil && !overwrite {
+		return "", errors.Wrapf(ErrKeyExists, "chain %s key %s", c.ID, keyName)
+	}
+
+	data, err := json.Marshal(pair)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return "", err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), keyName+"-*")
+	if err != nil {
+		return "", err
+	}
+	defer SecureRemove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+	return path, os.Rename(tmp.Name(), path)
+}
+
+// writeSecretFile writes the secret into a new file of a private temporary directory.
+// The returned cleanup function overwrites and removes the file.
+func writeSecretFile(secret []byte) (string, func(), error) {
+	dir, err := os.MkdirTemp("", "hermes-secret")
+	if err != nil {
+		return "", nil, err
+	}
+	cleanup := func() {
+		_ = SecureRemove(filepath.Join(dir, "secret"))
+		_ = os.RemoveAll(dir)
+	}
+
+	path := filepath.Join(dir, "secret")
+	if err := os.WriteFile(path, secret, 0o600); err != nil {
+		cleanup()
+		return "", nil, err
+	}
+	return path, cleanup, nil
+}
+
+// SecureRemove overwrites the file content with zeros before removing it, so the
+// secret cannot be read back from the removed file blocks.
+func SecureRemove(path string) error {
+	f, err := os.OpenFile(path, os.O_WRONLY, 0)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+	if _, err := f.Write(make([]byte, info.Size())); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Remove(path)
+}
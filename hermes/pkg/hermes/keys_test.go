@@ -0,0 +1,150 @@
+package hermes
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	"github.com/ignite/cli/v28/ignite/pkg/cosmosaccount"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewKeyPair(t *testing.T) {
+	privKey := secp256k1.GenPrivKeyFromSecret([]byte("secret"))
+
+	pair, err := NewKeyPair(privKey, "cosmos")
+	require.NoError(t, err)
+	require.Equal(t, hex.EncodeToString(privKey.Bytes()), pair.PrivateKey)
+	require.Equal(t, hex.EncodeToString(privKey.PubKey().Bytes()), pair.PublicKey)
+	require.Equal(t, []byte(privKey.PubKey().Address()), pair.Address[:])
+	require.Equal(t, "Cosmos", pair.AddressType)
+	require.Contains(t, pair.Account, "cosmos1")
+
+	data, err := json.Marshal(pair)
+	require.NoError(t, err)
+	var got KeyPair
+	require.NoError(t, json.Unmarshal(data, &got))
+	require.Equal(t, pair, got)
+}
+
+func TestExportKeyringKey(t *testing.T) {
+	home := t.TempDir()
+	registry, err := cosmosaccount.New(
+		cosmosaccount.WithKeyringBackend(cosmosaccount.KeyringTest),
+		cosmosaccount.WithHome(home),
+	)
+	require.NoError(t, err)
+	account, _, err := registry.Create("alice")
+	require.NoError(t, err)
+
+	privKey, err := ExportKeyringKey(
+		"alice",
+		cosmosaccount.WithKeyringBackend(cosmosaccount.KeyringTest),
+		cosmosaccount.WithHome(home),
+	)
+	require.NoError(t, err)
+	pubKey, err := account.Record.GetPubKey()
+	require.NoError(t, err)
+	require.Equal(t, pubKey.Bytes(), privKey.PubKey().Bytes())
+
+	_, err = ExportKeyringKey(
+		"bob",
+		cosmosaccount.WithKeyringBackend(cosmosaccount.KeyringTest),
+		cosmosaccount.WithHome(home),
+	)
+	require.Error(t, err)
+}
+
+func TestImportKey(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	pair, err := NewKeyPair(secp256k1.GenPrivKeyFromSecret([]byte("secret")), "cosmos")
+	require.NoError(t, err)
+
+	chain := Chain{ID: "mars-1"}
+	path, err := chain.ImportKey("wallet", pair, false)
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(os.Getenv("HOME"), ".hermes/keys/mars-1/keyring-test/wallet.json"), path)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	_, err = chain.ImportKey("wallet", pair, false)
+	require.ErrorIs(t, err, ErrKeyExists)
+	_, err = chain.ImportKey("wallet", pair, true)
+	require.NoError(t, err)
+
+	chain.KeyStoreFolder = filepath.Join(t.TempDir(), "keys")
+	path, err = chain.ImportKey("wallet", pair, false)
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(chain.KeyStoreFolder, "mars-1/keyring-test/wallet.json"), path)
+
+	chain.KeyStoreType = "file"
+	_, err = chain.ImportKey("wallet", pair, true)
+	require.Error(t, err)
+}
+
+func TestImportKeyHermes(t *testing.T) {
+	h, err := New(WithBinaryCacheDir(t.TempDir()))
+	require.NoError(t, err)
+	pair, err := NewKeyPair(secp256k1.GenPrivKeyFromSecret([]byte("secret")), "cosmos")
+	require.NoError(t, err)
+
+	cfg, err := LoadConfig(filepath.Join("testdata", "migrate", "v1.9.0.toml"))
+	require.NoError(t, err)
+	cfg.Chains = cfg.Chains[:1]
+	chain := &cfg.Chains[0]
+	chain.KeyStoreFolder = filepath.Join(t.TempDir(), "keys")
+	cfgPath := filepath.Join(t.TempDir(), "config.toml")
+	require.NoError(t, cfg.SaveFile(cfgPath))
+
+	_, err = chain.ImportKey(chain.KeyName, pair, false)
+	require.NoError(t, err)
+
+	// Hermes reads the key back from the chain key store folder.
+	keys, err := h.Keys(context.Background(), chain.ID, WithConfigFile(cfgPath))
+	require.NoError(t, err)
+	require.Equal(t, map[string]KeyPair{chain.KeyName: pair}, keys)
+}
+
+func TestWriteSecretFile(t *testing.T) {
+	path, cleanup, err := writeSecretFile([]byte("secret words"))
+	require.NoError(t, err)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	dirInfo, err := os.Stat(filepath.Dir(path))
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o700), dirInfo.Mode().Perm())
+
+	cleanup()
+	_, err = os.Stat(filepath.Dir(path))
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestSecureRemove(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "secret")
+	require.NoError(t, os.WriteFile(path, []byte("secret words"), 0o600))
+
+	// keep a link to the file to check the content was overwritten.
+	link := path + ".link"
+	require.NoError(t, os.Link(path, link))
+
+	require.NoError(t, SecureRemove(path))
+	_, err := os.Stat(path)
+	require.True(t, os.IsNotExist(err))
+
+	content, err := os.ReadFile(link)
+	require.NoError(t, err)
+	require.Equal(t, make([]byte, len("secret words")), content)
+
+	require.NoError(t, SecureRemove(path))
+}
@@ -0,0 +1,310 @@
+package hermes
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ignite/cli/v28/ignite/pkg/errors"
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+type (
+	// Manifest represents a declarative relayer manifest describing the chains
+	// and the paths relayed by Hermes.
+	Manifest struct {
+		LogLevel  string          `yaml:"log_level" toml:"log_level"`
+		Telemetry *ManifestServer `yaml:"telemetry" toml:"telemetry"`
+		Rest      *ManifestServer `yaml:"rest" toml:"rest"`
+		Chains    []ManifestChain `yaml:"chains" toml:"chains"`
+		Paths     []ManifestPath  `yaml:"paths" toml:"paths"`
+	}
+
+	// ManifestServer represents the telemetry or rest server into the manifest.
+	ManifestServer struct {
+		Enabled bool   `yaml:"enabled" toml:"enabled"`
+		Host    string `yaml:"host" toml:"host"`
+		Port    uint64 `yaml:"port" toml:"port"`
+	}
+
+	// ManifestChain represents a chain into the manifest. Empty values keep the Hermes chain defaults.
+	ManifestChain struct {
+		ID                    string  `yaml:"id" toml:"id"`
+		RPCAddr               string  `yaml:"rpc_addr" toml:"rpc_addr"`
+		GRPCAddr              string  `yaml:"grpc_addr" toml:"grpc_addr"`
+		EventSourceURL        string  `yaml:"event_source_url" toml:"event_source_url"`
+		EventSourceMode       string  `yaml:"event_source_mode" toml:"event_source_mode"`
+		EventSourceBatchDelay string  `yaml:"event_source_batch_delay" toml:"event_source_batch_delay"`
+		RPCTimeout            string  `yaml:"rpc_timeout" toml:"rpc_timeout"`
+		AccountPrefix         string  `yaml:"account_prefix" toml:"account_prefix"`
+		KeyName               string  `yaml:"key_name" toml:"key_name"`
+		AddressType           string  `yaml:"address_type" toml:"address_type"`
+		KeyStoreType          string  `yaml:"key_store_type" toml:"key_store_type"`
+		StorePrefix           string  `yaml:"store_prefix" toml:"store_prefix"`
+		DefaultGas            uint64  `yaml:"default_gas" toml:"default_gas"`
+		MaxGas                uint64  `yaml:"max_gas" toml:"max_gas"`
+		GasPrice              string  `yaml:"gas_price" toml:"gas_price"`
+		GasMultiplier         float64 `yaml:"gas_multiplier" toml:"gas_multiplier"`
+		MaxMsgNum             uint64  `yaml:"max_msg_num" toml:"max_msg_num"`
+		MaxTxSize             uint64  `yaml:"max_tx_size" toml:"max_tx_size"`
+		ClockDrift            string  `yaml:"clock_drift" toml:"clock_drift"`
+		MaxBlockTime          string  `yaml:"max_block_time" toml:"max_block_time"`
+		TrustingPeriod        string  `yaml:"trusting_period" toml:"trusting_period"`
+		TrustThreshold        string  `yaml:"trust_threshold" toml:"trust_threshold"`
+		CCVConsumerChain      bool    `yaml:"ccv_consumer_chain" toml:"ccv_consumer_chain"`
+		TrustedNode           bool    `yaml:"trusted_node" toml:"trusted_node"`
+		MemoPrefix            string  `yaml:"memo_prefix" toml:"memo_prefix"`
+		Type                  string  `yaml:"type" toml:"type"`
+		SequentialBatchTx     bool    `yaml:"sequential_batch_tx" toml:"sequential_batch_tx"`
+		Faucet                string  `yaml:"faucet" toml:"faucet"`
+	}
+
+	// ManifestPath represents a relayer path into the manifest.
+	// The ports are set to DefaultPortID if not specified.
+	ManifestPath struct {
+		ChainA  string `yaml:"chain_a" toml:"chain_a"`
+		PortA   string `yaml:"port_a" toml:"port_a"`
+		ChainB  string `yaml:"chain_b" toml:"chain_b"`
+		PortB   string `yaml:"port_b" toml:"port_b"`
+		Version string `yaml:"version" toml:"version"`
+	}
+)
+
+// LoadManifest loads a YAML or TOML manifest file based on the file extension.
+func LoadManifest(path string) (Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Manifest{}, errors.Wrapf(err, "failed to read the manifest %s", path)
+	}
+
+	var m Manifest
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yml", ".yaml":
+		err = yaml.Unmarshal(data, &m)
+	case ".toml":
+		err = toml.Unmarshal(data, &m)
+	default:
+		return Manifest{}, errors.Errorf("unsupported manifest extension %s, use .yml, .yaml or .toml", ext)
+	}
+	if err != nil {
+		return Manifest{}, errors.Wrapf(err, "failed to parse the manifest %s", path)
+	}
+	return m, m.Validate()
+}
+
+// Validate checks if the manifest chains and paths are valid.
+func (m Manifest) Validate() error {
+	if len(m.Chains) < 2 {
+		return errors.New("the manifest must have at least two chains")
+	}
+	chains := make(map[string]struct{})
+	for _, chain := range m.Chains {
+		switch {
+		case chain.ID == "":
+			return errors.New("manifest chain id is required")
+		case chain.RPCAddr == "":
+			return errors.Errorf("manifest chain %s rpc_addr is required", chain.ID)
+		case chain.GRPCAddr == "":
+			return errors.Errorf("manifest chain %s grpc_addr is required", chain.ID)
+		}
+		if _, ok := chains[chain.ID]; ok {
+			return errors.Errorf("duplicated manifest chain %s", chain.ID)
+		}
+		chains[chain.ID] = struct{}{}
+	}
+
+	if len(m.Paths) == 0 {
+		return errors.New("the manifest must have at least one path")
+	}
+	for _, p := range m.RelayerPaths() {
+		if err := p.Validate(); err != nil {
+			return err
+		}
+		for _, chainID := range []string{p.ChainA, p.ChainB} {
+			if _, ok := chains[chainID]; !ok {
+				return errors.Errorf("invalid path %s: chain %s not found in the manifest", p, chainID)
+			}
+		}
+	}
+	return nil
+}
+
+// Chain returns the manifest chain by chain id.
+func (m Manifest) Chain(chainID string) (ManifestChain, error) {
+	for _, chain := range m.Chains {
+		if chain.ID == chainID {
+			return chain, nil
+		}
+	}
+	return ManifestChain{}, errors.Errorf("chain %s not found in the manifest", chainID)
+}
+
+// RelayerPaths returns the manifest paths with the default ports.
+func (m Manifest) RelayerPaths() Paths {
+	paths := make(Paths, 0, len(m.Paths))
+	for _, p := range m.Paths {
+		path := Path{
+			ChainA:  p.ChainA,
+			PortA:   p.PortA,
+			ChainB:  p.ChainB,
+			PortB:   p.PortB,
+			Version: p.Version,
+		}
+		if path.PortA == "" {
+			path.PortA = DefaultPortID
+		}
+		if path.PortB == "" {
+			path.PortB = DefaultPortID
+		}
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+// Config creates the Hermes config from the manifest.
+func (m Manifest) Config() (*Config, error) {
+	c := DefaultConfig(m.configOptions()...)
+	for _, chain := range m.Chains {
+		options, err := chain.Options()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := c.AddChain(chain.ID, chain.RPCAddr, chain.GRPCAddr, options...); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// configOptions returns the Hermes config options from the manifest.
+func (m Manifest) configOptions() []ConfigOption {
+	options := make([]ConfigOption, 0)
+	if m.LogLevel != "" {
+		options = append(options, func(c *Config) {
+			c.Global.LogLevel = m.LogLevel
+		})
+	}
+	if m.Telemetry != nil {
+		options = append(options, WithTelemetryEnabled(m.Telemetry.Enabled))
+		if m.Telemetry.Host != "" {
+			options = append(options, WithTelemetryHost(m.Telemetry.Host))
+		}
+		if m.Telemetry.Port > 0 {
+			options = append(options, WithTelemetryPort(m.Telemetry.Port))
+		}
+	}
+	if m.Rest != nil {
+		options = append(options, WithRestEnabled(m.Rest.Enabled))
+		if m.Rest.Host != "" {
+			options = append(options, WithRestHost(m.Rest.Host))
+		}
+		if m.Rest.Port > 0 {
+			options = append(options, WithRestPort(m.Rest.Port))
+		}
+	}
+	return options
+}
+
+// Options returns the Hermes chain options from the manifest chain.
+func (c ManifestChain) Options() ([]ChainOption, error) {
+	options := []ChainOption{
+		WithChainCCVConsumerChain(c.CCVConsumerChain),
+		WithChainTrustedNode(c.TrustedNode),
+		WithChainSequentialBatchTx(c.SequentialBatchTx),
+	}
+	if c.EventSourceURL != "" {
+		mode, batchDelay := c.EventSourceMode, c.EventSourceBatchDelay
+		if mode == "" {
+			mode = "push"
+		}
+		if batchDelay == "" {
+			batchDelay = "500ms"
+		}
+		options = append(options, WithChainEventSource(mode, c.EventSourceURL, batchDelay))
+	}
+	if c.RPCTimeout != "" {
+		options = append(options, WithChainRPCTimeout(c.RPCTimeout))
+	}
+	if c.AccountPrefix != "" {
+		options = append(options, WithChainAccountPrefix(c.AccountPrefix))
+	}
+	if c.KeyName != "" {
+		options = append(options, WithChainKeyName(c.KeyName))
+	}
+	if c.AddressType != "" {
+		options = append(options, WithChainAddressType(c.AddressType))
+	}
+	if c.KeyStoreType != "" {
+		options = append(options, WithChainKeyStoreType(c.KeyStoreType))
+	}
+	if c.StorePrefix != "" {
+		options = append(options, WithChainStorePrefix(c.StorePrefix))
+	}
+	if c.DefaultGas > 0 {
+		options = append(options, WithChainDefaultGas(c.DefaultGas))
+	}
+	if c.MaxGas > 0 {
+		options = append(options, WithChainMaxGas(c.MaxGas))
+	}
+	if c.GasPrice != "" {
+		gasPrice, err := sdk.ParseDecCoin(c.GasPrice)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid chain %s gas price %s", c.ID, c.GasPrice)
+		}
+		options = append(options, WithChainGasPrice(gasPrice))
+	}
+	if c.GasMultiplier > 0 {
+		options = append(options, WithChainGasMultiplier(big.NewFloat(c.GasMultiplier)))
+	}
+	if c.MaxMsgNum > 0 {
+		options = append(options, WithChainMaxMsgNum(c.MaxMsgNum))
+	}
+	if c.MaxTxSize > 0 {
+		options = append(options, WithChainMaxTxSize(c.MaxTxSize))
+	}
+	if c.ClockDrift != "" {
+		options = append(options, WithChainClockDrift(c.ClockDrift))
+	}
+	if c.MaxBlockTime != "" {
+		options = append(options, WithChainMaxBlockTime(c.MaxBlockTime))
+	}
+	if c.TrustingPeriod != "" {
+		options = append(options, WithChainTrustingPeriod(c.TrustingPeriod))
+	}
+	if c.TrustThreshold != "" {
+		numerator, denominator, err := parseTrustThreshold(c.TrustThreshold)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid chain %s trust threshold", c.ID)
+		}
+		options = append(options, WithChainTrustThreshold(numerator, denominator))
+	}
+	if c.MemoPrefix != "" {
+		options = append(options, WithChainMemoPrefix(c.MemoPrefix))
+	}
+	if c.Type != "" {
+		options = append(options, WithChainType(c.Type))
+	}
+	return options, nil
+}
+
+// parseTrustThreshold parses a trust threshold fraction in the format <numerator>/<denominator>.
+func parseTrustThreshold(threshold string) (numerator, denominator uint64, err error) {
+	n, d, ok := strings.Cut(threshold, "/")
+	if !ok {
+		return 0, 0, errors.Errorf("expected <numerator>/<denominator>, got %s", threshold)
+	}
+	if numerator, err = strconv.ParseUint(strings.TrimSpace(n), 10, 64); err != nil {
+		return 0, 0, err
+	}
+	if denominator, err = strconv.ParseUint(strings.TrimSpace(d), 10, 64); err != nil {
+		return 0, 0, err
+	}
+	if denominator == 0 || numerator > denominator {
+		return 0, 0, errors.Errorf("invalid fraction %s", threshold)
+	}
+	return numerator, denominator, nil
+}
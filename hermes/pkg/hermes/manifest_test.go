@@ -0,0 +1,151 @@
+package hermes
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	yamlManifest = `log_level: debug
+telemetry:
+  enabled: true
+  port: 4001
+chains:
+  - id: mars-1
+    rpc_addr: http://localhost:26657
+    grpc_addr: http://localhost:9090
+    account_prefix: mars
+    gas_price: 0.025umars
+    trust_threshold: 2/3
+    faucet: http://localhost:4500
+  - id: venus-1
+    rpc_addr: http://localhost:26659
+    grpc_addr: http://localhost:9092
+paths:
+  - chain_a: mars-1
+    chain_b: venus-1
+  - chain_a: mars-1
+    port_a: blog
+    chain_b: venus-1
+    port_b: blog
+    version: blog-1
+`
+	tomlManifest = `log_level = "debug"
+
+[telemetry]
+enabled = true
+port = 4001
+
+[[chains]]
+id = "mars-1"
+rpc_addr = "http://localhost:26657"
+grpc_addr = "http://localhost:9090"
+account_prefix = "mars"
+gas_price = "0.025umars"
+trust_threshold = "2/3"
+faucet = "http://localhost:4500"
+
+[[chains]]
+id = "venus-1"
+rpc_addr = "http://localhost:26659"
+grpc_addr = "http://localhost:9092"
+
+[[paths]]
+chain_a = "mars-1"
+chain_b = "venus-1"
+
+[[paths]]
+chain_a = "mars-1"
+port_a = "blog"
+chain_b = "venus-1"
+port_b = "blog"
+version = "blog-1"
+`
+)
+
+func TestLoadManifest(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		manifest string
+		err      string
+	}{
+		{
+			name:     "yaml manifest",
+			file:     "relayer.yml",
+			manifest: yamlManifest,
+		},
+		{
+			name:     "toml manifest",
+			file:     "relayer.toml",
+			manifest: tomlManifest,
+		},
+		{
+			name:     "unsupported extension",
+			file:     "relayer.json",
+			manifest: "{}",
+			err:      "unsupported manifest extension .json, use .yml, .yaml or .toml",
+		},
+		{
+			name:     "single chain",
+			file:     "relayer.yml",
+			manifest: "chains:\n  - id: mars-1\n",
+			err:      "the manifest must have at least two chains",
+		},
+		{
+			name: "unknown path chain",
+			file: "relayer.yml",
+			manifest: `chains:
+  - {id: mars-1, rpc_addr: "http://localhost:26657", grpc_addr: "http://localhost:9090"}
+  - {id: venus-1, rpc_addr: "http://localhost:26659", grpc_addr: "http://localhost:9092"}
+paths:
+  - {chain_a: mars-1, chain_b: earth-1}
+`,
+			err: "invalid path mars-1:transfer/earth-1:transfer: chain earth-1 not found in the manifest",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.file)
+			require.NoError(t, os.WriteFile(path, []byte(tt.manifest), 0o644))
+
+			m, err := LoadManifest(path)
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, Paths{
+				{ChainA: "mars-1", PortA: "transfer", ChainB: "venus-1", PortB: "transfer"},
+				{ChainA: "mars-1", PortA: "blog", ChainB: "venus-1", PortB: "blog", Version: "blog-1"},
+			}, m.RelayerPaths())
+
+			chain, err := m.Chain("mars-1")
+			require.NoError(t, err)
+			require.Equal(t, "http://localhost:4500", chain.Faucet)
+
+			c, err := m.Config()
+			require.NoError(t, err)
+			require.Equal(t, "debug", c.Global.LogLevel)
+			require.True(t, c.Telemetry.Enabled)
+			require.EqualValues(t, 4001, c.Telemetry.Port)
+			require.Equal(t, []string{"mars-1", "venus-1"}, c.Chains.IDs())
+			require.NoError(t, c.ValidatePaths(m.RelayerPaths()))
+
+			mars, err := c.Chains.Get("mars-1")
+			require.NoError(t, err)
+			require.Equal(t, "mars", mars.AccountPrefix)
+			require.Equal(t, GasPrice{Denom: "umars", Price: 0.025}, mars.GasPrice)
+			require.Equal(t, TrustThreshold{Numerator: "2", Denominator: "3"}, mars.TrustThreshold)
+
+			// empty manifest values keep the chain defaults.
+			venus, err := c.Chains.Get("venus-1")
+			require.NoError(t, err)
+			require.Equal(t, "cosmos", venus.AccountPrefix)
+			require.Equal(t, "ws://localhost:26659/websocket", venus.EventSource.URL)
+		})
+	}
+}
@@ -0,0 +1,261 @@
+package hermes
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ignite/cli/v28/ignite/pkg/errors"
+	"github.com/pelletier/go-toml/v2"
+	"golang.org/x/mod/semver"
+)
+
+// backupDirName is the directory of the config backups created by the migrations.
+const backupDirName = "backup"
+
+type (
+	// migration represents a change of the Hermes config schema introduced by a Hermes release.
+	migration struct {
+		version string
+		// up rewrites the chain section to the new schema.
+		up func(chain map[string]interface{}) ([]string, error)
+		// down rewrites the chain section to the previous schema.
+		down func(chain map[string]interface{}) ([]string, error)
+	}
+
+	// MigrationResult represents the changes of a config migration.
+	MigrationResult struct {
+		From    string   `json:"from"`
+		To      string   `json:"to"`
+		Changes []string `json:"changes"`
+	}
+)
+
+// migrations are the config schema changes sorted by Hermes version, following the
+// Hermes release notes. A nil up step means the new keys are optional and the Hermes
+// defaults apply when they are not set.
+var migrations = []migration{
+	{
+		// Hermes 1.5 added the chain type, an empty type is the CosmosSdk default.
+		version: "1.5.0",
+		up: func(chain map[string]interface{}) ([]string, error) {
+			if chainType, ok := chain["type"]; ok && chainType != "" {
+				return nil, nil
+			}
+			chain["type"] = "CosmosSdk"
+			return []string{"set the chain type to CosmosSdk"}, nil
+		},
+		down: func(chain map[string]interface{}) ([]string, error) {
+			if chainType, ok := chain["type"]; ok {
+				if chainType != "CosmosSdk" && chainType != "" {
+					return nil, errors.Errorf("chain type %v is not supported before Hermes 1.5", chainType)
+				}
+				delete(chain, "type")
+				return []string{"remove the chain type"}, nil
+			}
+			return nil, nil
+		},
+	},
+	{
+		// Hermes 1.6 added the optional CometBFT compat_mode, detected from the node by default.
+		version: "1.6.0",
+		down: func(chain map[string]interface{}) ([]string, error) {
+			if _, ok := chain["compat_mode"]; !ok {
+				return nil, nil
+			}
+			delete(chain, "compat_mode")
+			return []string{"remove the compat_mode, the CometBFT version is detected from the node"}, nil
+		},
+	},
+	{
+		// Hermes 1.7 replaced the websocket_addr with the push or pull event_source.
+		version: "1.7.0",
+		up: func(chain map[string]interface{}) ([]string, error) {
+			addr, ok := chain["websocket_addr"]
+			if !ok {
+				return nil, nil
+			}
+			delete(chain, "websocket_addr")
+			chain["event_source"] = map[string]interface{}{
+				"mode":        "push",
+				"url":         addr,
+				"batch_delay": "500ms",
+			}
+			return []string{"replace websocket_addr with a push event_source"}, nil
+		},
+		down: func(chain map[string]interface{}) ([]string, error) {
+			eventSource, ok := chain["event_source"].(map[string]interface{})
+			if !ok {
+				return nil, nil
+			}
+			if mode := eventSource["mode"]; mode != "push" {
+				return nil, errors.Errorf("event source mode %v is not supported before Hermes 1.7", mode)
+			}
+			delete(chain, "event_source")
+			chain["websocket_addr"] = eventSource["url"]
+			return []string{"replace the push event_source with websocket_addr"}, nil
+		},
+	},
+	{
+		// Hermes 1.9 added the optional dynamic_gas_price, disabled by default.
+		version: "1.9.0",
+		down: func(chain map[string]interface{}) ([]string, error) {
+			dynamicGas, ok := chain["dynamic_gas_price"].(map[string]interface{})
+			if !ok {
+				return nil, nil
+			}
+			delete(chain, "dynamic_gas_price")
+			if dynamicGas["enabled"] == true {
+				return []string{"remove the enabled dynamic_gas_price, the static gas_price is used"}, nil
+			}
+			return []string{"remove the dynamic_gas_price"}, nil
+		},
+	},
+}
+
+// DetectSchemaVersion returns the oldest Hermes version supporting all the keys of the config.
+func DetectSchemaVersion(data []byte) (string, error) {
+	chains, _, err := decodeChains(data)
+	if err != nil {
+		return "", err
+	}
+
+	version := "1.0.0"
+	for _, chain := range chains {
+		chainVersion := "1.0.0"
+		switch {
+		case chain["dynamic_gas_price"] != nil:
+			chainVersion = "1.9.0"
+		case chain["event_source"] != nil:
+			chainVersion = "1.7.0"
+		case chain["compat_mode"] != nil:
+			chainVersion = "1.6.0"
+		case chain["type"] != nil:
+			chainVersion = "1.5.0"
+		}
+		if semver.Compare("v"+chainVersion, "v"+version) > 0 {
+			version = chainVersion
+		}
+	}
+	return version, nil
+}
+
+// MigrateConfig rewrites the config data to the schema of the target Hermes version.
+// The config is re-encoded from its decoded keys, so the comments are lost and the
+// keys are sorted.
+func MigrateConfig(data []byte, target string) ([]byte, MigrationResult, error) {
+	target = strings.TrimPrefix(target, "v")
+	if !semver.IsValid("v" + target) {
+		return nil, MigrationResult{}, errors.Errorf("invalid Hermes version %s", target)
+	}
+	if semver.Compare("v"+target, "v1.0.0") < 0 {
+		return nil, MigrationResult{}, errors.Errorf("Hermes %s configs are not supported", target)
+	}
+
+	from, err := DetectSchemaVersion(data)
+	if err != nil {
+		return nil, MigrationResult{}, err
+	}
+	result := MigrationResult{From: from, To: target}
+
+	chains, cfg, err := decodeChains(data)
+	if err != nil {
+		return nil, MigrationResult{}, err
+	}
+
+	apply := func(m migration, step func(map[string]interface{}) ([]string, error)) error {
+		if step == nil {
+			return nil
+		}
+		for _, chain := range chains {
+			changes, err := step(chain)
+			if err != nil {
+				return errors.Wrapf(err, "chain %v", chain["id"])
+			}
+			for _, change := range changes {
+				result.Changes = append(result.Changes, fmt.Sprintf("chain %v: %s (Hermes %s)", chain["id"], change, m.version))
+			}
+		}
+		return nil
+	}
+
+	if semver.Compare("v"+target, "v"+from) >= 0 {
+		for _, m := range migrations {
+			if semver.Compare("v"+m.version, "v"+target) <= 0 {
+				if err := apply(m, m.up); err != nil {
+					return nil, MigrationResult{}, err
+				}
+			}
+		}
+	} else {
+		for i := len(migrations) - 1; i >= 0; i-- {
+			m := migrations[i]
+			if semver.Compare("v"+m.version, "v"+target) > 0 {
+				if err := apply(m, m.down); err != nil {
+					return nil, MigrationResult{}, err
+				}
+			}
+		}
+	}
+
+	out, err := toml.Marshal(cfg)
+	if err != nil {
+		return nil, MigrationResult{}, err
+	}
+	return out, result, nil
+}
+
+// MigrateConfigFile migrates the config file to the target Hermes version, copying the
+// original config into the backup dir next to it first. It returns the backup path,
+// empty if the config has no changes.
+func MigrateConfigFile(cfgPath, target string) (MigrationResult, string, error) {
+	data, err := os.ReadFile(cfgPath)
+	if err != nil {
+		return MigrationResult{}, "", err
+	}
+	out, result, err := MigrateConfig(data, target)
+	if err != nil {
+		return MigrationResult{}, "", errors.Wrapf(err, "failed to migrate the Hermes config %s", cfgPath)
+	}
+	if len(result.Changes) == 0 {
+		return result, "", nil
+	}
+
+	backupPath := filepath.Join(
+		filepath.Dir(cfgPath),
+		backupDirName,
+		fmt.Sprintf("%s.%s", filepath.Base(cfgPath), time.Now().UTC().Format("20060102150405")),
+	)
+	if err := os.MkdirAll(filepath.Dir(backupPath), 0o755); err != nil {
+		return MigrationResult{}, "", err
+	}
+	if err := os.WriteFile(backupPath, data, 0o644); err != nil {
+		return MigrationResult{}, "", err
+	}
+	return result, backupPath, os.WriteFile(cfgPath, out, 0o644)
+}
+
+// decodeChains decodes the config data as a TOML document, keeping the keys unknown by the
+// Config struct, and returns its chain sections.
+func decodeChains(data []byte) ([]map[string]interface{}, map[string]interface{}, error) {
+	var cfg map[string]interface{}
+	if err := toml.Unmarshal(data, &cfg); err != nil {
+		return nil, nil, errors.Wrap(err, "failed to parse the Hermes config")
+	}
+
+	rawChains, ok := cfg["chains"].([]interface{})
+	if !ok && cfg["chains"] != nil {
+		return nil, nil, errors.New("invalid Hermes config chains")
+	}
+	chains := make([]map[string]interface{}, 0, len(rawChains))
+	for _, rawChain := range rawChains {
+		chain, ok := rawChain.(map[string]interface{})
+		if !ok {
+			return nil, nil, errors.New("invalid Hermes config chain")
+		}
+		chains = append(chains, chain)
+	}
+	return chains, cfg, nil
+}
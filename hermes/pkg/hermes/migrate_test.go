@@ -0,0 +1,194 @@
+package hermes
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// readMigrateConfig reads the testdata config of a Hermes version.
+func readMigrateConfig(t *testing.T, version string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", "migrate", "v"+version+".toml"))
+	require.NoError(t, err)
+	return data
+}
+
+// rawChains decodes the chain sections of the config data.
+func rawChains(t *testing.T, data []byte) []map[string]interface{} {
+	t.Helper()
+	chains, _, err := decodeChains(data)
+	require.NoError(t, err)
+	return chains
+}
+
+func TestDetectSchemaVersion(t *testing.T) {
+	tests := []struct {
+		version string
+		want    string
+	}{
+		{version: "1.4.0", want: "1.0.0"},
+		{version: "1.6.0", want: "1.6.0"},
+		{version: "1.7.0", want: "1.7.0"},
+		{version: "1.9.0", want: "1.9.0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			got, err := DetectSchemaVersion(readMigrateConfig(t, tt.version))
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := DetectSchemaVersion([]byte("chains = 'invalid'"))
+	require.Error(t, err)
+}
+
+func TestMigrateConfig(t *testing.T) {
+	t.Run("1.4 to 1.10", func(t *testing.T) {
+		out, result, err := MigrateConfig(readMigrateConfig(t, "1.4.0"), "v1.10.0")
+		require.NoError(t, err)
+		require.Equal(t, "1.0.0", result.From)
+		require.Equal(t, "1.10.0", result.To)
+		require.Len(t, result.Changes, 4)
+
+		// the migrated chains match the 1.7 schema, the optional keys are not added.
+		want := rawChains(t, readMigrateConfig(t, "1.7.0"))
+		for i, chain := range rawChains(t, out) {
+			require.Equal(t, "CosmosSdk", chain["type"])
+			require.NotContains(t, chain, "websocket_addr")
+			require.NotContains(t, chain, "compat_mode")
+			require.NotContains(t, chain, "dynamic_gas_price")
+			if i == 0 {
+				require.Equal(t, want[i]["event_source"], chain["event_source"])
+			}
+		}
+
+		cfg, err := loadConfigData(t, out)
+		require.NoError(t, err)
+		require.Equal(t, "push", cfg.Chains[1].EventSource.Mode)
+		require.Equal(t, "ws://127.0.0.1:26557/websocket", cfg.Chains[1].EventSource.URL)
+
+		// migrating again has no changes.
+		_, result, err = MigrateConfig(out, "1.10.0")
+		require.NoError(t, err)
+		require.Empty(t, result.Changes)
+	})
+
+	t.Run("1.6 to 1.7", func(t *testing.T) {
+		out, result, err := MigrateConfig(readMigrateConfig(t, "1.6.0"), "1.7.0")
+		require.NoError(t, err)
+		require.Equal(t, "1.6.0", result.From)
+		require.Len(t, result.Changes, 2)
+
+		chains := rawChains(t, out)
+		require.Equal(t, "0.37", chains[0]["compat_mode"])
+		for _, chain := range chains {
+			require.NotContains(t, chain, "websocket_addr")
+			require.Equal(t, "push", chain["event_source"].(map[string]interface{})["mode"])
+		}
+	})
+
+	t.Run("1.9 to 1.6", func(t *testing.T) {
+		out, result, err := MigrateConfig(readMigrateConfig(t, "1.9.0"), "1.6.0")
+		require.NoError(t, err)
+		require.Equal(t, "1.9.0", result.From)
+		require.Equal(t, []string{
+			"chain ibc-0: remove the enabled dynamic_gas_price, the static gas_price is used (Hermes 1.9.0)",
+			"chain ibc-0: replace the push event_source with websocket_addr (Hermes 1.7.0)",
+			"chain ibc-1: replace the push event_source with websocket_addr (Hermes 1.7.0)",
+		}, result.Changes)
+
+		want := rawChains(t, readMigrateConfig(t, "1.6.0"))
+		require.Equal(t, want, rawChains(t, out))
+	})
+
+	t.Run("1.6 to 1.5", func(t *testing.T) {
+		out, result, err := MigrateConfig(readMigrateConfig(t, "1.6.0"), "1.5.0")
+		require.NoError(t, err)
+		require.Len(t, result.Changes, 1)
+		require.NotContains(t, rawChains(t, out)[0], "compat_mode")
+	})
+
+	t.Run("1.9 to 1.4", func(t *testing.T) {
+		out, _, err := MigrateConfig(readMigrateConfig(t, "1.9.0"), "1.4.0")
+		require.NoError(t, err)
+
+		want := rawChains(t, readMigrateConfig(t, "1.4.0"))
+		for i, chain := range rawChains(t, out) {
+			for _, key := range []string{"type", "event_source", "compat_mode", "dynamic_gas_price"} {
+				require.NotContains(t, chain, key)
+			}
+			require.Equal(t, want[i]["websocket_addr"], chain["websocket_addr"])
+		}
+	})
+
+	t.Run("empty type", func(t *testing.T) {
+		data, err := os.ReadFile(filepath.Join("testdata", "migrate", "empty-type.toml"))
+		require.NoError(t, err)
+
+		// an empty chain type is the CosmosSdk default in both directions.
+		out, result, err := MigrateConfig(data, "1.5.0")
+		require.NoError(t, err)
+		require.Equal(t, "1.5.0", result.From)
+		require.Equal(t, []string{
+			"chain ibc-0: set the chain type to CosmosSdk (Hermes 1.5.0)",
+			"chain ibc-1: set the chain type to CosmosSdk (Hermes 1.5.0)",
+		}, result.Changes)
+		for _, chain := range rawChains(t, out) {
+			require.Equal(t, "CosmosSdk", chain["type"])
+		}
+
+		out, result, err = MigrateConfig(data, "1.4.0")
+		require.NoError(t, err)
+		require.Len(t, result.Changes, 2)
+		for _, chain := range rawChains(t, out) {
+			require.NotContains(t, chain, "type")
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		data := readMigrateConfig(t, "1.7.0")
+		_, _, err := MigrateConfig(data, "0.15.0")
+		require.Error(t, err)
+		_, _, err = MigrateConfig(data, "latest")
+		require.Error(t, err)
+
+		// the pull event source of ibc-1 cannot be migrated before 1.7.
+		_, _, err = MigrateConfig(data, "1.6.0")
+		require.ErrorContains(t, err, "event source mode pull is not supported before Hermes 1.7")
+	})
+}
+
+func TestMigrateConfigFile(t *testing.T) {
+	data := readMigrateConfig(t, "1.4.0")
+	cfgPath := filepath.Join(t.TempDir(), "ibc-0_ibc-1")
+	require.NoError(t, os.WriteFile(cfgPath, data, 0o644))
+
+	_, backupPath, err := MigrateConfigFile(cfgPath, "1.10.0")
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(filepath.Dir(cfgPath), "backup"), filepath.Dir(backupPath))
+
+	backup, err := os.ReadFile(backupPath)
+	require.NoError(t, err)
+	require.Equal(t, data, backup)
+
+	cfg, err := LoadConfig(cfgPath)
+	require.NoError(t, err)
+	require.Equal(t, "push", cfg.Chains[1].EventSource.Mode)
+
+	// no backup without changes.
+	_, backupPath, err = MigrateConfigFile(cfgPath, "1.10.0")
+	require.NoError(t, err)
+	require.Empty(t, backupPath)
+}
+
+// loadConfigData parses the config data.
+func loadConfigData(t *testing.T, data []byte) (*Config, error) {
+	t.Helper()
+	cfgPath := filepath.Join(t.TempDir(), "config.toml")
+	require.NoError(t, os.WriteFile(cfgPath, data, 0o644))
+	return LoadConfig(cfgPath)
+}
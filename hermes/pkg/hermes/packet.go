@@ -0,0 +1,109 @@
+package hermes
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/ignite/cli/v28/ignite/pkg/cosmosclient"
+	"github.com/ignite/cli/v28/ignite/pkg/errors"
+)
+
+const (
+	eventSendPacket              = "send_packet"
+	attributePacketSequence      = "packet_sequence"
+	attributePacketTimeoutHeight = "packet_timeout_height"
+	attributePacketTimeoutStamp  = "packet_timeout_timestamp"
+
+	// noTimeoutHeight is the timeout height of packets without a height timeout.
+	noTimeoutHeight = "0-0"
+)
+
+// ErrPacketNotFound indicates the send packet transaction was not found in the chain tx index.
+var ErrPacketNotFound = errors.New("packet not found")
+
+// Packet represents an IBC packet sent by a chain.
+type Packet struct {
+	Sequence         uint64
+	TimeoutHeight    string
+	TimeoutTimestamp time.Time
+	Height           int64
+	SentAt           time.Time
+}
+
+// Timeout returns the packet timeout description.
+func (p Packet) Timeout() string {
+	switch {
+	case !p.TimeoutTimestamp.IsZero():
+		return p.TimeoutTimestamp.UTC().Format(time.RFC3339)
+	case p.TimeoutHeight != "" && p.TimeoutHeight != noTimeoutHeight:
+		return fmt.Sprintf("height %s", p.TimeoutHeight)
+	default:
+		return "none"
+	}
+}
+
+// TimedOut returns true if the packet timeout timestamp has passed.
+func (p Packet) TimedOut(now time.Time) bool {
+	return !p.TimeoutTimestamp.IsZero() && now.After(p.TimeoutTimestamp)
+}
+
+// SentPacket returns the packet sent through the channel by sequence from the chain tx index.
+// The client is created once per chain with NewClient and shared by the packet queries.
+func SentPacket(
+	ctx context.Context,
+	client cosmosclient.Client,
+	portID,
+	channelID string,
+	sequence uint64,
+) (Packet, error) {
+	query := fmt.Sprintf(
+		"%[1]s.packet_src_port='%[2]s' AND %[1]s.packet_src_channel='%[3]s' AND %[1]s.%[4]s=%[5]d",
+		eventSendPacket,
+		portID,
+		channelID,
+		attributePacketSequence,
+		sequence,
+	)
+	res, err := client.RPC.TxSearch(ctx, query, false, nil, nil, "asc")
+	if err != nil {
+		return Packet{}, err
+	}
+	if len(res.Txs) == 0 {
+		return Packet{}, errors.Wrapf(ErrPacketNotFound, "%s/%s sequence %d", portID, channelID, sequence)
+	}
+
+	tx := res.Txs[0]
+	packet := Packet{Sequence: sequence, Height: tx.Height}
+	for _, event := range tx.TxResult.Events {
+		if event.Type != eventSendPacket {
+			continue
+		}
+		attributes := make(map[string]string)
+		for _, attr := range event.Attributes {
+			attributes[attr.Key] = attr.Value
+		}
+		// a transaction can send more than one packet.
+		if attributes[attributePacketSequence] != strconv.FormatUint(sequence, 10) {
+			continue
+		}
+		packet.TimeoutHeight = attributes[attributePacketTimeoutHeight]
+		if timestamp, err := strconv.ParseInt(attributes[attributePacketTimeoutStamp], 10, 64); err == nil && timestamp > 0 {
+			packet.TimeoutTimestamp = time.Unix(0, timestamp)
+		}
+		break
+	}
+
+	block, err := client.RPC.Block(ctx, &tx.Height)
+	if err != nil {
+		return Packet{}, err
+	}
+	packet.SentAt = block.Block.Time
+	return packet, nil
+}
+
+// NewClient creates the chain RPC client.
+func (c *Chain) NewClient(ctx context.Context) (cosmosclient.Client, error) {
+	return cosmosclient.New(ctx, cosmosclient.WithNodeAddress(c.RPCAddr))
+}
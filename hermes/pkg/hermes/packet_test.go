@@ -0,0 +1,47 @@
+package hermes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPacketTimeout(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		packet   Packet
+		want     string
+		timedOut bool
+	}{
+		{
+			name:     "timestamp timeout",
+			packet:   Packet{TimeoutHeight: noTimeoutHeight, TimeoutTimestamp: now.Add(time.Hour)},
+			want:     "2024-01-01T13:00:00Z",
+			timedOut: false,
+		},
+		{
+			name:     "expired timestamp timeout",
+			packet:   Packet{TimeoutHeight: "1-100", TimeoutTimestamp: now.Add(-time.Minute)},
+			want:     "2024-01-01T11:59:00Z",
+			timedOut: true,
+		},
+		{
+			name:   "height timeout",
+			packet: Packet{TimeoutHeight: "1-100"},
+			want:   "height 1-100",
+		},
+		{
+			name:   "no timeout",
+			packet: Packet{TimeoutHeight: noTimeoutHeight},
+			want:   "none",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.packet.Timeout())
+			require.Equal(t, tt.timedOut, tt.packet.TimedOut(now))
+		})
+	}
+}
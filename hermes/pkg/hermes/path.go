@@ -0,0 +1,134 @@
+package hermes
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ignite/cli/v28/ignite/pkg/errors"
+)
+
+const (
+	// DefaultPortID is the default IBC port ID of the relayer paths.
+	DefaultPortID = "transfer"
+
+	// pathChainSeparator separates the two path ends.
+	pathChainSeparator = "/"
+	// pathPortSeparator separates the chain ID and the port ID of a path end.
+	pathPortSeparator = ":"
+	// pathVersionSeparator separates the path ends and the channel version.
+	pathVersionSeparator = "@"
+)
+
+type (
+	// Path represents an IBC path between two chains relayed by Hermes.
+	Path struct {
+		ChainA  string
+		PortA   string
+		ChainB  string
+		PortB   string
+		Version string
+	}
+
+	// Paths represents a list of relayer paths.
+	Paths []Path
+)
+
+// ParsePath parses a path in the format <chain-a>[:<port-a>]/<chain-b>[:<port-b>][@<version>].
+// The ports are set to DefaultPortID if not specified.
+func ParsePath(path string) (Path, error) {
+	ends, version, _ := strings.Cut(strings.TrimSpace(path), pathVersionSeparator)
+	endA, endB, ok := strings.Cut(ends, pathChainSeparator)
+	if !ok {
+		return Path{}, errors.Errorf("invalid path %s: expected <chain-a>[:<port-a>]/<chain-b>[:<port-b>][@<version>]", path)
+	}
+
+	chainA, portA := parsePathEnd(endA)
+	chainB, portB := parsePathEnd(endB)
+	p := Path{
+		ChainA:  chainA,
+		PortA:   portA,
+		ChainB:  chainB,
+		PortB:   portB,
+		Version: strings.TrimSpace(version),
+	}
+	return p, p.Validate()
+}
+
+// parsePathEnd parses a path end in the format <chain>[:<port>].
+func parsePathEnd(end string) (chainID, portID string) {
+	chainID, portID, _ = strings.Cut(strings.TrimSpace(end), pathPortSeparator)
+	chainID, portID = strings.TrimSpace(chainID), strings.TrimSpace(portID)
+	if portID == "" {
+		portID = DefaultPortID
+	}
+	return chainID, portID
+}
+
+// Validate checks if the path has both ends set with different chains.
+func (p Path) Validate() error {
+	switch {
+	case p.ChainA == "" || p.ChainB == "":
+		return errors.Errorf("invalid path %s: both chain IDs are required", p)
+	case p.PortA == "" || p.PortB == "":
+		return errors.Errorf("invalid path %s: both port IDs are required", p)
+	case p.ChainA == p.ChainB:
+		return errors.Errorf("invalid path %s: chains must be different", p)
+	default:
+		return nil
+	}
+}
+
+// String returns the path in the same format accepted by ParsePath.
+func (p Path) String() string {
+	path := fmt.Sprintf(
+		"%s%s%s%s%s%s%s",
+		p.ChainA, pathPortSeparator, p.PortA,
+		pathChainSeparator,
+		p.ChainB, pathPortSeparator, p.PortB,
+	)
+	if p.Version != "" {
+		path += pathVersionSeparator + p.Version
+	}
+	return path
+}
+
+// ParsePaths parses a list of paths.
+func ParsePaths(paths ...string) (Paths, error) {
+	result := make(Paths, 0, len(paths))
+	for _, path := range paths {
+		p, err := ParsePath(path)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, p)
+	}
+	return result, nil
+}
+
+// ValidatePaths checks if all path chains are present into the Hermes config.
+func (c *Config) ValidatePaths(paths Paths) error {
+	if len(paths) == 0 {
+		return errors.New("at least one relayer path is required")
+	}
+	for _, p := range paths {
+		if err := p.Validate(); err != nil {
+			return err
+		}
+		if _, err := c.Chains.Get(p.ChainA); err != nil {
+			return errors.Wrapf(err, "invalid path %s", p)
+		}
+		if _, err := c.Chains.Get(p.ChainB); err != nil {
+			return errors.Wrapf(err, "invalid path %s", p)
+		}
+	}
+	return nil
+}
+
+// IDs returns the chain IDs.
+func (c Chains) IDs() []string {
+	ids := make([]string, 0, len(c))
+	for _, chain := range c {
+		ids = append(ids, chain.ID)
+	}
+	return ids
+}
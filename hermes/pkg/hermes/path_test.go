@@ -0,0 +1,82 @@
+package hermes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePath(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		want Path
+		err  string
+	}{
+		{
+			name: "default ports",
+			path: "mars-1/venus-1",
+			want: Path{ChainA: "mars-1", PortA: "transfer", ChainB: "venus-1", PortB: "transfer"},
+		},
+		{
+			name: "custom ports and version",
+			path: "mars-1:blog/venus-1:blog@blog-1",
+			want: Path{ChainA: "mars-1", PortA: "blog", ChainB: "venus-1", PortB: "blog", Version: "blog-1"},
+		},
+		{
+			name: "only one custom port",
+			path: " hub-1 / zone-1:icahost ",
+			want: Path{ChainA: "hub-1", PortA: "transfer", ChainB: "zone-1", PortB: "icahost"},
+		},
+		{
+			name: "missing chain separator",
+			path: "mars-1:transfer",
+			err:  "invalid path mars-1:transfer: expected <chain-a>[:<port-a>]/<chain-b>[:<port-b>][@<version>]",
+		},
+		{
+			name: "missing chain",
+			path: "mars-1/",
+			err:  "invalid path mars-1:transfer/:transfer: both chain IDs are required",
+		},
+		{
+			name: "same chain",
+			path: "mars-1/mars-1",
+			err:  "invalid path mars-1:transfer/mars-1:transfer: chains must be different",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePath(tt.path)
+			if tt.err != "" {
+				require.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+
+			// the path string must be parsed back to the same path.
+			parsed, err := ParsePath(got.String())
+			require.NoError(t, err)
+			require.Equal(t, got, parsed)
+		})
+	}
+}
+
+func TestConfigValidatePaths(t *testing.T) {
+	c := DefaultConfig()
+	for _, id := range []string{"hub-1", "zone-1", "zone-2"} {
+		_, err := c.AddChain(id, "http://localhost:26657", "http://localhost:9090")
+		require.NoError(t, err)
+	}
+	require.Equal(t, []string{"hub-1", "zone-1", "zone-2"}, c.Chains.IDs())
+
+	paths, err := ParsePaths("hub-1/zone-1", "hub-1/zone-2", "zone-1/zone-2")
+	require.NoError(t, err)
+	require.NoError(t, c.ValidatePaths(paths))
+
+	paths, err = ParsePaths("hub-1/zone-3")
+	require.NoError(t, err)
+	require.EqualError(t, c.ValidatePaths(paths), "invalid path hub-1:transfer/zone-3:transfer: chain zone-3 not exist")
+
+	require.EqualError(t, c.ValidatePaths(nil), "at least one relayer path is required")
+}
@@ -0,0 +1,141 @@
+package hermes
+
+import (
+	"context"
+	"crypto/tls"
+	"net/url"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	"github.com/gorilla/websocket"
+	"github.com/ignite/cli/v28/ignite/pkg/cosmosclient"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// probeTimeout is the timeout of each chain endpoint probe.
+const probeTimeout = 10 * time.Second
+
+// Probe checks the chain endpoints are reachable and the chain config matches the
+// chain ID, the bech32 account prefix and the unbonding period of the chain.
+func (c *Chain) Probe(ctx context.Context) Issues {
+	var issues Issues
+	c.probeRPC(ctx, &issues)
+	c.probeGRPC(ctx, &issues)
+	if c.EventSource.Mode == "push" {
+		c.probeEventSource(ctx, &issues)
+	}
+	return issues
+}
+
+// probeRPC checks the RPC node is reachable and runs the chain.
+func (c *Chain) probeRPC(ctx context.Context, issues *Issues) {
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+
+	client, err := cosmosclient.New(ctx, cosmosclient.WithNodeAddress(c.RPCAddr))
+	if err != nil {
+		issues.add(c.ID, "rpc_addr", SeverityError, "unreachable RPC %s: %s", c.RPCAddr, err)
+		return
+	}
+	status, err := client.Status(ctx)
+	if err != nil {
+		issues.add(c.ID, "rpc_addr", SeverityError, "failed to query the RPC %s status: %s", c.RPCAddr, err)
+		return
+	}
+	if status.NodeInfo.Network != c.ID {
+		issues.add(c.ID, "id", SeverityError, "the RPC %s runs the chain %s", c.RPCAddr, status.NodeInfo.Network)
+	}
+}
+
+// probeGRPC checks the gRPC server is reachable, the account prefix and the trusting
+// period against the unbonding period.
+func (c *Chain) probeGRPC(ctx context.Context, issues *Issues) {
+	conn, err := grpcConn(c.GRPCAddr)
+	if err != nil {
+		issues.add(c.ID, "grpc_addr", SeverityError, "invalid gRPC %s: %s", c.GRPCAddr, err)
+		return
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+
+	prefix, err := authtypes.NewQueryClient(conn).Bech32Prefix(ctx, &authtypes.Bech32PrefixRequest{})
+	if err != nil {
+		issues.add(c.ID, "grpc_addr", SeverityError, "unreachable gRPC %s: %s", c.GRPCAddr, err)
+		return
+	}
+	if prefix.Bech32Prefix != c.AccountPrefix {
+		issues.add(c.ID, "account_prefix", SeverityError, "the chain account prefix is %s", prefix.Bech32Prefix)
+	}
+
+	params, err := stakingtypes.NewQueryClient(conn).Params(ctx, &stakingtypes.QueryParamsRequest{})
+	if err != nil {
+		// the consumer chains have no staking module, the unbonding period comes from the provider.
+		if !c.CCVConsumerChain {
+			issues.add(c.ID, "trusting_period", SeverityWarning, "failed to query the unbonding period: %s", err)
+		}
+		return
+	}
+	trustingPeriod, err := ParseDuration(c.TrustingPeriod)
+	if err != nil {
+		return
+	}
+	unbondingPeriod := params.Params.UnbondingTime
+	switch {
+	case trustingPeriod >= unbondingPeriod:
+		issues.add(
+			c.ID,
+			"trusting_period",
+			SeverityError,
+			"the trusting period %s must be lower than the unbonding period %s",
+			trustingPeriod,
+			unbondingPeriod,
+		)
+	case trustingPeriod > unbondingPeriod*2/3:
+		issues.add(
+			c.ID,
+			"trusting_period",
+			SeverityWarning,
+			"the trusting period %s is greater than 2/3 of the unbonding period %s",
+			trustingPeriod,
+			unbondingPeriod,
+		)
+	}
+}
+
+// probeEventSource checks the event source websocket is reachable.
+func (c *Chain) probeEventSource(ctx context.Context, issues *Issues) {
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+
+	conn, resp, err := websocket.DefaultDialer.DialContext(ctx, c.EventSource.URL, nil)
+	if err != nil {
+		issues.add(c.ID, "event_source.url", SeverityError, "unreachable event source %s: %s", c.EventSource.URL, err)
+		return
+	}
+	_ = resp.Body.Close()
+	_ = conn.Close()
+}
+
+// grpcConn creates a gRPC client connection to the chain, using TLS for https addresses.
+func grpcConn(addr string) (*grpc.ClientConn, error) {
+	u, err := url.Parse(addr)
+	if err != nil {
+		return nil, err
+	}
+	creds := insecure.NewCredentials()
+	if u.Scheme == "https" {
+		creds = credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})
+	}
+	return grpc.NewClient(
+		u.Host,
+		grpc.WithTransportCredentials(creds),
+		grpc.WithDefaultCallOptions(grpc.ForceCodec(codec.NewProtoCodec(codectypes.NewInterfaceRegistry()).GRPCCodec())),
+	)
+}
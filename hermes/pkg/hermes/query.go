@@ -0,0 +1,170 @@
+package hermes
+
+import (
+	"bytes"
+	"context"
+	"strconv"
+	"time"
+)
+
+// Clients returns the clients hosted by a chain, optionally filtered by the reference chain.
+func (h *Hermes) Clients(ctx context.Context, hostChain, referenceChain string, options ...Option) ([]ClientChainResult, error) {
+	clients := make([]ClientChainResult, 0)
+	flags := Flags{FlagHostChain: hostChain}
+	if referenceChain != "" {
+		flags[FlagReferenceChain] = referenceChain
+	}
+	return clients, h.query(ctx, &clients, flags, options, cmdClients)
+}
+
+// ClientState returns the state of a client.
+func (h *Hermes) ClientState(ctx context.Context, chain, clientID string, options ...Option) (ClientStateResult, error) {
+	var state ClientStateResult
+	flags := Flags{FlagChain: chain, FlagClient: clientID}
+	return state, h.query(ctx, &state, flags, options, cmdClient, cmdState)
+}
+
+// ClientStatus returns the status of a client (Active, Expired or Frozen).
+func (h *Hermes) ClientStatus(ctx context.Context, chain, clientID string, options ...Option) (string, error) {
+	var status string
+	flags := Flags{FlagChain: chain, FlagClient: clientID}
+	return status, h.query(ctx, &status, flags, options, cmdClient, cmdStatus)
+}
+
+// ConsensusHeights returns the heights of the consensus states stored by a client.
+func (h *Hermes) ConsensusHeights(ctx context.Context, chain, clientID string, options ...Option) ([]ConsensusHeight, error) {
+	heights := make([]ConsensusHeight, 0)
+	flags := Flags{FlagChain: chain, FlagClient: clientID}
+	return heights, h.query(ctx, &heights, flags, options, cmdClient, cmdConsensus)
+}
+
+// ConsensusState returns the consensus state stored by a client at a height.
+func (h *Hermes) ConsensusState(ctx context.Context, chain, clientID string, height uint64, options ...Option) (ConsensusStateResult, error) {
+	var state ConsensusStateResult
+	flags := Flags{FlagChain: chain, FlagClient: clientID, FlagConsensusHeight: strconv.FormatUint(height, 10)}
+	return state, h.query(ctx, &state, flags, options, cmdClient, cmdConsensus)
+}
+
+// Connections returns the connections of a chain, optionally filtered by the counterparty chain.
+func (h *Hermes) Connections(ctx context.Context, chain, counterpartyChain string, options ...Option) ([]ConnectionEndsResult, error) {
+	connections := make([]ConnectionEndsResult, 0)
+	flags := Flags{FlagChain: chain, FlagVerbose: true}
+	if counterpartyChain != "" {
+		flags[FlagCounterpartyChain] = counterpartyChain
+	}
+	return connections, h.query(ctx, &connections, flags, options, cmdConnections)
+}
+
+// ConnectionEnd returns the end of a connection.
+func (h *Hermes) ConnectionEnd(ctx context.Context, chain, connectionID string, options ...Option) (ConnectionEnd, error) {
+	var end ConnectionEnd
+	flags := Flags{FlagChain: chain, FlagConnection: connectionID}
+	return end, h.query(ctx, &end, flags, options, cmdConnection, cmdEnd)
+}
+
+// ChannelEnd returns the end of a channel.
+func (h *Hermes) ChannelEnd(ctx context.Context, chain, portID, channelID string, options ...Option) (ChannelEnd, error) {
+	var end ChannelEnd
+	return end, h.query(ctx, &end, packetFlags(chain, portID, channelID), options, cmdChannel, cmdEnd)
+}
+
+// Channels returns the channels of a chain with their counterparty ends, optionally
+// filtered by the counterparty chain.
+func (h *Hermes) Channels(ctx context.Context, chain, counterpartyChain string, options ...Option) ([]ChannelEndsResult, error) {
+	channels := make([]ChannelEndsResult, 0)
+	flags := Flags{FlagChain: chain, FlagShowCounterparty: true}
+	if counterpartyChain != "" {
+		flags[FlagCounterpartyChain] = counterpartyChain
+	}
+	return channels, h.query(ctx, &channels, flags, options, cmdChannels)
+}
+
+// PacketCommitments returns the sequences of the packets sent through a channel and not acknowledged yet.
+func (h *Hermes) PacketCommitments(ctx context.Context, chain, portID, channelID string, options ...Option) (PacketSeqsResult, error) {
+	var commitments PacketSeqsResult
+	return commitments, h.query(ctx, &commitments, packetFlags(chain, portID, channelID), options, cmdPacket, cmdCommitments)
+}
+
+// PacketAcknowledgments returns the sequences of the packets acknowledged on a channel.
+func (h *Hermes) PacketAcknowledgments(ctx context.Context, chain, portID, channelID string, options ...Option) (PacketSeqsResult, error) {
+	var acks PacketSeqsResult
+	return acks, h.query(ctx, &acks, packetFlags(chain, portID, channelID), options, cmdPacket, cmdAcknowledgments)
+}
+
+// PendingPackets returns the packets and acknowledgments pending to be relayed on both channel ends.
+func (h *Hermes) PendingPackets(ctx context.Context, chain, portID, channelID string, options ...Option) (PendingPacketsResult, error) {
+	var pending PendingPacketsResult
+	return pending, h.query(ctx, &pending, packetFlags(chain, portID, channelID), options, cmdPacket, cmdPending)
+}
+
+// UnreceivedPackets returns the sequences of the packets sent by the counterparty chain and not received by the chain.
+func (h *Hermes) UnreceivedPackets(ctx context.Context, chain, portID, channelID string, options ...Option) ([]uint64, error) {
+	seqs := make([]uint64, 0)
+	return seqs, h.query(ctx, &seqs, packetFlags(chain, portID, channelID), options, cmdPacket, cmdUnreceivedPackets)
+}
+
+// UnreceivedAcks returns the sequences of the acknowledgments written by the counterparty chain
+// and not received by the chain.
+func (h *Hermes) UnreceivedAcks(ctx context.Context, chain, portID, channelID string, options ...Option) ([]uint64, error) {
+	seqs := make([]uint64, 0)
+	return seqs, h.query(ctx, &seqs, packetFlags(chain, portID, channelID), options, cmdPacket, cmdUnreceivedAcks)
+}
+
+// Keys returns the Hermes key pairs of a chain by key name.
+func (h *Hermes) Keys(ctx context.Context, chainID string, options ...Option) (map[string]KeyPair, error) {
+	var out bytes.Buffer
+	options = append(
+		options,
+		WithArgs(string(cmdKeys), string(cmdKeysList)),
+		WithFlags(Flags{FlagChain: chainID}),
+		WithJSONOutput(),
+		WithStdOut(&out),
+	)
+	if err := h.Run(ctx, options...); err != nil {
+		return nil, err
+	}
+	keys := make(map[string]KeyPair)
+	return keys, UnmarshalResult(lastLine(out.Bytes()), &keys)
+}
+
+// query runs a Hermes query command with JSON output and unmarshal the result into v.
+func (h *Hermes) query(ctx context.Context, v any, flags Flags, options []Option, cmds ...subCmd) error {
+	args := []string{string(cmdQuery)}
+	for _, cmd := range cmds {
+		args = append(args, string(cmd))
+	}
+
+	var out bytes.Buffer
+	options = append(
+		options,
+		WithArgs(args...),
+		WithFlags(flags),
+		WithJSONOutput(),
+		WithStdOut(&out),
+	)
+	if err := h.Run(ctx, options...); err != nil {
+		return err
+	}
+	return UnmarshalResult(lastLine(out.Bytes()), v)
+}
+
+// packetFlags returns the flags of the query packet commands.
+func packetFlags(chain, portID, channelID string) Flags {
+	return Flags{
+		FlagChain:   chain,
+		FlagPort:    portID,
+		FlagChannel: channelID,
+	}
+}
+
+// lastLine returns the last non-empty line of the output, where Hermes writes
+// the command result after any logging entries.
+func lastLine(out []byte) []byte {
+	lines := bytes.Split(bytes.TrimSpace(out), []byte("\n"))
+	return lines[len(lines)-1]
+}
+
+// Duration returns the time as a duration.
+func (t Time) Duration() time.Duration {
+	return time.Duration(t.Secs)*time.Second + time.Duration(t.Nanos)
+}
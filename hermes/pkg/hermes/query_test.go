@@ -0,0 +1,100 @@
+package hermes
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeHermes is a Hermes binary replacement that prints a logging entry and
+// the JSON result of the query commands based on the args.
+const fakeHermes = `#!/bin/sh
+echo '{"timestamp":"Jan 01 00:00:00.000","level":"INFO","fields":{"message":"using default configuration"}}'
+case "$*" in
+  *"query clients "*)
+    echo '{"result":[{"chain_id":"venus-1","client_id":"07-tendermint-0"}],"status":"success"}' ;;
+  *"query client state "*)
+    echo '{"result":{"Tendermint":{"chain_id":"venus-1","trust_threshold":{"numerator":"1","denominator":"3"},"trusting_period":{"secs":1209600,"nanos":0},"unbonding_period":{"secs":1814400,"nanos":0},"max_clock_drift":{"secs":5,"nanos":0},"latest_height":{"revision_number":1,"revision_height":42},"frozen_height":null}},"status":"success"}' ;;
+  *"query client consensus "*)
+    echo '{"result":{"Tendermint":{"timestamp":"2024-01-01T00:00:00Z","root":"aWJj","next_validators_hash":"00"}},"status":"success"}' ;;
+  *"query client status"*)
+    echo '{"result":"Active","status":"success"}' ;;
+  *"query connection end "*)
+    echo '{"result":{"client_id":"07-tendermint-0","counterparty":{"client_id":"07-tendermint-1","connection_id":"connection-1"},"delay_period":{"secs":0,"nanos":0},"state":"Open"},"status":"success"}' ;;
+  *"query channel end "*"--channel=channel-1"*)
+    echo '{"result":{"state":"TryOpen","ordering":"ORDER_UNORDERED","remote":{"port_id":"transfer","channel_id":null},"connection_hops":["connection-0"]},"status":"success"}' ;;
+  *"query channel end "*)
+    echo '{"result":{"state":{"Open":"NotUpgrading"},"ordering":"ORDER_UNORDERED","remote":{"port_id":"transfer","channel_id":"channel-3"},"connection_hops":["connection-0"]},"status":"success"}' ;;
+  *"query packet pending"*)
+    echo '{"result":{"src_chain":"mars-1","dst_chain":"venus-1","src":{"unreceived_packets":[3,4],"unreceived_acks":[]},"dst":{"unreceived_packets":[],"unreceived_acks":[1]}},"status":"success"}' ;;
+  *"query packet unreceived-acks"*)
+    echo '{"result":[1,2],"status":"success"}' ;;
+  *)
+    echo '{"result":"unexpected args: '"$*"'","status":"error"}'
+    exit 1 ;;
+esac
+`
+
+func newFakeHermes(t *testing.T) *Hermes {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake hermes binary requires a shell")
+	}
+	path := filepath.Join(t.TempDir(), "hermes")
+	require.NoError(t, os.WriteFile(path, []byte(fakeHermes), 0o755))
+	return &Hermes{path: path}
+}
+
+func TestQuery(t *testing.T) {
+	var (
+		ctx = context.Background()
+		h   = newFakeHermes(t)
+	)
+
+	clients, err := h.Clients(ctx, "mars-1", "venus-1")
+	require.NoError(t, err)
+	require.Equal(t, []ClientChainResult{{ClientID: "07-tendermint-0", ChainID: "venus-1"}}, clients)
+
+	state, err := h.ClientState(ctx, "mars-1", "07-tendermint-0")
+	require.NoError(t, err)
+	require.Equal(t, "venus-1", state.Tendermint.ChainID)
+	require.Equal(t, 14*24*time.Hour, state.Tendermint.TrustingPeriod.Duration())
+	require.Equal(t, ConsensusHeight{RevisionNumber: 1, RevisionHeight: 42}, state.Tendermint.LatestHeight)
+	require.Nil(t, state.Tendermint.FrozenHeight)
+
+	status, err := h.ClientStatus(ctx, "mars-1", "07-tendermint-0")
+	require.NoError(t, err)
+	require.Equal(t, ClientStatusActive, status)
+
+	connection, err := h.ConnectionEnd(ctx, "mars-1", "connection-0")
+	require.NoError(t, err)
+	require.Equal(t, StateOpen, connection.State)
+	require.Equal(t, "connection-1", connection.Counterparty.ConnectionID)
+
+	// the open state of the channels supporting upgrades has the upgrade state.
+	channel, err := h.ChannelEnd(ctx, "mars-1", "transfer", "channel-0")
+	require.NoError(t, err)
+	require.EqualValues(t, StateOpen, channel.State)
+	require.Equal(t, ChannelCounterparty{PortID: "transfer", ChannelID: "channel-3"}, channel.Remote)
+	channel, err = h.ChannelEnd(ctx, "mars-1", "transfer", "channel-1")
+	require.NoError(t, err)
+	require.EqualValues(t, "TryOpen", channel.State)
+	require.Empty(t, channel.Remote.ChannelID)
+
+	pending, err := h.PendingPackets(ctx, "mars-1", "transfer", "channel-0")
+	require.NoError(t, err)
+	require.Equal(t, []uint64{3, 4}, pending.Src.UnreceivedPackets)
+	require.Equal(t, []uint64{1}, pending.Dst.UnreceivedAcks)
+
+	acks, err := h.UnreceivedAcks(ctx, "mars-1", "transfer", "channel-0")
+	require.NoError(t, err)
+	require.Equal(t, []uint64{1, 2}, acks)
+
+	_, err = h.Connections(ctx, "mars-1", "venus-1")
+	require.ErrorIs(t, err, ErrResult)
+}
@@ -0,0 +1,290 @@
+package hermes
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	sdkmath "cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ignite/cli/v28/ignite/pkg/errors"
+)
+
+const (
+	// DefaultRegistryURL is the URL of the cosmos chain registry raw files.
+	DefaultRegistryURL = "https://raw.githubusercontent.com/cosmos/chain-registry/master"
+	// registryCacheTTL is the time a downloaded registry file is used before being downloaded again.
+	registryCacheTTL = 24 * time.Hour
+
+	registryDirName       = "registry"
+	registryTestnetsDir   = "testnets"
+	registryChainFile     = "chain.json"
+	registryAssetListFile = "assetlist.json"
+	grpcTLSPort           = "443"
+)
+
+// ErrRegistryChainNotFound indicates the chain is not found in the chain registry.
+var ErrRegistryChainNotFound = errors.New("chain not found in the chain registry")
+
+type (
+	// Registry represents the cosmos chain registry, read from a local checkout
+	// or downloaded and cached.
+	Registry struct {
+		dir      string
+		url      string
+		cacheDir string
+		client   *http.Client
+	}
+
+	// RegistryOption configures the chain registry.
+	RegistryOption func(*Registry)
+
+	// RegistryChain represents the chain.json file of the chain registry.
+	RegistryChain struct {
+		ChainName    string `json:"chain_name"`
+		ChainID      string `json:"chain_id"`
+		NetworkType  string `json:"network_type"`
+		Bech32Prefix string `json:"bech32_prefix"`
+		Fees         struct {
+			FeeTokens []RegistryFeeToken `json:"fee_tokens"`
+		} `json:"fees"`
+		Staking struct {
+			StakingTokens []struct {
+				Denom string `json:"denom"`
+			} `json:"staking_tokens"`
+		} `json:"staking"`
+		Codebase struct {
+			CosmosSDKVersion string `json:"cosmos_sdk_version"`
+		} `json:"codebase"`
+		APIs struct {
+			RPC  []RegistryEndpoint `json:"rpc"`
+			GRPC []RegistryEndpoint `json:"grpc"`
+		} `json:"apis"`
+	}
+
+	// RegistryFeeToken represents a fee token of the chain registry.
+	RegistryFeeToken struct {
+		Denom            string      `json:"denom"`
+		FixedMinGasPrice json.Number `json:"fixed_min_gas_price"`
+		LowGasPrice      json.Number `json:"low_gas_price"`
+		AverageGasPrice  json.Number `json:"average_gas_price"`
+		HighGasPrice     json.Number `json:"high_gas_price"`
+	}
+
+	// RegistryEndpoint represents an API endpoint of the chain registry.
+	RegistryEndpoint struct {
+		Address  string `json:"address"`
+		Provider string `json:"provider"`
+	}
+
+	// RegistryAssetList represents the assetlist.json file of the chain registry.
+	RegistryAssetList struct {
+		ChainName string          `json:"chain_name"`
+		Assets    []RegistryAsset `json:"assets"`
+	}
+
+	// RegistryAsset represents an asset of the chain registry.
+	RegistryAsset struct {
+		Base    string `json:"base"`
+		Display string `json:"display"`
+		Symbol  string `json:"symbol"`
+	}
+)
+
+// WithRegistryDir reads the chain registry from a local checkout.
+func WithRegistryDir(dir string) RegistryOption {
+	return func(r *Registry) {
+		r.dir = dir
+	}
+}
+
+// WithRegistryURL sets the URL to download the chain registry files.
+func WithRegistryURL(url string) RegistryOption {
+	return func(r *Registry) {
+		r.url = url
+	}
+}
+
+// WithRegistryCacheDir sets the directory of the downloaded chain registry files.
+func WithRegistryCacheDir(dir string) RegistryOption {
+	return func(r *Registry) {
+		r.cacheDir = dir
+	}
+}
+
+// NewRegistry creates a new chain registry, downloading the files into the config dir by default.
+func NewRegistry(options ...RegistryOption) (*Registry, error) {
+	r := &Registry{
+		url:    DefaultRegistryURL,
+		client: &http.Client{Timeout: defaultEndpointClientTimeout},
+	}
+	for _, o := range options {
+		o(r)
+	}
+	if r.cacheDir == "" {
+		configDir, err := ConfigDir()
+		if err != nil {
+			return nil, err
+		}
+		r.cacheDir = filepath.Join(configDir, registryDirName)
+	}
+	return r, nil
+}
+
+// Chain returns the chain and its asset list by chain name, looking for the testnets too.
+func (r *Registry) Chain(ctx context.Context, name string) (RegistryChain, RegistryAssetList, error) {
+	var (
+		chain  RegistryChain
+		assets RegistryAssetList
+	)
+	// the name is joined into the registry file paths and URLs.
+	if name == "" || name == "." || strings.ContainsAny(name, `/\`) || strings.Contains(name, "..") {
+		return chain, assets, errors.Errorf("invalid chain registry name %q", name)
+	}
+	for _, chainDir := range []string{name, registryTestnetsDir + "/" + name} {
+		data, err := r.file(ctx, chainDir, registryChainFile)
+		if errors.Is(err, ErrRegistryChainNotFound) {
+			continue
+		} else if err != nil {
+			return chain, assets, err
+		}
+		if err := json.Unmarshal(data, &chain); err != nil {
+			return chain, assets, errors.Wrapf(err, "failed to parse the %s %s", name, registryChainFile)
+		}
+
+		// the asset list is optional, the fee tokens are used as is without it.
+		data, err = r.file(ctx, chainDir, registryAssetListFile)
+		if err != nil && !errors.Is(err, ErrRegistryChainNotFound) {
+			return chain, assets, err
+		} else if err == nil {
+			if err := json.Unmarshal(data, &assets); err != nil {
+				return chain, assets, errors.Wrapf(err, "failed to parse the %s %s", name, registryAssetListFile)
+			}
+		}
+		return chain, assets, nil
+	}
+	return chain, assets, errors.Wrapf(ErrRegistryChainNotFound, "chain %s", name)
+}
+
+// file reads a chain registry file from the local checkout, the cache or downloads it.
+func (r *Registry) file(ctx context.Context, chainDir, name string) ([]byte, error) {
+	if r.dir != "" {
+		data, err := os.ReadFile(filepath.Join(r.dir, filepath.FromSlash(chainDir), name))
+		if os.IsNotExist(err) {
+			return nil, ErrRegistryChainNotFound
+		}
+		return data, err
+	}
+
+	cachePath := filepath.Join(r.cacheDir, filepath.FromSlash(chainDir), name)
+	if info, err := os.Stat(cachePath); err == nil && time.Since(info.ModTime()) < registryCacheTTL {
+		return os.ReadFile(cachePath)
+	}
+
+	data, err := r.download(ctx, chainDir+"/"+name)
+	if err != nil {
+		// use the outdated cached file if the registry is unreachable.
+		if cached, cacheErr := os.ReadFile(cachePath); cacheErr == nil && !errors.Is(err, ErrRegistryChainNotFound) {
+			return cached, nil
+		}
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(cachePath), 0o755); err != nil {
+		return nil, err
+	}
+	return data, os.WriteFile(cachePath, data, 0o644)
+}
+
+// download downloads a chain registry file.
+func (r *Registry) download(ctx context.Context, path string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.url+"/"+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to download the chain registry %s", path)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return io.ReadAll(resp.Body)
+	case http.StatusNotFound:
+		return nil, ErrRegistryChainNotFound
+	default:
+		return nil, errors.Errorf("failed to download the chain registry %s: %s", path, resp.Status)
+	}
+}
+
+// RPCAddr returns the first RPC address of the chain.
+func (c RegistryChain) RPCAddr() (string, error) {
+	if len(c.APIs.RPC) == 0 {
+		return "", errors.Errorf("no RPC address for the chain %s in the chain registry", c.ChainName)
+	}
+	return c.APIs.RPC[0].Address, nil
+}
+
+// GRPCAddr returns the first gRPC address of the chain, adding the URL scheme
+// expected by Hermes: https for the port 443 and http otherwise.
+func (c RegistryChain) GRPCAddr() (string, error) {
+	if len(c.APIs.GRPC) == 0 {
+		return "", errors.Errorf("no gRPC address for the chain %s in the chain registry", c.ChainName)
+	}
+	addr := c.APIs.GRPC[0].Address
+	if strings.Contains(addr, "://") {
+		return addr, nil
+	}
+	scheme := "http"
+	if u, err := url.Parse("//" + addr); err == nil && u.Port() == grpcTLSPort {
+		scheme = "https"
+	}
+	return scheme + "://" + addr, nil
+}
+
+// GasPrice returns the gas price of the first fee token, or the staking token if it is a fee token
+// listed into the asset list, using the average, low or fixed min gas price.
+func (c RegistryChain) GasPrice(assets RegistryAssetList) (sdk.DecCoin, error) {
+	if len(c.Fees.FeeTokens) == 0 {
+		return sdk.DecCoin{}, errors.Errorf("no fee token for the chain %s in the chain registry", c.ChainName)
+	}
+
+	token := c.Fees.FeeTokens[0]
+	for _, t := range c.Fees.FeeTokens {
+		if len(c.Staking.StakingTokens) > 0 && t.Denom == c.Staking.StakingTokens[0].Denom && assets.Has(t.Denom) {
+			token = t
+			break
+		}
+	}
+
+	for _, price := range []json.Number{token.AverageGasPrice, token.LowGasPrice, token.FixedMinGasPrice} {
+		if price == "" {
+			continue
+		}
+		amount, err := sdkmath.LegacyNewDecFromStr(price.String())
+		if err != nil {
+			return sdk.DecCoin{}, errors.Wrapf(err, "invalid %s gas price %s", token.Denom, price)
+		}
+		return sdk.NewDecCoinFromDec(token.Denom, amount), nil
+	}
+	return sdk.NewDecCoinFromDec(token.Denom, sdkmath.LegacyZeroDec()), nil
+}
+
+// Has returns true if the asset list is empty or contains the base denom.
+func (a RegistryAssetList) Has(denom string) bool {
+	if len(a.Assets) == 0 {
+		return true
+	}
+	for _, asset := range a.Assets {
+		if asset.Base == denom {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,116 @@
+package hermes
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	sdkmath "cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	registryChainJSON = `{
+  "chain_name": "marstestnet",
+  "chain_id": "mars-testnet-1",
+  "network_type": "testnet",
+  "bech32_prefix": "mars",
+  "fees": {
+    "fee_tokens": [
+      {"denom": "uusdc", "fixed_min_gas_price": 0.01},
+      {"denom": "umars", "low_gas_price": 0.01, "average_gas_price": 0.025, "high_gas_price": 0.04}
+    ]
+  },
+  "staking": {"staking_tokens": [{"denom": "umars"}]},
+  "codebase": {"cosmos_sdk_version": "v0.50.8"},
+  "apis": {
+    "rpc": [{"address": "https://rpc.mars.test", "provider": "mars"}],
+    "grpc": [{"address": "grpc.mars.test:443", "provider": "mars"}]
+  }
+}`
+	registryAssetListJSON = `{
+  "chain_name": "marstestnet",
+  "assets": [{"base": "umars", "display": "mars", "symbol": "MARS"}]
+}`
+)
+
+func TestRegistryChain(t *testing.T) {
+	dir := t.TempDir()
+	chainDir := filepath.Join(dir, registryTestnetsDir, "marstestnet")
+	require.NoError(t, os.MkdirAll(chainDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(chainDir, registryChainFile), []byte(registryChainJSON), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(chainDir, registryAssetListFile), []byte(registryAssetListJSON), 0o644))
+
+	r, err := NewRegistry(WithRegistryDir(dir), WithRegistryCacheDir(t.TempDir()))
+	require.NoError(t, err)
+
+	chain, assets, err := r.Chain(context.Background(), "marstestnet")
+	require.NoError(t, err)
+	require.Equal(t, "mars-testnet-1", chain.ChainID)
+	require.Equal(t, "mars", chain.Bech32Prefix)
+	require.Equal(t, "v0.50.8", chain.Codebase.CosmosSDKVersion)
+
+	rpcAddr, err := chain.RPCAddr()
+	require.NoError(t, err)
+	require.Equal(t, "https://rpc.mars.test", rpcAddr)
+
+	grpcAddr, err := chain.GRPCAddr()
+	require.NoError(t, err)
+	require.Equal(t, "https://grpc.mars.test:443", grpcAddr)
+
+	price, err := chain.GasPrice(assets)
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewDecCoinFromDec("umars", sdkmath.LegacyMustNewDecFromStr("0.025")), price)
+
+	// without the staking token in the asset list, the first fee token is used.
+	price, err = chain.GasPrice(RegistryAssetList{Assets: []RegistryAsset{{Base: "uusdc"}}})
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewDecCoinFromDec("uusdc", sdkmath.LegacyMustNewDecFromStr("0.01")), price)
+
+	_, _, err = r.Chain(context.Background(), "venustestnet")
+	require.ErrorIs(t, err, ErrRegistryChainNotFound)
+
+	// the name cannot escape the registry dir.
+	for _, name := range []string{"", ".", "..", "../marstestnet", "testnets/marstestnet", `testnets\marstestnet`, "mars..testnet"} {
+		_, _, err = r.Chain(context.Background(), name)
+		require.ErrorContains(t, err, "invalid chain registry name", name)
+	}
+}
+
+func TestRegistryDownload(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch r.URL.Path {
+		case "/marstestnet/" + registryChainFile:
+			_, _ = w.Write([]byte(registryChainJSON))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	cacheDir := t.TempDir()
+	r, err := NewRegistry(WithRegistryURL(server.URL), WithRegistryCacheDir(cacheDir))
+	require.NoError(t, err)
+
+	chain, assets, err := r.Chain(context.Background(), "marstestnet")
+	require.NoError(t, err)
+	require.Equal(t, "mars-testnet-1", chain.ChainID)
+	require.Empty(t, assets.Assets)
+	require.FileExists(t, filepath.Join(cacheDir, "marstestnet", registryChainFile))
+	require.Equal(t, 2, requests)
+
+	// the chain file is read from the cache.
+	_, _, err = r.Chain(context.Background(), "marstestnet")
+	require.NoError(t, err)
+	require.Equal(t, 3, requests)
+
+	grpcAddr, err := chain.GRPCAddr()
+	require.NoError(t, err)
+	require.Equal(t, "https://grpc.mars.test:443", grpcAddr)
+}
@@ -0,0 +1,116 @@
+package hermes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/ignite/cli/v28/ignite/pkg/errors"
+)
+
+const (
+	restStatusSuccess = "success"
+
+	restPathVersion = "/version"
+	restPathChains  = "/chains"
+	restPathState   = "/state"
+)
+
+type (
+	// RESTClient represents a client of the Hermes REST API.
+	RESTClient struct {
+		url    string
+		client *http.Client
+	}
+
+	// RESTVersion represents the version of a Hermes component.
+	RESTVersion struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	}
+
+	// RESTState represents the Hermes supervisor state with the running workers by type.
+	RESTState struct {
+		Chains  []string                     `json:"chains"`
+		Workers map[string][]json.RawMessage `json:"workers"`
+	}
+
+	// restResponse represents the Hermes REST API response wrapper.
+	restResponse struct {
+		Status string          `json:"status"`
+		Result json.RawMessage `json:"result"`
+	}
+)
+
+// RestURL returns the Hermes REST API URL.
+func (c *Config) RestURL() string {
+	return fmt.Sprintf("http://%s", net.JoinHostPort(c.Rest.Host, strconv.FormatUint(c.Rest.Port, 10)))
+}
+
+// NewRESTClient creates a new Hermes REST API client.
+func NewRESTClient(url string) *RESTClient {
+	return &RESTClient{
+		url:    url,
+		client: &http.Client{Timeout: defaultEndpointClientTimeout},
+	}
+}
+
+// Version returns the version of the Hermes components.
+func (c *RESTClient) Version(ctx context.Context) ([]RESTVersion, error) {
+	versions := make([]RESTVersion, 0)
+	return versions, c.get(ctx, restPathVersion, &versions)
+}
+
+// Chains returns the IDs of the chains relayed by Hermes.
+func (c *RESTClient) Chains(ctx context.Context) ([]string, error) {
+	chains := make([]string, 0)
+	return chains, c.getResult(ctx, restPathChains, &chains)
+}
+
+// State returns the Hermes supervisor state.
+func (c *RESTClient) State(ctx context.Context) (RESTState, error) {
+	var state RESTState
+	return state, c.getResult(ctx, restPathState, &state)
+}
+
+// WorkersCount returns the number of workers by type.
+func (s RESTState) WorkersCount() map[string]int {
+	count := make(map[string]int)
+	for kind, workers := range s.Workers {
+		count[kind] = len(workers)
+	}
+	return count
+}
+
+// getResult gets the API path and unmarshal the result of the response wrapper into v.
+func (c *RESTClient) getResult(ctx context.Context, path string, v any) error {
+	var resp restResponse
+	if err := c.get(ctx, path, &resp); err != nil {
+		return err
+	}
+	if resp.Status != restStatusSuccess {
+		return errors.Errorf("Hermes REST API %s error: %s", path, string(resp.Result))
+	}
+	return json.Unmarshal(resp.Result, v)
+}
+
+// get gets the API path and unmarshal the JSON response into v.
+func (c *RESTClient) get(ctx context.Context, path string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url+path, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "failed to request the Hermes REST API %s", path)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("failed to request the Hermes REST API %s: %s", path, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
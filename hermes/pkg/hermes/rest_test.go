@@ -0,0 +1,55 @@
+package hermes
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRESTClient(t *testing.T) {
+	responses := map[string]string{
+		restPathVersion: `[{"name":"ibc-relayer","version":"1.10.0"},{"name":"ibc-relayer-rest","version":"0.1.0"}]`,
+		restPathChains:  `{"status":"success","result":["mars-1","venus-1"]}`,
+		restPathState:   `{"status":"success","result":{"chains":["mars-1","venus-1"],"workers":{"Client":[{"id":1},{"id":2}],"Packet":[{"id":3}]}}}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp, ok := responses[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(resp))
+	}))
+	defer server.Close()
+
+	var (
+		ctx    = context.Background()
+		client = NewRESTClient(server.URL)
+	)
+
+	versions, err := client.Version(ctx)
+	require.NoError(t, err)
+	require.Equal(t, RESTVersion{Name: "ibc-relayer", Version: "1.10.0"}, versions[0])
+
+	chains, err := client.Chains(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []string{"mars-1", "venus-1"}, chains)
+
+	state, err := client.State(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []string{"mars-1", "venus-1"}, state.Chains)
+	require.Equal(t, map[string]int{"Client": 2, "Packet": 1}, state.WorkersCount())
+
+	responses[restPathChains] = `{"status":"error","result":{"name":"chain not found"}}`
+	_, err = client.Chains(ctx)
+	require.EqualError(t, err, `Hermes REST API /chains error: {"name":"chain not found"}`)
+}
+
+func TestConfigEndpointURLs(t *testing.T) {
+	c := DefaultConfig()
+	require.Equal(t, "http://127.0.0.1:3001/metrics", c.TelemetryURL())
+	require.Equal(t, "http://127.0.0.1:3000", c.RestURL())
+}
@@ -0,0 +1,187 @@
+package hermes
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ignite/cli/v28/ignite/pkg/errors"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+)
+
+// Hermes telemetry metric names, without the counters "_total" suffix.
+const (
+	metricWalletBalance         = "wallet_balance"
+	metricMessagesSubmitted     = "total_messages_submitted"
+	metricBroadcastErrors       = "broadcast_errors"
+	metricSimulateErrors        = "simulate_errors"
+	metricTxLatencySubmitted    = "tx_latency_submitted"
+	metricBacklogSize           = "backlog_size"
+	metricBacklogOldestSequence = "backlog_oldest_sequence"
+)
+
+const (
+	labelChain   = "chain"
+	labelAccount = "account"
+	labelDenom   = "denom"
+
+	counterSuffix        = "_total"
+	telemetryMetricsPath = "/metrics"
+
+	// defaultEndpointClientTimeout is the request timeout of the Hermes telemetry and REST endpoints.
+	defaultEndpointClientTimeout = 10 * time.Second
+)
+
+type (
+	// ChainMetrics represents the relayer metrics of a chain.
+	ChainMetrics struct {
+		ChainID  string
+		Balances []WalletBalance
+		// MsgsSubmitted is the number of messages submitted to the chain, a tx can hold many messages.
+		MsgsSubmitted uint64
+		// TxFailed is the number of simulation and broadcast errors of the chain accounts.
+		TxFailed uint64
+		// RelayLatency is the average time between a packet event and the relay tx submission.
+		RelayLatency time.Duration
+		// Backlog is the number of packets pending to be relayed from the chain.
+		Backlog uint64
+		// BacklogOldestSequence is the sequence of the oldest pending packet.
+		BacklogOldestSequence uint64
+	}
+
+	// WalletBalance represents the balance of a relayer account.
+	WalletBalance struct {
+		Account string
+		Denom   string
+		Amount  float64
+	}
+)
+
+// String returns the balance amount with the denom.
+func (b WalletBalance) String() string {
+	return strconv.FormatFloat(b.Amount, 'f', -1, 64) + b.Denom
+}
+
+// TelemetryURL returns the Hermes telemetry metrics endpoint URL.
+func (c *Config) TelemetryURL() string {
+	return fmt.Sprintf("http://%s%s", net.JoinHostPort(c.Telemetry.Host, strconv.FormatUint(c.Telemetry.Port, 10)), telemetryMetricsPath)
+}
+
+// ScrapeMetrics scrapes the Hermes telemetry endpoint and returns the metrics by chain.
+func ScrapeMetrics(ctx context.Context, url string) ([]ChainMetrics, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	client := http.Client{Timeout: defaultEndpointClientTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to scrape the Hermes telemetry %s", url)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("failed to scrape the Hermes telemetry %s: %s", url, resp.Status)
+	}
+	return ParseMetrics(resp.Body)
+}
+
+// ParseMetrics parses the Hermes telemetry in the Prometheus text format and returns the metrics by chain.
+func ParseMetrics(r io.Reader) ([]ChainMetrics, error) {
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse the Hermes telemetry")
+	}
+	metrics := make(map[string][]*dto.Metric)
+	for name, family := range families {
+		name = strings.TrimSuffix(name, counterSuffix)
+		metrics[name] = append(metrics[name], family.GetMetric()...)
+	}
+
+	var (
+		chains   = make(map[string]*ChainMetrics)
+		accounts = make(map[string]string)
+		latency  = make(map[string]*dto.Histogram)
+	)
+	chain := func(id string) *ChainMetrics {
+		if _, ok := chains[id]; !ok {
+			chains[id] = &ChainMetrics{ChainID: id}
+		}
+		return chains[id]
+	}
+
+	for _, m := range metrics[metricWalletBalance] {
+		c, account := chain(label(m, labelChain)), label(m, labelAccount)
+		accounts[account] = c.ChainID
+		c.Balances = append(c.Balances, WalletBalance{
+			Account: account,
+			Denom:   label(m, labelDenom),
+			Amount:  m.GetGauge().GetValue(),
+		})
+	}
+	for _, m := range metrics[metricMessagesSubmitted] {
+		chain(label(m, labelChain)).MsgsSubmitted += uint64(m.GetCounter().GetValue())
+	}
+	// the errors are labeled by account only, which is matched with the wallet chain.
+	for _, m := range append(metrics[metricBroadcastErrors], metrics[metricSimulateErrors]...) {
+		if chainID, ok := accounts[label(m, labelAccount)]; ok {
+			chain(chainID).TxFailed += uint64(m.GetCounter().GetValue())
+		}
+	}
+	for _, m := range metrics[metricTxLatencySubmitted] {
+		chainID := label(m, labelChain)
+		chain(chainID)
+		if _, ok := latency[chainID]; !ok {
+			latency[chainID] = &dto.Histogram{SampleCount: new(uint64), SampleSum: new(float64)}
+		}
+		*latency[chainID].SampleCount += m.GetHistogram().GetSampleCount()
+		*latency[chainID].SampleSum += m.GetHistogram().GetSampleSum()
+	}
+	for chainID, h := range latency {
+		if h.GetSampleCount() > 0 {
+			// the Hermes latency histograms are in milliseconds.
+			avg := h.GetSampleSum() / float64(h.GetSampleCount())
+			chains[chainID].RelayLatency = time.Duration(avg * float64(time.Millisecond))
+		}
+	}
+	for _, m := range metrics[metricBacklogSize] {
+		chain(label(m, labelChain)).Backlog += uint64(m.GetGauge().GetValue())
+	}
+	for _, m := range metrics[metricBacklogOldestSequence] {
+		c, seq := chain(label(m, labelChain)), uint64(m.GetGauge().GetValue())
+		if seq > 0 && (c.BacklogOldestSequence == 0 || seq < c.BacklogOldestSequence) {
+			c.BacklogOldestSequence = seq
+		}
+	}
+
+	result := make([]ChainMetrics, 0, len(chains))
+	for _, c := range chains {
+		if c.ChainID == "" {
+			continue
+		}
+		sort.Slice(c.Balances, func(i, j int) bool {
+			return c.Balances[i].Account+c.Balances[i].Denom < c.Balances[j].Account+c.Balances[j].Denom
+		})
+		result = append(result, *c)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ChainID < result[j].ChainID })
+	return result, nil
+}
+
+// label returns the value of a metric label.
+func label(m *dto.Metric, name string) string {
+	for _, l := range m.GetLabel() {
+		if l.GetName() == name {
+			return l.GetValue()
+		}
+	}
+	return ""
+}
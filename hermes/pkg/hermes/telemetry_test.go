@@ -0,0 +1,67 @@
+package hermes
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const telemetryOutput = `# HELP wallet_balance The balance of each wallet Hermes uses per chain
+# TYPE wallet_balance gauge
+wallet_balance{account="cosmos1mars",chain="mars-1",denom="stake"} 99500
+wallet_balance{account="cosmos1venus",chain="venus-1",denom="token"} 1000
+# HELP total_messages_submitted_total Number of messages submitted to a specific chain
+# TYPE total_messages_submitted_total counter
+total_messages_submitted_total{account="cosmos1mars",chain="mars-1"} 12
+total_messages_submitted_total{account="cosmos1venus",chain="venus-1"} 3
+# HELP broadcast_errors_total Number of errors observed by Hermes when broadcasting a Tx
+# TYPE broadcast_errors_total counter
+broadcast_errors_total{account="cosmos1mars",error_code="13",error_description="insufficient fee"} 2
+broadcast_errors_total{account="cosmos1unknown",error_code="13",error_description="insufficient fee"} 5
+# HELP simulate_errors_total Number of errors observed by Hermes when simulating a Tx
+# TYPE simulate_errors_total counter
+simulate_errors_total{account="cosmos1mars",error_description="out of gas",recoverable="true"} 1
+# HELP tx_latency_submitted Latency for all transactions submitted to a specific chain
+# TYPE tx_latency_submitted histogram
+tx_latency_submitted_bucket{chain="mars-1",channel="channel-0",counterparty="venus-1",port="transfer",le="+Inf"} 2
+tx_latency_submitted_sum{chain="mars-1",channel="channel-0",counterparty="venus-1",port="transfer"} 3000
+tx_latency_submitted_count{chain="mars-1",channel="channel-0",counterparty="venus-1",port="transfer"} 2
+tx_latency_submitted_bucket{chain="mars-1",channel="channel-1",counterparty="venus-1",port="blog",le="+Inf"} 1
+tx_latency_submitted_sum{chain="mars-1",channel="channel-1",counterparty="venus-1",port="blog"} 3000
+tx_latency_submitted_count{chain="mars-1",channel="channel-1",counterparty="venus-1",port="blog"} 1
+# HELP backlog_size Total number of SendPacket events in the backlog
+# TYPE backlog_size gauge
+backlog_size{chain="venus-1",channel="channel-0",counterparty="mars-1",port="transfer"} 4
+backlog_size{chain="venus-1",channel="channel-1",counterparty="mars-1",port="blog"} 1
+# HELP backlog_oldest_sequence Sequence number of the oldest SendPacket event in the backlog
+# TYPE backlog_oldest_sequence gauge
+backlog_oldest_sequence{chain="venus-1",channel="channel-0",counterparty="mars-1",port="transfer"} 7
+backlog_oldest_sequence{chain="venus-1",channel="channel-1",counterparty="mars-1",port="blog"} 3
+`
+
+func TestParseMetrics(t *testing.T) {
+	got, err := ParseMetrics(strings.NewReader(telemetryOutput))
+	require.NoError(t, err)
+	require.Equal(t, []ChainMetrics{
+		{
+			ChainID:       "mars-1",
+			Balances:      []WalletBalance{{Account: "cosmos1mars", Denom: "stake", Amount: 99500}},
+			MsgsSubmitted: 12,
+			TxFailed:      3,
+			RelayLatency:  2 * time.Second,
+		},
+		{
+			ChainID:               "venus-1",
+			Balances:              []WalletBalance{{Account: "cosmos1venus", Denom: "token", Amount: 1000}},
+			MsgsSubmitted:         3,
+			Backlog:               5,
+			BacklogOldestSequence: 3,
+		},
+	}, got)
+	require.Equal(t, "99500stake", got[0].Balances[0].String())
+
+	_, err = ParseMetrics(strings.NewReader("invalid metric"))
+	require.Error(t, err)
+}
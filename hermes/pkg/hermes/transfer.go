@@ -0,0 +1,102 @@
+package hermes
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ignite/cli/v28/ignite/pkg/errors"
+)
+
+type (
+	// TransferResult represents an event from the result of the tx ft-transfer command.
+	TransferResult struct {
+		Event TransferEvent `json:"event"`
+	}
+
+	// TransferEvent represents the IBC event emitted by a transfer.
+	TransferEvent struct {
+		SendPacket *SendPacketEvent `json:"SendPacket"`
+	}
+
+	// SendPacketEvent represents the send packet event.
+	SendPacketEvent struct {
+		Packet PacketResult `json:"packet"`
+	}
+
+	// PacketResult represents an IBC packet from the Hermes output.
+	PacketResult struct {
+		Sequence           json.Number `json:"sequence"`
+		SourcePort         string      `json:"source_port"`
+		SourceChannel      string      `json:"source_channel"`
+		DestinationPort    string      `json:"destination_port"`
+		DestinationChannel string      `json:"destination_channel"`
+	}
+)
+
+// Transfer sends an ICS-20 fungible token transfer through the channel from the chain A
+// relayer key to the receiver on chain B and returns the packet sequence.
+func (h *Hermes) Transfer(
+	ctx context.Context,
+	channel ChannelResult,
+	amount sdk.Coin,
+	receiver string,
+	timeout time.Duration,
+	options ...Option,
+) (uint64, error) {
+	var out bytes.Buffer
+	options = append(
+		options,
+		WithArgs(string(cmdTx), string(cmdFtTransfer)),
+		WithFlags(Flags{
+			FlagSrcChain:       channel.ChainIDA,
+			FlagDstChain:       channel.ChainIDB,
+			FlagSrcPort:        channel.PortA,
+			FlagSrcChannel:     channel.ChannelA,
+			FlagAmount:         amount.Amount.String(),
+			FlagDenom:          amount.Denom,
+			FlagReceiver:       receiver,
+			FlagTimeoutSeconds: strconv.FormatInt(int64(timeout.Seconds()), 10),
+		}),
+		WithJSONOutput(),
+		WithStdOut(&out),
+	)
+	if err := h.Run(ctx, options...); err != nil {
+		return 0, err
+	}
+
+	results := make([]TransferResult, 0)
+	if err := UnmarshalResult(lastLine(out.Bytes()), &results); err != nil {
+		return 0, err
+	}
+	return transferSequence(results)
+}
+
+// transferSequence returns the sequence of the first packet sent by the transfer.
+func transferSequence(results []TransferResult) (uint64, error) {
+	for _, result := range results {
+		if result.Event.SendPacket == nil {
+			continue
+		}
+		seq, err := strconv.ParseUint(result.Event.SendPacket.Packet.Sequence.String(), 10, 64)
+		if err != nil {
+			return 0, errors.Wrapf(err, "invalid packet sequence %s", result.Event.SendPacket.Packet.Sequence)
+		}
+		return seq, nil
+	}
+	return 0, errors.New("the transfer did not send any packet")
+}
+
+// IBCDenom returns the denom of the tokens received through the port and channel,
+// in the ibc/<hash> format of the ICS-20 denom traces.
+func IBCDenom(portID, channelID, denom string) string {
+	hash := sha256.Sum256([]byte(fmt.Sprintf("%s/%s/%s", portID, channelID, denom)))
+	return "ibc/" + strings.ToUpper(hex.EncodeToString(hash[:]))
+}
@@ -0,0 +1,56 @@
+package hermes
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIBCDenom(t *testing.T) {
+	require.Equal(
+		t,
+		"ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2",
+		IBCDenom("transfer", "channel-0", "uatom"),
+	)
+}
+
+func TestTransferSequence(t *testing.T) {
+	tests := []struct {
+		name    string
+		result  string
+		want    uint64
+		wantErr bool
+	}{
+		{
+			name: "number sequence",
+			result: `[{"event":{"SendPacket":{"packet":{"sequence":7,"source_port":"transfer","source_channel":"channel-0"}}},
+				"height":{"revision_height":10,"revision_number":0}}]`,
+			want: 7,
+		},
+		{
+			name:   "string sequence",
+			result: `[{"event":{"SendPacket":{"packet":{"sequence":"12"}}}}]`,
+			want:   12,
+		},
+		{
+			name:    "no send packet",
+			result:  `[{"event":{"WriteAcknowledgement":{}}}]`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var results []TransferResult
+			require.NoError(t, json.Unmarshal([]byte(tt.result), &results))
+
+			got, err := transferSequence(results)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,245 @@
+package hermes
+
+import (
+	"fmt"
+	"math/big"
+	"net/url"
+	"slices"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/ignite/cli/v28/ignite/pkg/errors"
+)
+
+const (
+	// SeverityError is the severity of the issues making Hermes fail.
+	SeverityError Severity = "error"
+	// SeverityWarning is the severity of the issues that may make the relayer misbehave.
+	SeverityWarning Severity = "warning"
+
+	// maxMsgNum is the maximum number of messages into a Hermes tx.
+	maxMsgNum = 100
+	// maxTxSize is the maximum size in bytes of a Hermes tx.
+	maxTxSize = 8 << 20
+)
+
+var (
+	logLevels        = []string{"trace", "debug", "info", "warn", "error"}
+	eventSourceModes = []string{"push", "pull"}
+	// packetFilterPolicies are the packet filter policies.
+	packetFilterPolicies = []string{PacketFilterAllow, PacketFilterDeny}
+	// compatModes are the CometBFT versions supported by the compat mode.
+	compatModes = []string{"0.34", "0.37", "0.38"}
+	// minTrustThreshold is the minimum trust threshold accepted by the light clients.
+	minTrustThreshold = big.NewRat(1, 3)
+)
+
+type (
+	// Severity represents the severity of a config issue.
+	Severity string
+
+	// Issue represents an issue of the Hermes config.
+	Issue struct {
+		ChainID  string   `json:"chain_id,omitempty"`
+		Field    string   `json:"field"`
+		Severity Severity `json:"severity"`
+		Message  string   `json:"message"`
+	}
+
+	// Issues represents a list of config issues.
+	Issues []Issue
+)
+
+// HasErrors returns true if any issue is an error.
+func (i Issues) HasErrors() bool {
+	for _, issue := range i {
+		if issue.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// add adds an issue with the formatted message.
+func (i *Issues) add(chainID, field string, severity Severity, format string, args ...interface{}) {
+	*i = append(*i, Issue{
+		ChainID:  chainID,
+		Field:    field,
+		Severity: severity,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// Validate runs the schema checks of the Hermes config and its chains.
+func (c *Config) Validate() Issues {
+	var issues Issues
+	if !slices.Contains(logLevels, c.Global.LogLevel) {
+		issues.add("", "global.log_level", SeverityError, "invalid log level %q, expected one of %v", c.Global.LogLevel, logLevels)
+	}
+	if c.Telemetry.Enabled && c.Telemetry.Port == 0 {
+		issues.add("", "telemetry.port", SeverityError, "the telemetry port is required")
+	}
+	if c.Rest.Enabled && c.Rest.Port == 0 {
+		issues.add("", "rest.port", SeverityError, "the REST port is required")
+	}
+	if c.Telemetry.Enabled && c.Rest.Enabled && c.Telemetry.Host == c.Rest.Host && c.Telemetry.Port == c.Rest.Port {
+		issues.add("", "telemetry.port", SeverityError, "the telemetry and REST servers use the same address")
+	}
+	if !c.Mode.Clients.Refresh {
+		issues.add("", "mode.clients.refresh", SeverityWarning, "the clients are not refreshed and will expire without manual updates")
+	}
+
+	if len(c.Chains) < 2 {
+		issues.add("", "chains", SeverityError, "at least two chains are required to relay")
+	}
+	seen := make(map[string]bool)
+	for _, chain := range c.Chains {
+		if seen[chain.ID] {
+			issues.add(chain.ID, "id", SeverityError, "duplicated chain %s", chain.ID)
+		}
+		seen[chain.ID] = true
+		issues = append(issues, chain.Validate()...)
+	}
+	return issues
+}
+
+// Validate runs the schema checks of the chain config.
+func (c *Chain) Validate() Issues {
+	var issues Issues
+	if c.ID == "" {
+		issues.add(c.ID, "id", SeverityError, "the chain ID is required")
+	}
+
+	validateURL(&issues, c.ID, "rpc_addr", c.RPCAddr, "http", "https")
+	validateURL(&issues, c.ID, "grpc_addr", c.GRPCAddr, "http", "https")
+	switch c.EventSource.Mode {
+	case "push":
+		validateURL(&issues, c.ID, "event_source.url", c.EventSource.URL, "ws", "wss")
+		validateDuration(&issues, c.ID, "event_source.batch_delay", c.EventSource.BatchDelay)
+	case "pull":
+	default:
+		issues.add(c.ID, "event_source.mode", SeverityError, "invalid event source mode %q, expected one of %v", c.EventSource.Mode, eventSourceModes)
+	}
+
+	if c.AccountPrefix == "" {
+		issues.add(c.ID, "account_prefix", SeverityError, "the account prefix is required")
+	}
+	if c.KeyName == "" {
+		issues.add(c.ID, "key_name", SeverityError, "the key name is required")
+	}
+
+	if err := sdk.ValidateDenom(c.GasPrice.Denom); err != nil {
+		issues.add(c.ID, "gas_price.denom", SeverityError, "invalid gas price denom: %s", err)
+	}
+	if c.GasPrice.Price < 0 {
+		issues.add(c.ID, "gas_price.price", SeverityError, "the gas price cannot be negative")
+	} else if c.GasPrice.Price == 0 {
+		issues.add(c.ID, "gas_price.price", SeverityWarning, "zero gas price, the txs are rejected by the chains with minimum gas prices")
+	}
+	if c.MaxGas == 0 {
+		issues.add(c.ID, "max_gas", SeverityError, "the max gas is required")
+	} else if c.DefaultGas > c.MaxGas {
+		issues.add(c.ID, "default_gas", SeverityError, "the default gas %d is greater than the max gas %d", c.DefaultGas, c.MaxGas)
+	}
+	if c.GasMultiplier != 0 && c.GasMultiplier < 1 {
+		issues.add(c.ID, "gas_multiplier", SeverityError, "the gas multiplier %v must be greater or equal to 1", c.GasMultiplier)
+	}
+	if c.MaxMsgNum == 0 || c.MaxMsgNum > maxMsgNum {
+		issues.add(c.ID, "max_msg_num", SeverityError, "the max messages number %d must be between 1 and %d", c.MaxMsgNum, maxMsgNum)
+	}
+	if c.MaxTxSize > maxTxSize {
+		issues.add(c.ID, "max_tx_size", SeverityError, "the max tx size %d must be lower or equal to %d", c.MaxTxSize, maxTxSize)
+	}
+
+	validateDuration(&issues, c.ID, "rpc_timeout", c.RPCTimeout)
+	validateDuration(&issues, c.ID, "clock_drift", c.ClockDrift)
+	validateDuration(&issues, c.ID, "max_block_time", c.MaxBlockTime)
+	if !c.CCVConsumerChain || c.TrustingPeriod != "" {
+		validateDuration(&issues, c.ID, "trusting_period", c.TrustingPeriod)
+	}
+	if _, err := c.TrustThreshold.Rat(); err != nil {
+		issues.add(c.ID, "trust_threshold", SeverityError, "%s", err)
+	}
+
+	if c.DynamicGasPrice.Enabled {
+		if c.DynamicGasPrice.Multiplier < 1 {
+			issues.add(c.ID, "dynamic_gas_price.multiplier", SeverityError, "the dynamic gas price multiplier %v must be greater or equal to 1", c.DynamicGasPrice.Multiplier)
+		}
+		if c.DynamicGasPrice.Max < c.GasPrice.Price {
+			issues.add(c.ID, "dynamic_gas_price.max", SeverityError, "the dynamic gas price max %v is lower than the gas price %v", c.DynamicGasPrice.Max, c.GasPrice.Price)
+		}
+	}
+	if c.FeeGranter != "" {
+		if _, err := sdk.GetFromBech32(c.FeeGranter, c.AccountPrefix); err != nil {
+			issues.add(c.ID, "fee_granter", SeverityError, "invalid fee granter address %s: %s", c.FeeGranter, err)
+		}
+	}
+	for channelID, sequences := range c.ExcludedSequences {
+		if len(sequences) == 0 {
+			issues.add(c.ID, "excluded_sequences", SeverityWarning, "no excluded sequences for the channel %s", channelID)
+		}
+	}
+	if c.PacketFilter.Policy != "" || len(c.PacketFilter.List) > 0 {
+		if !slices.Contains(packetFilterPolicies, c.PacketFilter.Policy) {
+			issues.add(c.ID, "packet_filter.policy", SeverityError, "invalid packet filter policy %q, expected one of %v", c.PacketFilter.Policy, packetFilterPolicies)
+		}
+		for _, filter := range c.PacketFilter.List {
+			if err := filter.Validate(); err != nil {
+				issues.add(c.ID, "packet_filter.list", SeverityError, "%s", err)
+			}
+		}
+		if c.PacketFilter.Policy == PacketFilterAllow && len(c.PacketFilter.List) == 0 {
+			issues.add(c.ID, "packet_filter.list", SeverityWarning, "the allow packet filter is empty, no packet is relayed")
+		}
+	}
+	if c.CompatMode != "" && !slices.Contains(compatModes, c.CompatMode) {
+		issues.add(c.ID, "compat_mode", SeverityError, "invalid compat mode %q, expected one of %v", c.CompatMode, compatModes)
+	}
+	return issues
+}
+
+// Rat returns the trust threshold as a fraction, which must be between 1/3 and 1.
+func (t TrustThreshold) Rat() (*big.Rat, error) {
+	numerator, ok := new(big.Int).SetString(t.Numerator, 10)
+	if !ok {
+		return nil, errors.Errorf("invalid trust threshold numerator %q", t.Numerator)
+	}
+	denominator, ok := new(big.Int).SetString(t.Denominator, 10)
+	if !ok || denominator.Sign() <= 0 {
+		return nil, errors.Errorf("invalid trust threshold denominator %q", t.Denominator)
+	}
+	threshold := new(big.Rat).SetFrac(numerator, denominator)
+	if threshold.Cmp(minTrustThreshold) < 0 || threshold.Cmp(big.NewRat(1, 1)) > 0 {
+		return nil, errors.Errorf("the trust threshold %s must be between 1/3 and 1", threshold.RatString())
+	}
+	return threshold, nil
+}
+
+// validateURL adds an issue if the address is not a valid URL with one of the schemes.
+func validateURL(issues *Issues, chainID, field, addr string, schemes ...string) {
+	if addr == "" {
+		issues.add(chainID, field, SeverityError, "the address is required")
+		return
+	}
+	u, err := url.Parse(addr)
+	if err != nil {
+		issues.add(chainID, field, SeverityError, "invalid address %s: %s", addr, err)
+		return
+	}
+	if !slices.Contains(schemes, u.Scheme) {
+		issues.add(chainID, field, SeverityError, "invalid address %s scheme, expected one of %v", addr, schemes)
+	}
+	if u.Host == "" {
+		issues.add(chainID, field, SeverityError, "invalid address %s, missing the host", addr)
+	}
+}
+
+// validateDuration adds an issue if the value is not a valid positive humantime duration.
+func validateDuration(issues *Issues, chainID, field, value string) {
+	d, err := ParseDuration(value)
+	switch {
+	case err != nil:
+		issues.add(chainID, field, SeverityError, "%s", err)
+	case d == 0:
+		issues.add(chainID, field, SeverityError, "the duration must be greater than zero")
+	}
+}
@@ -0,0 +1,102 @@
+package hermes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigValidate(t *testing.T) {
+	newConfig := func(options ...ChainOption) *Config {
+		c := DefaultConfig()
+		_, err := c.AddChain("mars-1", "http://localhost:26657", "http://localhost:9090")
+		require.NoError(t, err)
+		_, err = c.AddChain("venus-1", "http://localhost:26667", "http://localhost:9100", options...)
+		require.NoError(t, err)
+		return c
+	}
+
+	tests := []struct {
+		name   string
+		config *Config
+		want   Issues
+	}{
+		{
+			name:   "valid config",
+			config: newConfig(),
+		},
+		{
+			name: "single chain",
+			config: func() *Config {
+				c := newConfig()
+				c.Chains = c.Chains[:1]
+				return c
+			}(),
+			want: Issues{
+				{Field: "chains", Severity: SeverityError, Message: "at least two chains are required to relay"},
+			},
+		},
+		{
+			name: "invalid global and chain values",
+			config: func() *Config {
+				c := newConfig(
+					WithChainTrustThreshold(1, 4),
+					WithChainClockDrift("5 parsecs"),
+					WithChainMaxGas(100),
+					WithChainDefaultGas(1000),
+				)
+				c.Global.LogLevel = "verbose"
+				c.Chains[1].EventSource.URL = "http://localhost:26667/websocket"
+				c.Chains[1].GasPrice.Price = 0
+				return c
+			}(),
+			want: Issues{
+				{Field: "global.log_level", Severity: SeverityError, Message: `invalid log level "verbose", expected one of [trace debug info warn error]`},
+				{ChainID: "venus-1", Field: "event_source.url", Severity: SeverityError, Message: "invalid address http://localhost:26667/websocket scheme, expected one of [ws wss]"},
+				{ChainID: "venus-1", Field: "gas_price.price", Severity: SeverityWarning, Message: "zero gas price, the txs are rejected by the chains with minimum gas prices"},
+				{ChainID: "venus-1", Field: "default_gas", Severity: SeverityError, Message: "the default gas 1000 is greater than the max gas 100"},
+				{ChainID: "venus-1", Field: "clock_drift", Severity: SeverityError, Message: `invalid duration 5 parsecs: unknown unit "parsecs"`},
+				{ChainID: "venus-1", Field: "trust_threshold", Severity: SeverityError, Message: "the trust threshold 1/4 must be between 1/3 and 1"},
+			},
+		},
+		{
+			name: "invalid fee sections",
+			config: newConfig(
+				WithChainDynamicGasPrice(true, 0.5, 0.001),
+				WithChainFeeGranter("osmo1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqmcn030"),
+				WithChainPacketFilter("block", PortFilter{"transfer", ""}),
+				WithChainCompatMode("0.36"),
+			),
+			want: Issues{
+				{ChainID: "venus-1", Field: "dynamic_gas_price.multiplier", Severity: SeverityError, Message: "the dynamic gas price multiplier 0.5 must be greater or equal to 1"},
+				{ChainID: "venus-1", Field: "dynamic_gas_price.max", Severity: SeverityError, Message: "the dynamic gas price max 0.001 is lower than the gas price 0.01"},
+				{ChainID: "venus-1", Field: "fee_granter", Severity: SeverityError, Message: "invalid fee granter address osmo1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqmcn030: invalid Bech32 prefix; expected cosmos, got osmo"},
+				{ChainID: "venus-1", Field: "packet_filter.policy", Severity: SeverityError, Message: `invalid packet filter policy "block", expected one of [allow deny]`},
+				{ChainID: "venus-1", Field: "packet_filter.list", Severity: SeverityError, Message: "invalid packet filter transfer/, the port and channel are required"},
+				{ChainID: "venus-1", Field: "compat_mode", Severity: SeverityError, Message: `invalid compat mode "0.36", expected one of [0.34 0.37 0.38]`},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.config.Validate()
+			require.Equal(t, tt.want, got)
+			require.Equal(t, len(tt.want) > 0, got.HasErrors())
+		})
+	}
+}
+
+func TestChainProbeUnreachable(t *testing.T) {
+	c := DefaultConfig()
+	chain, err := c.AddChain("mars-1", "http://127.0.0.1:1", "http://127.0.0.1:1")
+	require.NoError(t, err)
+
+	issues := chain.Probe(context.Background())
+	fields := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		require.Equal(t, SeverityError, issue.Severity)
+		fields = append(fields, issue.Field)
+	}
+	require.Equal(t, []string{"rpc_addr", "grpc_addr", "event_source.url"}, fields)
+}
@@ -0,0 +1,223 @@
+// Package sandbox prepares and tracks local chains started from Ignite apps to
+// test the Hermes relayer end to end.
+package sandbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	chainconfig "github.com/ignite/cli/v28/ignite/config/chain"
+	"github.com/ignite/cli/v28/ignite/config/chain/base"
+	"github.com/ignite/cli/v28/ignite/pkg/cosmosclient"
+	"github.com/ignite/cli/v28/ignite/pkg/errors"
+	"github.com/ignite/cli/v28/ignite/pkg/goenv"
+	"github.com/ignite/cli/v28/ignite/pkg/gomodulepath"
+)
+
+const (
+	// RelayerAccountName is the genesis account name of the relayer key.
+	RelayerAccountName = "hermes-relayer"
+	// PortsPerChain is the number of ports used by each sandbox chain.
+	PortsPerChain = 6
+
+	stateFile  = "sandbox.json"
+	configFile = "config.yml"
+	homeDir    = "home"
+	daemonDir  = "daemon"
+
+	// blockPollInterval is the interval to check the chain block height.
+	blockPollInterval = time.Second
+)
+
+// ErrNotFound indicates there is no sandbox state.
+var ErrNotFound = errors.New("sandbox not found")
+
+type (
+	// State represents the sandbox chains and Hermes config.
+	State struct {
+		HermesConfig string  `json:"hermes_config"`
+		Chains       []Chain `json:"chains"`
+	}
+
+	// Chain represents a sandbox chain started from an Ignite app.
+	Chain struct {
+		ID         string `json:"id"`
+		AppPath    string `json:"app_path"`
+		Dir        string `json:"dir"`
+		Home       string `json:"home"`
+		ConfigPath string `json:"config_path"`
+		Binary     string `json:"binary,omitempty"`
+		RPCAddr    string `json:"rpc_addr"`
+		GRPCAddr   string `json:"grpc_addr"`
+	}
+)
+
+// DaemonDir returns the directory of the chain node daemon.
+func (c Chain) DaemonDir() string {
+	return filepath.Join(c.Dir, daemonDir)
+}
+
+// StartCommand returns the command to start the chain node.
+func (c Chain) StartCommand() []string {
+	return []string{c.Binary, "start", "--home", c.Home}
+}
+
+// LoadState loads the sandbox state from the directory.
+func LoadState(dir string) (State, error) {
+	data, err := os.ReadFile(filepath.Join(dir, stateFile))
+	if os.IsNotExist(err) {
+		return State{}, ErrNotFound
+	} else if err != nil {
+		return State{}, err
+	}
+	var state State
+	return state, json.Unmarshal(data, &state)
+}
+
+// SaveState saves the sandbox state into the directory.
+func SaveState(dir string, state State) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, stateFile), data, 0o644)
+}
+
+// PrepareChain writes the app chain config with the chain ID, a dedicated home,
+// the ports and a genesis account funding the relayer key from the mnemonic.
+// The chain files are stored into the directory.
+func PrepareChain(appPath, chainID, dir, mnemonic string, ports []uint) (Chain, error) {
+	if len(ports) < PortsPerChain {
+		return Chain{}, errors.Errorf("expected %d ports, got %d", PortsPerChain, len(ports))
+	}
+	appPath, err := filepath.Abs(appPath)
+	if err != nil {
+		return Chain{}, err
+	}
+	appCfgPath, err := chainconfig.LocateDefault(appPath)
+	if err != nil {
+		return Chain{}, errors.Wrapf(err, "failed to locate the %s chain config", appPath)
+	}
+	cfg, err := chainconfig.ParseFile(appCfgPath)
+	if err != nil {
+		return Chain{}, err
+	}
+	if len(cfg.Accounts) == 0 || len(cfg.Validators) == 0 {
+		return Chain{}, errors.Errorf("the %s chain config requires at least one account and one validator", appPath)
+	}
+
+	chain := Chain{
+		ID:         chainID,
+		AppPath:    appPath,
+		Dir:        dir,
+		Home:       filepath.Join(dir, homeDir),
+		ConfigPath: filepath.Join(dir, configFile),
+		RPCAddr:    fmt.Sprintf("http://127.0.0.1:%d", ports[0]),
+		GRPCAddr:   fmt.Sprintf("http://127.0.0.1:%d", ports[1]),
+	}
+	if err := setChainConfig(cfg, chain, mnemonic, ports); err != nil {
+		return Chain{}, err
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return Chain{}, err
+	}
+	// chainconfig.Save only writes into an existing file.
+	if err := os.WriteFile(chain.ConfigPath, nil, 0o644); err != nil {
+		return Chain{}, err
+	}
+	return chain, chainconfig.Save(*cfg, chain.ConfigPath)
+}
+
+// setChainConfig sets the chain ID, the validator home and servers and the relayer
+// genesis account, funded with the coins of the first account.
+func setChainConfig(cfg *chainconfig.Config, chain Chain, mnemonic string, ports []uint) error {
+	if cfg.Genesis == nil {
+		cfg.Genesis = make(map[string]interface{})
+	}
+	cfg.Genesis["chain_id"] = chain.ID
+
+	for _, account := range cfg.Accounts {
+		if account.Name == RelayerAccountName {
+			return errors.Errorf("the chain config already has a %s account", RelayerAccountName)
+		}
+	}
+	cfg.Accounts = append(cfg.Accounts, base.Account{
+		Name:     RelayerAccountName,
+		Coins:    cfg.Accounts[0].Coins,
+		Mnemonic: mnemonic,
+	})
+
+	validator := &cfg.Validators[0]
+	validator.Home = chain.Home
+	servers, err := validator.GetServers()
+	if err != nil {
+		return err
+	}
+	servers.RPC.Address = fmt.Sprintf("tcp://127.0.0.1:%d", ports[0])
+	servers.GRPC.Address = fmt.Sprintf("127.0.0.1:%d", ports[1])
+	servers.GRPCWeb.Address = fmt.Sprintf("127.0.0.1:%d", ports[2])
+	servers.API.Address = fmt.Sprintf("tcp://127.0.0.1:%d", ports[3])
+	servers.P2P.Address = fmt.Sprintf("tcp://127.0.0.1:%d", ports[4])
+	servers.RPC.PProfAddress = fmt.Sprintf("127.0.0.1:%d", ports[5])
+	return validator.SetServers(servers)
+}
+
+// FindBinary returns the path of the chain binary installed by Ignite, from the
+// build binary of the chain config or the app name.
+func FindBinary(chain Chain) (string, error) {
+	cfg, err := chainconfig.ParseFile(chain.ConfigPath)
+	if err != nil {
+		return "", err
+	}
+	name := cfg.Build.Binary
+	if name == "" {
+		modulePath, err := gomodulepath.ParseAt(chain.AppPath)
+		if err != nil {
+			return "", err
+		}
+		name = modulePath.Root + "d"
+	}
+
+	binary := filepath.Join(goenv.Bin(), name)
+	if _, err := os.Stat(binary); err == nil {
+		return binary, nil
+	}
+	if binary, err = exec.LookPath(name); err != nil {
+		return "", errors.Wrapf(err, "chain %s binary %s not found", chain.ID, name)
+	}
+	return binary, nil
+}
+
+// WaitBlock waits until the chain node produces the block height, ignoring the RPC
+// errors while the node is starting.
+func WaitBlock(ctx context.Context, rpcAddr string, height int64, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	client, err := cosmosclient.New(ctx, cosmosclient.WithNodeAddress(rpcAddr))
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(blockPollInterval)
+	defer ticker.Stop()
+	for {
+		if latest, err := client.LatestBlockHeight(ctx); err == nil && latest >= height {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return errors.Errorf("node %s did not reach the block %d within %s", rpcAddr, height, timeout)
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package sandbox
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	chainconfig "github.com/ignite/cli/v28/ignite/config/chain"
+	"github.com/stretchr/testify/require"
+)
+
+const appConfig = `version: 1
+accounts:
+  - name: alice
+    coins:
+      - 20000token
+      - 200000000stake
+  - name: bob
+    coins:
+      - 10000token
+validators:
+  - name: alice
+    bonded: 100000000stake
+`
+
+func TestPrepareChain(t *testing.T) {
+	var (
+		appPath = t.TempDir()
+		dir     = filepath.Join(t.TempDir(), "sandbox-a")
+		ports   = []uint{1001, 1002, 1003, 1004, 1005, 1006}
+	)
+	require.NoError(t, os.WriteFile(filepath.Join(appPath, "config.yml"), []byte(appConfig), 0o644))
+
+	chain, err := PrepareChain(appPath, "sandbox-a", dir, "test mnemonic", ports)
+	require.NoError(t, err)
+	require.Equal(t, Chain{
+		ID:         "sandbox-a",
+		AppPath:    appPath,
+		Dir:        dir,
+		Home:       filepath.Join(dir, "home"),
+		ConfigPath: filepath.Join(dir, "config.yml"),
+		RPCAddr:    "http://127.0.0.1:1001",
+		GRPCAddr:   "http://127.0.0.1:1002",
+	}, chain)
+
+	cfg, err := chainconfig.ParseFile(chain.ConfigPath)
+	require.NoError(t, err)
+	require.Equal(t, "sandbox-a", cfg.Genesis["chain_id"])
+	require.Len(t, cfg.Accounts, 3)
+	require.Equal(t, RelayerAccountName, cfg.Accounts[2].Name)
+	require.Equal(t, "test mnemonic", cfg.Accounts[2].Mnemonic)
+	require.Equal(t, []string{"20000token", "200000000stake"}, cfg.Accounts[2].Coins)
+	require.Equal(t, chain.Home, cfg.Validators[0].Home)
+
+	servers, err := cfg.Validators[0].GetServers()
+	require.NoError(t, err)
+	require.Equal(t, "tcp://127.0.0.1:1001", servers.RPC.Address)
+	require.Equal(t, "127.0.0.1:1002", servers.GRPC.Address)
+	require.Equal(t, "127.0.0.1:1003", servers.GRPCWeb.Address)
+	require.Equal(t, "tcp://127.0.0.1:1004", servers.API.Address)
+	require.Equal(t, "tcp://127.0.0.1:1005", servers.P2P.Address)
+	require.Equal(t, "127.0.0.1:1006", servers.RPC.PProfAddress)
+
+	_, err = PrepareChain(appPath, "sandbox-a", dir, "test mnemonic", ports[:2])
+	require.Error(t, err)
+}
+
+func TestState(t *testing.T) {
+	dir := t.TempDir()
+	_, err := LoadState(dir)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	state := State{
+		HermesConfig: "/tmp/hermes.toml",
+		Chains:       []Chain{{ID: "sandbox-a", Dir: "/tmp/sandbox-a"}},
+	}
+	require.NoError(t, SaveState(dir, state))
+
+	loaded, err := LoadState(dir)
+	require.NoError(t, err)
+	require.Equal(t, state, loaded)
+	require.Equal(t, "/tmp/sandbox-a/daemon", loaded.Chains[0].DaemonDir())
+}
@@ -37,6 +37,48 @@ var defaultFlags = []*plugin.Flag{
 		Usage: "ssh key password",
 		Type:  plugin.FlagTypeString,
 	},
+	{
+		Name:  flagKnownHosts,
+		Usage: "ssh known hosts file used to verify the host key (default $HOME/.ssh/known_hosts)",
+		Type:  plugin.FlagTypeString,
+	},
+	{
+		Name:      flagGroup,
+		Shorthand: "g",
+		Usage:     "run the command against a host group from the groups file",
+		Type:      plugin.FlagTypeString,
+	},
+	{
+		Name:  flagGroupsFile,
+		Usage: "host groups file (default $HOME/.ignite/spaceship/hosts.yml)",
+		Type:  plugin.FlagTypeString,
+	},
+	{
+		Name:         flagParallel,
+		Usage:        "number of hosts processed at the same time",
+		Type:         plugin.FlagTypeInt,
+		DefaultValue: "5",
+	},
+}
+
+var rollingFlags = []*plugin.Flag{
+	{
+		Name:  flagRolling,
+		Usage: "process the hosts one by one, waiting for each node to be healthy before moving on",
+		Type:  plugin.FlagTypeBool,
+	},
+	{
+		Name:         flagHealthTimeout,
+		Usage:        "time to wait for a node to be healthy in the rolling mode",
+		Type:         plugin.FlagTypeString,
+		DefaultValue: "5m",
+	},
+	{
+		Name:         flagRPCPort,
+		Usage:        "node RPC port used to check the node health in the rolling mode",
+		Type:         plugin.FlagTypeString,
+		DefaultValue: "26657",
+	},
 }
 
 // GetCommands returns the list of spaceship app commands.
@@ -47,9 +89,9 @@ func GetCommands() []*plugin.Command {
 			Short: "spaceship is an awesome Ignite application!",
 			Commands: []*plugin.Command{
 				{
-					Use:   "deploy",
+					Use:   "deploy [host...]",
 					Short: "deploy your chain",
-					Flags: append(defaultFlags,
+					Flags: append(append(defaultFlags, rollingFlags...),
 						&plugin.Flag{
 							Name:      flagInitChain,
 							Shorthand: "i",
@@ -59,7 +101,7 @@ func GetCommands() []*plugin.Command {
 					),
 				},
 				{
-					Use:   "log",
+					Use:   "log [host...]",
 					Short: "get chain logs if its running",
 					Flags: append(defaultFlags,
 						&plugin.Flag{
@@ -77,17 +119,52 @@ func GetCommands() []*plugin.Command {
 					),
 				},
 				{
-					Use:   "status",
+					Use:   "metrics [host...]",
+					Short: "collect the remote host and chain node metrics",
+					Flags: append(defaultFlags,
+						&plugin.Flag{
+							Name:         flagInterval,
+							Shorthand:    "i",
+							Usage:        "interval between the metrics samples",
+							Type:         plugin.FlagTypeString,
+							DefaultValue: "5s",
+						},
+						&plugin.Flag{
+							Name:         flagSamples,
+							Usage:        "number of samples to collect (0 to run until interrupted)",
+							Type:         plugin.FlagTypeInt,
+							DefaultValue: "0",
+						},
+						&plugin.Flag{
+							Name:         flagPrometheusPort,
+							Usage:        "node Prometheus endpoint port",
+							Type:         plugin.FlagTypeString,
+							DefaultValue: "26660",
+						},
+						&plugin.Flag{
+							Name:  flagEnablePrometheus,
+							Usage: "enable the node Prometheus endpoint into the chain config.toml, restarting the chain if the config changes",
+							Type:  plugin.FlagTypeBool,
+						},
+						&plugin.Flag{
+							Name:  flagJSON,
+							Usage: "export the metrics as JSON lines",
+							Type:  plugin.FlagTypeBool,
+						},
+					),
+				},
+				{
+					Use:   "status [host...]",
 					Short: "get chain status if its running",
 					Flags: defaultFlags,
 				},
 				{
-					Use:   "restart",
+					Use:   "restart [host...]",
 					Short: "restart your chain",
-					Flags: defaultFlags,
+					Flags: append(defaultFlags, rollingFlags...),
 				},
 				{
-					Use:   "stop",
+					Use:   "stop [host...]",
 					Short: "stop your chain",
 					Flags: defaultFlags,
 				},
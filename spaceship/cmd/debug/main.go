@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -9,25 +10,49 @@ import (
 	"github.com/ignite/cli/v28/ignite/services/plugin"
 
 	"github.com/ignite/apps/spaceship/cmd"
+	"github.com/ignite/apps/spaceship/pkg/sshtest"
 )
 
 func main() {
-	home, err := os.UserHomeDir()
-	if err != nil {
-		fmt.Fprintln(os.Stderr, err)
+	var (
+		host    = flag.String("host", "", "ssh host uri, an in-process ssh server is used if empty")
+		key     = flag.String("key", "", "ssh key")
+		root    = flag.String("root", filepath.Join(os.TempDir(), "spaceship-debug"), "in-process ssh server home directory")
+		appPath = flag.String("app", ".", "chain app path")
+		chainID = flag.String("chain-id", "mars", "chain id")
+	)
+	flag.Parse()
+	if flag.NArg() == 0 {
+		fmt.Fprintln(os.Stderr, "usage: debug [flags] deploy|log|metrics|status|restart|stop")
 		return
 	}
+
+	var knownHosts string
+	if *host == "" {
+		if err := os.MkdirAll(*root, 0o755); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return
+		}
+		srv, err := sshtest.NewServer(sshtest.WithRoot(*root))
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return
+		}
+		defer srv.Close()
+		*host, *key, knownHosts = srv.URI(), srv.KeyPath, srv.KnownHostsPath
+	}
+
 	var (
-		args      = os.Args
+		args      = flag.Args()
 		ctx       = context.Background()
 		chainInfo = &plugin.ChainInfo{
-			AppPath: filepath.Join(home, "Desktop/go/src/github.com/ignite/mars"),
-			ChainId: "mars",
+			AppPath: *appPath,
+			ChainId: *chainID,
 		}
 		c = &plugin.ExecutedCommand{
-			Use:    args[1],
-			Path:   "ignite spaceship " + args[1],
-			Args:   []string{fmt.Sprintf("%s@127.0.0.1", filepath.Base(home))},
+			Use:    args[0],
+			Path:   "ignite spaceship " + args[0],
+			Args:   []string{*host},
 			OsArgs: os.Args,
 			With:   nil,
 			Flags: []*plugin.Flag{
@@ -36,21 +61,20 @@ func main() {
 					Shorthand: "k",
 					Usage:     "ssh key",
 					Type:      plugin.FlagTypeString,
-					Value:     filepath.Join(home, ".ssh/id_rsa"),
+					Value:     *key,
+				},
+				{
+					Name:  "known-hosts",
+					Usage: "ssh known hosts file used to verify the host key",
+					Type:  plugin.FlagTypeString,
+					Value: knownHosts,
 				},
 			},
 		}
 	)
-	switch args[1] {
+	switch args[0] {
 	case "deploy":
 		c.Flags = append(c.Flags,
-			&plugin.Flag{
-				Name:      "init-chain",
-				Shorthand: "i",
-				Usage:     "run init chain and create the home folder",
-				Type:      plugin.FlagTypeBool,
-				Value:     "true",
-			},
 			&plugin.Flag{
 				Name:      "init-chain",
 				Shorthand: "i",
@@ -73,6 +97,17 @@ func main() {
 			fmt.Fprintln(os.Stderr, err)
 			return
 		}
+	case "metrics":
+		c.Flags = append(c.Flags, &plugin.Flag{
+			Name:  "interval",
+			Usage: "interval between the metrics samples",
+			Type:  plugin.FlagTypeString,
+			Value: "5s",
+		})
+		if err := cmd.ExecuteSSHMetrics(ctx, c, chainInfo); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return
+		}
 	case "status":
 		if err := cmd.ExecuteSSHStatus(ctx, c, chainInfo); err != nil {
 			fmt.Fprintln(os.Stderr, err)
@@ -89,7 +124,7 @@ func main() {
 			return
 		}
 	default:
-		fmt.Fprintf(os.Stderr, "unknown command: %s", args[1])
+		fmt.Fprintf(os.Stderr, "unknown command: %s", args[0])
 		return
 	}
 }
@@ -0,0 +1,178 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/gookit/color"
+	"github.com/ignite/cli/v28/ignite/pkg/cliui"
+	"github.com/ignite/cli/v28/ignite/pkg/errors"
+	"github.com/ignite/cli/v28/ignite/services/plugin"
+
+	"github.com/ignite/apps/spaceship/pkg/fleet"
+	"github.com/ignite/apps/spaceship/pkg/ssh"
+)
+
+// hostOperation represents an operation executed against a connected host.
+type hostOperation func(ctx context.Context, host string, c *ssh.SSH) (string, error)
+
+// getHosts returns the hosts from the command args and the host group flag.
+func getHosts(cmd *plugin.ExecutedCommand) ([]string, error) {
+	var (
+		flags         = plugin.Flags(cmd.Flags)
+		group, _      = flags.GetString(flagGroup)
+		groupsFile, _ = flags.GetString(flagGroupsFile)
+		hosts         = append([]string{}, cmd.Args...)
+	)
+	if group != "" {
+		if groupsFile == "" {
+			path, err := fleet.DefaultGroupsPath()
+			if err != nil {
+				return nil, err
+			}
+			groupsFile = path
+		}
+		groups, err := fleet.LoadGroups(groupsFile)
+		if err != nil {
+			return nil, err
+		}
+		groupHosts, err := groups.Hosts(group)
+		if err != nil {
+			return nil, err
+		}
+		hosts = append(hosts, groupHosts...)
+	}
+
+	hosts = fleet.Unique(hosts...)
+	if len(hosts) == 0 {
+		return nil, errors.Errorf("a host URI argument or a host group (--%s) is required", flagGroup)
+	}
+	return hosts, nil
+}
+
+// getParallel returns the number of hosts processed at the same time.
+func getParallel(cmd *plugin.ExecutedCommand) int {
+	parallel, _ := plugin.Flags(cmd.Flags).GetInt(flagParallel)
+	if parallel <= 0 {
+		return fleet.DefaultParallel
+	}
+	return parallel
+}
+
+// isRolling returns true if the hosts should be processed one by one.
+func isRolling(cmd *plugin.ExecutedCommand) bool {
+	rolling, _ := plugin.Flags(cmd.Flags).GetBool(flagRolling)
+	return rolling
+}
+
+// healthGate returns the node RPC port and the health timeout used to wait for the node
+// after a rolling operation.
+func healthGate(cmd *plugin.ExecutedCommand) (string, time.Duration, error) {
+	var (
+		flags            = plugin.Flags(cmd.Flags)
+		rpcPort, _       = flags.GetString(flagRPCPort)
+		healthTimeout, _ = flags.GetString(flagHealthTimeout)
+	)
+	if rpcPort == "" {
+		rpcPort = ssh.DefaultRPCPort
+	}
+	timeout, err := time.ParseDuration(healthTimeout)
+	if err != nil {
+		return "", 0, errors.Errorf("failed to parse %s flag: %s", flagHealthTimeout, err)
+	}
+	return rpcPort, timeout, nil
+}
+
+// runHosts connects to every host and runs the operation concurrently,
+// or one by one if the rolling flag is set.
+// If requireRunner is true, hosts without the runner script fail with ErrServerNotInitialized.
+func runHosts(
+	ctx context.Context,
+	cmd *plugin.ExecutedCommand,
+	chain *plugin.ChainInfo,
+	hosts []string,
+	requireRunner bool,
+	op hostOperation,
+) fleet.Results {
+	run := hostRunner(cmd, chain, requireRunner, op)
+	if isRolling(cmd) {
+		return fleet.RunRolling(ctx, hosts, run)
+	}
+	return fleet.Run(ctx, hosts, getParallel(cmd), run)
+}
+
+// hostRunner returns the fleet operation connecting to the host and running the operation.
+// If requireRunner is true, hosts without the runner script fail with ErrServerNotInitialized.
+func hostRunner(
+	cmd *plugin.ExecutedCommand,
+	chain *plugin.ChainInfo,
+	requireRunner bool,
+	op hostOperation,
+) fleet.Operation {
+	return func(ctx context.Context, host string) (string, error) {
+		c, err := executeSSH(cmd, chain, host)
+		if err != nil {
+			return "", err
+		}
+		defer c.Close()
+
+		if requireRunner && !c.HasRunnerScript(ctx) {
+			return "", ErrServerNotInitialized
+		}
+		return op(ctx, host, c)
+	}
+}
+
+// executeHosts runs the operation against all command hosts and prints the results.
+// A single host prints the operation output, otherwise a per-host summary table is printed.
+// It returns an error if any host failed.
+func executeHosts(
+	ctx context.Context,
+	session *cliui.Session,
+	cmd *plugin.ExecutedCommand,
+	chain *plugin.ChainInfo,
+	requireRunner bool,
+	op hostOperation,
+) error {
+	hosts, err := getHosts(cmd)
+	if err != nil {
+		return err
+	}
+
+	results := runHosts(ctx, cmd, chain, hosts, requireRunner, op)
+	session.StopSpinner()
+	if len(results) == 1 {
+		if results[0].Err != nil {
+			return results[0].Err
+		}
+		return session.Println(results[0].Output)
+	}
+
+	if err := printResults(session, results); err != nil {
+		return err
+	}
+	return results.Err()
+}
+
+// printResults prints the per-host results table.
+func printResults(session *cliui.Session, results fleet.Results) error {
+	entries := make([][]string, 0, len(results))
+	for _, result := range results {
+		status, message := color.Green.Sprint("success"), lastLine(result.Output)
+		switch {
+		case errors.Is(result.Err, fleet.ErrSkipped):
+			status, message = color.Yellow.Sprint("skipped"), lastLine(result.Err.Error())
+		case result.Err != nil:
+			status, message = color.Red.Sprint("failed"), lastLine(result.Err.Error())
+		}
+		entries = append(entries, []string{result.Host, status, message})
+	}
+	return session.PrintTable([]string{"host", "status", "result"}, entries...)
+}
+
+// lastLine returns the last non-empty line of a text.
+func lastLine(text string) string {
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
@@ -0,0 +1,247 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/gookit/color"
+	"github.com/ignite/cli/v28/ignite/pkg/cliui"
+	"github.com/ignite/cli/v28/ignite/pkg/errors"
+	"github.com/ignite/cli/v28/ignite/services/plugin"
+
+	"github.com/ignite/apps/spaceship/pkg/fleet"
+	"github.com/ignite/apps/spaceship/pkg/ssh"
+)
+
+// clearScreen is the ANSI sequence to move the cursor to the top left and clear the terminal.
+const clearScreen = "\033[H\033[2J"
+
+// nodeMetrics are the node metrics shown into the live terminal view.
+var nodeMetrics = []struct {
+	header string
+	name   string
+}{
+	{header: "height", name: "consensus_height"},
+	{header: "validators", name: "consensus_validators"},
+	{header: "txs", name: "consensus_total_txs"},
+	{header: "mempool", name: "mempool_size"},
+	{header: "peers", name: "p2p_peers"},
+}
+
+// metricsSample represents a single metrics sample from the remote server.
+type metricsSample struct {
+	Time      time.Time       `json:"time"`
+	Host      string          `json:"host"`
+	Resources ssh.HostMetrics `json:"resources"`
+	Node      ssh.NodeMetrics `json:"node,omitempty"`
+	NodeError string          `json:"node_error,omitempty"`
+}
+
+// ExecuteSSHMetrics executes the ssh metrics subcommand.
+func ExecuteSSHMetrics(ctx context.Context, cmd *plugin.ExecutedCommand, chain *plugin.ChainInfo) error {
+	session := cliui.New(cliui.StartSpinnerWithText(statusConnecting))
+	defer session.End()
+
+	var (
+		flags               = plugin.Flags(cmd.Flags)
+		isJSON, _           = flags.GetBool(flagJSON)
+		samples, _          = flags.GetInt(flagSamples)
+		intervalFlag, _     = flags.GetString(flagInterval)
+		prometheusPort, _   = flags.GetString(flagPrometheusPort)
+		enablePrometheus, _ = flags.GetBool(flagEnablePrometheus)
+	)
+	if prometheusPort == "" {
+		prometheusPort = ssh.DefaultPrometheusPort
+	}
+
+	interval, err := time.ParseDuration(intervalFlag)
+	if err != nil {
+		return errors.Errorf("failed to parse %s flag: %s", flagInterval, err)
+	}
+
+	hosts, err := getHosts(cmd)
+	if err != nil {
+		return err
+	}
+
+	var (
+		mu        sync.Mutex
+		latest    = make(map[string]metricsSample)
+		restarted = make(map[string]bool)
+	)
+	collect := func(ctx context.Context, host string, c *ssh.SSH) (string, error) {
+		// the chain config is only changed and the chain restarted if explicitly asked.
+		chainRestarted := false
+		if enablePrometheus {
+			changed, err := c.EnablePrometheus(prometheusPort)
+			if err != nil {
+				return "", err
+			}
+			if changed {
+				if _, err := c.Restart(ctx); err != nil {
+					return "", errors.Wrap(err, "failed to restart the chain to enable Prometheus")
+				}
+				chainRestarted = true
+				mu.Lock()
+				restarted[host] = true
+				session.StopSpinner()
+				_, _ = fmt.Fprintln(os.Stderr, color.Yellow.Sprintf("%s: Prometheus enabled, chain restarted", host))
+				mu.Unlock()
+			}
+		}
+
+		collected := 0
+		for ; samples <= 0 || collected < samples; collected++ {
+			resources, err := c.HostMetrics(ctx, interval)
+			if errors.Is(err, context.Canceled) {
+				break
+			}
+			if err != nil {
+				return "", err
+			}
+
+			sample := metricsSample{
+				Time:      time.Now(),
+				Host:      host,
+				Resources: resources,
+			}
+			sample.Node, err = c.NodeMetrics(ctx, prometheusPort)
+			if err != nil && !enablePrometheus {
+				sample.NodeError = fmt.Sprintf("%s (enable the node Prometheus endpoint with --%s)", err, flagEnablePrometheus)
+			} else if err != nil {
+				sample.NodeError = err.Error()
+			}
+
+			mu.Lock()
+			session.StopSpinner()
+			if isJSON {
+				err = printMetricsJSON(sample)
+			} else {
+				latest[host] = sample
+				err = printMetrics(session, hosts, latest, restarted)
+			}
+			mu.Unlock()
+			if err != nil {
+				return "", err
+			}
+		}
+		if chainRestarted {
+			return fmt.Sprintf("%d samples collected, chain restarted to enable Prometheus", collected), nil
+		}
+		return fmt.Sprintf("%d samples collected", collected), nil
+	}
+
+	// the watch mode samples every host until interrupted, so each host has its own
+	// worker instead of waiting for a free slot of the parallel limit.
+	var results fleet.Results
+	if samples <= 0 {
+		results = fleet.Run(ctx, hosts, len(hosts), hostRunner(cmd, chain, true, collect))
+	} else {
+		results = runHosts(ctx, cmd, chain, hosts, true, collect)
+	}
+	session.StopSpinner()
+	if len(results) == 1 {
+		return results[0].Err
+	}
+
+	if !isJSON {
+		if err := printResults(session, results); err != nil {
+			return err
+		}
+	}
+	return results.Err()
+}
+
+// printMetricsJSON prints the metrics sample as a JSON line.
+func printMetricsJSON(sample metricsSample) error {
+	data, err := json.Marshal(sample)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(os.Stdout, string(data))
+	return err
+}
+
+// printMetrics renders the latest metrics sample of each host into the terminal, listing
+// the hosts whose chain was restarted to enable Prometheus.
+func printMetrics(session *cliui.Session, hosts []string, latest map[string]metricsSample, restarted map[string]bool) error {
+	var (
+		resources   = make([][]string, 0, len(hosts))
+		node        = make([][]string, 0, len(hosts))
+		nodeHeaders = []string{"host"}
+	)
+	for _, metric := range nodeMetrics {
+		nodeHeaders = append(nodeHeaders, metric.header)
+	}
+
+	for _, host := range hosts {
+		sample, ok := latest[host]
+		if !ok {
+			continue
+		}
+
+		r := sample.Resources
+		resources = append(resources, []string{
+			host,
+			fmt.Sprintf("%.2f%%", r.CPUPercent),
+			fmt.Sprintf("%s / %s", formatBytes(float64(r.MemoryUsed)), formatBytes(float64(r.MemoryTotal))),
+			fmt.Sprintf("%s/s (total %s)", formatBytes(r.DiskReadRate), formatBytes(float64(r.DiskReadBytes))),
+			fmt.Sprintf("%s/s (total %s)", formatBytes(r.DiskWriteRate), formatBytes(float64(r.DiskWrittenBytes))),
+		})
+
+		row := []string{host}
+		for _, metric := range nodeMetrics {
+			value, ok := sample.Node.Get(metric.name)
+			switch {
+			case sample.NodeError != "":
+				row = append(row, color.Red.Sprint("unavailable"))
+			case !ok:
+				row = append(row, "-")
+			default:
+				row = append(row, fmt.Sprintf("%g", value))
+			}
+		}
+		node = append(node, row)
+	}
+
+	_ = session.Print(clearScreen)
+	_ = session.Println(color.Yellow.Sprintf("Metrics - %s", time.Now().Format(time.DateTime)))
+	if err := session.PrintTable(
+		[]string{"host", "cpu", "memory", "disk read", "disk write"},
+		resources...,
+	); err != nil {
+		return err
+	}
+	_ = session.Println("")
+	if err := session.PrintTable(nodeHeaders, node...); err != nil {
+		return err
+	}
+
+	for _, host := range hosts {
+		if restarted[host] {
+			_ = session.Println(color.Yellow.Sprintf("%s: Prometheus enabled, chain restarted", host))
+		}
+		if sample, ok := latest[host]; ok && sample.NodeError != "" {
+			_ = session.Println(color.Red.Sprintf("%s: node metrics unavailable: %s", host, sample.NodeError))
+		}
+	}
+	return nil
+}
+
+// formatBytes formats a byte size into a human-readable string.
+func formatBytes(b float64) string {
+	const unit = 1024
+	if b < unit {
+		return fmt.Sprintf("%.0f B", b)
+	}
+	div, exp := float64(unit), 0
+	for n := b / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", b/div, "KMGTPE"[exp])
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"github.com/gookit/color"
 	ignitecmd "github.com/ignite/cli/v28/ignite/cmd"
@@ -29,22 +30,28 @@ const (
 	flagKey         = "key"
 	flagRawKey      = "raw-key"
 	flagKeyPassword = "key-password"
+	flagKnownHosts  = "known-hosts"
 	flagInitChain   = "init-chain"
 	flagLines       = "lines"
 	flagRealTime    = "real-time"
+	flagJSON        = "json"
+	flagSamples     = "samples"
+	flagInterval    = "interval"
+
+	flagGroup            = "group"
+	flagGroupsFile       = "groups-file"
+	flagParallel         = "parallel"
+	flagRolling          = "rolling"
+	flagHealthTimeout    = "health-timeout"
+	flagRPCPort          = "rpc-port"
+	flagPrometheusPort   = "prometheus-port"
+	flagEnablePrometheus = "enable-prometheus"
 
 	statusConnecting = "Connecting..."
 )
 
-func executeSSH(cmd *plugin.ExecutedCommand, chain *plugin.ChainInfo) (*ssh.SSH, error) {
-	args := cmd.Args
-	if len(args) < 1 {
-		return nil, errors.New("must specify unless a uri host")
-	}
-
+func executeSSH(cmd *plugin.ExecutedCommand, chain *plugin.ChainInfo, host string) (*ssh.SSH, error) {
 	var (
-		host = args[0]
-
 		flags          = plugin.Flags(cmd.Flags)
 		user, _        = flags.GetString(flagUser)
 		port, _        = flags.GetString(flagPort)
@@ -52,6 +59,7 @@ func executeSSH(cmd *plugin.ExecutedCommand, chain *plugin.ChainInfo) (*ssh.SSH,
 		key, _         = flags.GetString(flagKey)
 		rawKey, _      = flags.GetString(flagRawKey)
 		keyPassword, _ = flags.GetString(flagKeyPassword)
+		knownHosts, _  = flags.GetString(flagKnownHosts)
 	)
 
 	// Connect to the SSH.
@@ -63,6 +71,7 @@ func executeSSH(cmd *plugin.ExecutedCommand, chain *plugin.ChainInfo) (*ssh.SSH,
 		ssh.WithKey(key),
 		ssh.WithRawKey(rawKey),
 		ssh.WithKeyPassword(keyPassword),
+		ssh.WithKnownHosts(knownHosts),
 		ssh.WithWorkspace(chain.ChainId),
 	)
 	if err != nil {
@@ -77,22 +86,9 @@ func ExecuteSSHStatus(ctx context.Context, cmd *plugin.ExecutedCommand, chain *p
 	session := cliui.New(cliui.StartSpinnerWithText(statusConnecting))
 	defer session.End()
 
-	c, err := executeSSH(cmd, chain)
-	if err != nil {
-		return err
-	}
-	defer c.Close()
-
-	if !c.HasRunnerScript(ctx) {
-		return ErrServerNotInitialized
-	}
-
-	status, err := c.Status(ctx)
-	if err != nil {
-		return err
-	}
-
-	return session.Println(status)
+	return executeHosts(ctx, session, cmd, chain, true, func(ctx context.Context, _ string, c *ssh.SSH) (string, error) {
+		return c.Status(ctx)
+	})
 }
 
 // ExecuteSSHSStop executes the ssh stop subcommand.
@@ -100,22 +96,9 @@ func ExecuteSSHSStop(ctx context.Context, cmd *plugin.ExecutedCommand, chain *pl
 	session := cliui.New(cliui.StartSpinnerWithText(statusConnecting))
 	defer session.End()
 
-	c, err := executeSSH(cmd, chain)
-	if err != nil {
-		return err
-	}
-	defer c.Close()
-
-	if !c.HasRunnerScript(ctx) {
-		return ErrServerNotInitialized
-	}
-
-	stop, err := c.Stop(ctx)
-	if err != nil {
-		return err
-	}
-
-	return session.Println(stop)
+	return executeHosts(ctx, session, cmd, chain, true, func(ctx context.Context, _ string, c *ssh.SSH) (string, error) {
+		return c.Stop(ctx)
+	})
 }
 
 // ExecuteSSHRestart executes the ssh restart subcommand.
@@ -123,22 +106,24 @@ func ExecuteSSHRestart(ctx context.Context, cmd *plugin.ExecutedCommand, chain *
 	session := cliui.New(cliui.StartSpinnerWithText(statusConnecting))
 	defer session.End()
 
-	c, err := executeSSH(cmd, chain)
+	rpcPort, healthTimeout, err := healthGate(cmd)
 	if err != nil {
 		return err
 	}
-	defer c.Close()
 
-	if !c.HasRunnerScript(ctx) {
-		return ErrServerNotInitialized
-	}
-
-	restart, err := c.Restart(ctx)
-	if err != nil {
-		return err
-	}
+	return executeHosts(ctx, session, cmd, chain, true, func(ctx context.Context, _ string, c *ssh.SSH) (string, error) {
+		restart, err := c.Restart(ctx)
+		if err != nil || !isRolling(cmd) {
+			return restart, err
+		}
 
-	return session.Println(restart)
+		// Wait for the node before restarting the next host.
+		status, err := c.WaitHealthy(ctx, rpcPort, healthTimeout)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s\nnode healthy at height %d", restart, status.LatestBlockHeight), nil
+	})
 }
 
 // ExecuteSSHLog executes the ssh log subcommand.
@@ -152,23 +137,36 @@ func ExecuteSSHLog(ctx context.Context, cmd *plugin.ExecutedCommand, chain *plug
 		realTime, _ = flags.GetBool(flagRealTime)
 	)
 
-	c, err := executeSSH(cmd, chain)
+	hosts, err := getHosts(cmd)
 	if err != nil {
 		return err
 	}
-	defer c.Close()
 
-	if !c.HasRunnerScript(ctx) {
-		return ErrServerNotInitialized
+	// Prefix the log lines with the host name if there is more than one host.
+	var mu sync.Mutex
+	printLog := func(host, line string) {
+		mu.Lock()
+		defer mu.Unlock()
+		if len(hosts) > 1 {
+			line = fmt.Sprintf("%s %s", color.Cyan.Sprintf("[%s]", host), line)
+		}
+		_ = session.Print(line)
 	}
 
-	logs, err := c.LatestLog(lines)
-	if err != nil {
-		return err
-	}
-	_ = session.Println(logs)
+	results := runHosts(ctx, cmd, chain, hosts, true, func(ctx context.Context, host string, c *ssh.SSH) (string, error) {
+		logs, err := c.LatestLog(lines)
+		if err != nil {
+			return "", err
+		}
+		session.StopSpinner()
+		for _, line := range strings.Split(logs, "\n") {
+			printLog(host, line+"\n")
+		}
+
+		if !realTime {
+			return "logs fetched", nil
+		}
 
-	if realTime {
 		// Create a buffered channel to receive log lines.
 		logChannel := make(chan string, 100)
 		g, ctx := errgroup.WithContext(ctx)
@@ -183,7 +181,7 @@ func ExecuteSSHLog(ctx context.Context, cmd *plugin.ExecutedCommand, chain *plug
 			for {
 				select {
 				case line := <-logChannel:
-					_ = session.Print(line)
+					printLog(host, line)
 				case <-ctx.Done():
 					return ctx.Err()
 				}
@@ -192,11 +190,19 @@ func ExecuteSSHLog(ctx context.Context, cmd *plugin.ExecutedCommand, chain *plug
 
 		// Wait for all goroutines to complete
 		if err := g.Wait(); err != nil && !errors.Is(err, context.Canceled) {
-			return err
+			return "", err
 		}
+		return "logs followed", nil
+	})
+	session.StopSpinner()
+	if len(results) == 1 {
+		return results[0].Err
 	}
 
-	return nil
+	if err := printResults(session, results); err != nil {
+		return err
+	}
+	return results.Err()
 }
 
 // ExecuteSSHDeploy executes the ssh deploy subcommand.
@@ -217,42 +223,31 @@ func ExecuteSSHDeploy(ctx context.Context, cmd *plugin.ExecutedCommand, chain *p
 	var (
 		initChain, _ = flags.GetBool(flagInitChain)
 
-		localChainHome = filepath.Join(localDir, "home")
 		localBinOutput = filepath.Join(localDir, "bin")
 	)
 
-	c, err := executeSSH(cmd, chain)
+	hosts, err := getHosts(cmd)
 	if err != nil {
 		return err
 	}
-	defer c.Close()
-
-	_ = session.Println(color.Yellow.Sprintf("Building chain binary using Ignite:"))
 
-	target, err := c.Target(ctx)
+	rpcPort, healthTimeout, err := healthGate(cmd)
 	if err != nil {
 		return err
 	}
-
-	// We are using the ignite chain build command to build the app.
-	igniteChainBuildCmd := ignitecmd.NewChainBuild()
-	igniteChainBuildCmd.SetArgs([]string{
-		"-p",
-		chain.AppPath,
-		"-o",
-		localBinOutput,
-		"--release",
-		"--release.targets",
-		target,
-		"-v",
-	})
-	if err := igniteChainBuildCmd.ExecuteContext(ctx); err != nil {
-		return err
+	rolling := isRolling(cmd)
+	// The rollback only restores the binary, so a reinitialized home would be kept.
+	if rolling && initChain {
+		return errors.Errorf("the --%s flag cannot be used with --%s, the rollback only restores the chain binary", flagInitChain, flagRolling)
 	}
 
 	// Define a progress callback function.
+	// The progress bar is only shown for a single host to avoid mixing the uploads.
 	bar := progressbar.DefaultBytes(1, "uploading")
 	progressCallback := func(bytesUploaded int64, totalBytes int64) error {
+		if len(hosts) > 1 {
+			return nil
+		}
 		if bar.GetMax64() != totalBytes {
 			bar.ChangeMax64(totalBytes)
 			bar.Reset()
@@ -267,66 +262,187 @@ func ExecuteSSHDeploy(ctx context.Context, cmd *plugin.ExecutedCommand, chain *p
 		}
 		return nil
 	}
+	describe := func(description string) {
+		if len(hosts) == 1 {
+			bar.Describe(description)
+		}
+	}
 
-	// Extract and upload the built binary.
 	var (
-		binName           = fmt.Sprintf("%sd", chain.ChainId)
-		localChainTarball = fmt.Sprintf(
-			"%s/%s_%s.tar.gz",
-			localBinOutput,
-			chain.ChainId,
-			strings.ReplaceAll(target, ":", "_"),
-		)
+		// mu serializes the local Ignite commands and the output between hosts.
+		mu       sync.Mutex
+		binaries = make(map[string]string)
 	)
-	extracted, err := tarball.Extract(ctx, localChainTarball, localBinOutput, binName)
-	if err != nil {
-		return err
-	}
-	if len(extracted) == 0 {
-		return errors.Errorf("zero files extracted from the tarball %s", localChainTarball)
+	printHost := func(host, message string) {
+		if len(hosts) > 1 {
+			message = fmt.Sprintf("%s %s", color.Cyan.Sprintf("[%s]", host), message)
+		}
+		_ = session.Println(message)
 	}
 
-	bar.Describe("Uploading chain binary")
-	binPath, err := c.UploadBinary(extracted[0], progressCallback)
-	if err != nil {
-		return err
+	// buildBinary builds the chain binary for the target only once and returns its path.
+	buildBinary := func(ctx context.Context, host, target string) (string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if binary, ok := binaries[target]; ok {
+			return binary, nil
+		}
+
+		session.StopSpinner()
+		printHost(host, color.Yellow.Sprintf("Building chain binary using Ignite:"))
+
+		// We are using the ignite chain build command to build the app.
+		igniteChainBuildCmd := ignitecmd.NewChainBuild()
+		igniteChainBuildCmd.SetArgs([]string{
+			"-p",
+			chain.AppPath,
+			"-o",
+			localBinOutput,
+			"--release",
+			"--release.targets",
+			target,
+			"-v",
+		})
+		if err := igniteChainBuildCmd.ExecuteContext(ctx); err != nil {
+			return "", err
+		}
+
+		// Extract the built binary.
+		var (
+			targetName        = strings.ReplaceAll(target, ":", "_")
+			binName           = fmt.Sprintf("%sd", chain.ChainId)
+			localTargetOutput = filepath.Join(localBinOutput, targetName)
+			localChainTarball = fmt.Sprintf(
+				"%s/%s_%s.tar.gz",
+				localBinOutput,
+				chain.ChainId,
+				targetName,
+			)
+		)
+		if err := os.MkdirAll(localTargetOutput, 0o755); err != nil {
+			return "", err
+		}
+		extracted, err := tarball.Extract(ctx, localChainTarball, localTargetOutput, binName)
+		if err != nil {
+			return "", err
+		}
+		if len(extracted) == 0 {
+			return "", errors.Errorf("zero files extracted from the tarball %s", localChainTarball)
+		}
+		binaries[target] = extracted[0]
+		return extracted[0], nil
 	}
-	_ = session.Println(color.Yellow.Sprintf("Chain binary uploaded to '%s'\n", binPath))
 
-	home := c.Home()
-	if initChain || !c.HasGenesis(ctx) {
-		_ = session.Println(color.Yellow.Sprintf("Initializing the chain home folder using Ignite:"))
+	// initHome initializes the chain home folder into the local host directory.
+	initHome := func(ctx context.Context, host, localChainHome string) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		session.StopSpinner()
+		printHost(host, color.Yellow.Sprintf("Initializing the chain home folder using Ignite:"))
 
 		igniteChainInitCmd := ignitecmd.NewChainInit()
 		igniteChainInitCmd.SetArgs([]string{"-p", chain.AppPath, "--home", localChainHome}) // TODO add verbose flag after merge and backport this one https://github.com/ignite/cli/pull/4286
-		if err := igniteChainInitCmd.ExecuteContext(ctx); err != nil {
-			return err
+		return igniteChainInitCmd.ExecuteContext(ctx)
+	}
+
+	return executeHosts(ctx, session, cmd, chain, false, func(ctx context.Context, host string, c *ssh.SSH) (_ string, err error) {
+		localHostDir, err := os.MkdirTemp(localDir, "host")
+		if err != nil {
+			return "", err
 		}
+		localChainHome := filepath.Join(localHostDir, "home")
 
-		bar.Describe("Uploading chain home folder")
-		homeFiles, err := c.UploadHome(ctx, localChainHome, progressCallback)
+		target, err := c.Target(ctx)
 		if err != nil {
-			return err
+			return "", err
 		}
-		_ = session.Println(color.Yellow.Sprintf("Uploaded files: \n- %s\n", strings.Join(homeFiles, "\n- ")))
-	}
 
-	// Create the runner script.
-	localRunScriptPath, err := script.NewRunScript(c.Workspace(), c.Log(), home, binPath, localDir)
-	if err != nil {
-		return err
-	}
+		binary, err := buildBinary(ctx, host, target)
+		if err != nil {
+			return "", err
+		}
 
-	bar.Describe("Uploading runner script")
-	if _, err := c.UploadRunnerScript(localRunScriptPath, progressCallback); err != nil {
-		return err
-	}
+		// Keep the current binary to roll back if the deploy fails or the node doesn't become healthy.
+		var (
+			binName   = filepath.Base(binary)
+			hasBackup bool
+			restarted bool
+		)
+		if rolling {
+			if hasBackup, err = c.BackupBinary(binName); err != nil {
+				return "", err
+			}
+			defer func() {
+				if err == nil || !hasBackup {
+					return
+				}
+				printHost(host, color.Red.Sprintf("Deploy failed, rolling back to the previous binary"))
+				if rollbackErr := c.RestoreBinary(binName); rollbackErr != nil {
+					err = errors.Wrapf(err, "rollback failed: %s", rollbackErr)
+					return
+				}
+				if restarted {
+					if _, rollbackErr := c.Restart(ctx); rollbackErr != nil {
+						err = errors.Wrapf(err, "rollback failed: %s", rollbackErr)
+						return
+					}
+				}
+				err = errors.Wrap(err, "deploy aborted and rolled back to the previous binary")
+			}()
+		}
 
-	start, err := c.Start(ctx)
-	if err != nil {
-		return err
-	}
-	_ = session.Println("")
+		// Upload the built binary.
+		describe("Uploading chain binary")
+		binPath, err := c.UploadBinary(ctx, binary, progressCallback)
+		if err != nil {
+			return "", err
+		}
+		printHost(host, color.Yellow.Sprintf("Chain binary uploaded to '%s'\n", binPath))
+
+		home := c.Home()
+		if initChain || !c.HasGenesis(ctx) {
+			if err := initHome(ctx, host, localChainHome); err != nil {
+				return "", err
+			}
 
-	return session.Println(color.Blue.Sprintf(start))
+			describe("Uploading chain home folder")
+			homeFiles, err := c.UploadHome(ctx, localChainHome, progressCallback)
+			if err != nil {
+				return "", err
+			}
+			printHost(host, color.Yellow.Sprintf("Uploaded files: \n- %s\n", strings.Join(homeFiles, "\n- ")))
+		}
+
+		// Create the runner script.
+		localRunScriptPath, err := script.NewRunScript(c.Workspace(), c.Log(), home, binPath, localHostDir)
+		if err != nil {
+			return "", err
+		}
+
+		describe("Uploading runner script")
+		if _, err := c.UploadRunnerScript(ctx, localRunScriptPath, progressCallback); err != nil {
+			return "", err
+		}
+
+		if !rolling {
+			return c.Start(ctx)
+		}
+
+		// Restart the node with the new binary and wait for it before deploying the next host.
+		restarted = true
+		start, err := c.Restart(ctx)
+		if err != nil {
+			return "", err
+		}
+		status, err := c.WaitHealthy(ctx, rpcPort, healthTimeout)
+		if err != nil && !hasBackup {
+			_, _ = c.Stop(ctx)
+			return "", errors.Wrap(err, "deploy aborted and node stopped, no previous binary to roll back")
+		} else if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s\nnode healthy at height %d", start, status.LatestBlockHeight), nil
+	})
 }
@@ -35,6 +35,8 @@ func (app) Execute(ctx context.Context, c *plugin.ExecutedCommand, api plugin.Cl
 		return cmd.ExecuteSSHLog(ctx, c, chainInfo)
 	case "status":
 		return cmd.ExecuteSSHStatus(ctx, c, chainInfo)
+	case "metrics":
+		return cmd.ExecuteSSHMetrics(ctx, c, chainInfo)
 	case "restart":
 		return cmd.ExecuteSSHRestart(ctx, c, chainInfo)
 	case "stop":
@@ -0,0 +1,170 @@
+// Package fleet provides helpers to run operations against a group of hosts
+// concurrently and collect the result of each one.
+package fleet
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/ignite/cli/v28/ignite/pkg/errors"
+	"golang.org/x/sync/errgroup"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultParallel is the default number of hosts processed at the same time.
+const DefaultParallel = 5
+
+// ErrSkipped indicates that the host was skipped because a previous host failed.
+var ErrSkipped = errors.New("skipped")
+
+type (
+	// Groups represents the host groups file, mapping the group name to the host URIs.
+	Groups struct {
+		Groups map[string][]string `yaml:"groups"`
+	}
+
+	// Result represents the result of an operation against a host.
+	Result struct {
+		Host   string
+		Output string
+		Err    error
+	}
+
+	// Results represents a list of host results.
+	Results []Result
+
+	// Operation represents an operation executed against a single host.
+	Operation func(ctx context.Context, host string) (string, error)
+)
+
+// DefaultGroupsPath returns the default host groups file path.
+func DefaultGroupsPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".ignite", "spaceship", "hosts.yml"), nil
+}
+
+// LoadGroups loads the host groups file.
+func LoadGroups(path string) (Groups, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Groups{}, errors.Wrapf(err, "failed to read the host groups file %s", path)
+	}
+	var groups Groups
+	if err := yaml.Unmarshal(data, &groups); err != nil {
+		return Groups{}, errors.Wrapf(err, "failed to parse the host groups file %s", path)
+	}
+	return groups, nil
+}
+
+// Hosts returns the hosts of a group.
+func (g Groups) Hosts(name string) ([]string, error) {
+	hosts, ok := g.Groups[name]
+	if !ok {
+		return nil, errors.Errorf("host group %s not found", name)
+	}
+	if len(hosts) == 0 {
+		return nil, errors.Errorf("host group %s is empty", name)
+	}
+	return hosts, nil
+}
+
+// Failed returns the failed host results.
+func (r Results) Failed() Results {
+	failed := make(Results, 0)
+	for _, result := range r {
+		if result.Err != nil {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// Err returns an error if any host failed or was skipped.
+func (r Results) Err() error {
+	if failed := r.Failed(); len(failed) > 0 {
+		return errors.Errorf("%d of %d hosts failed", len(failed), len(r))
+	}
+	return nil
+}
+
+// Unique returns the hosts without duplicates, keeping the original order.
+func Unique(hosts ...string) []string {
+	var (
+		seen   = make(map[string]struct{})
+		result = make([]string, 0, len(hosts))
+	)
+	for _, host := range hosts {
+		if _, ok := seen[host]; ok {
+			continue
+		}
+		seen[host] = struct{}{}
+		result = append(result, host)
+	}
+	return result
+}
+
+// Run runs the operation against all hosts concurrently, limited by the parallel number,
+// and returns the result of each host sorted in the same order as the hosts.
+// A failed host does not cancel the operations running in the other hosts.
+func Run(ctx context.Context, hosts []string, parallel int, op Operation) Results {
+	if parallel <= 0 {
+		parallel = DefaultParallel
+	}
+
+	var (
+		mu      sync.Mutex
+		results = make(Results, 0, len(hosts))
+		index   = make(map[string]int)
+		grp     errgroup.Group
+	)
+	grp.SetLimit(parallel)
+	for i, host := range hosts {
+		index[host] = i
+		grp.Go(func() error {
+			output, err := op(ctx, host)
+			mu.Lock()
+			defer mu.Unlock()
+			results = append(results, Result{
+				Host:   host,
+				Output: output,
+				Err:    err,
+			})
+			return nil
+		})
+	}
+	_ = grp.Wait()
+
+	sort.Slice(results, func(i, j int) bool {
+		return index[results[i].Host] < index[results[j].Host]
+	})
+	return results
+}
+
+// RunRolling runs the operation against the hosts one by one, in the hosts order.
+// If a host fails, the remaining hosts are not processed and are marked as skipped.
+func RunRolling(ctx context.Context, hosts []string, op Operation) Results {
+	results := make(Results, 0, len(hosts))
+	for _, host := range hosts {
+		if failed := results.Failed(); len(failed) > 0 {
+			results = append(results, Result{
+				Host: host,
+				Err:  errors.Wrapf(ErrSkipped, "host %s failed", failed[0].Host),
+			})
+			continue
+		}
+
+		output, err := op(ctx, host)
+		results = append(results, Result{
+			Host:   host,
+			Output: output,
+			Err:    err,
+		})
+	}
+	return results
+}
@@ -0,0 +1,94 @@
+package fleet
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ignite/cli/v28/ignite/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	var (
+		hosts   = []string{"host-1", "host-2", "host-3", "host-4"}
+		running atomic.Int32
+		maxRun  atomic.Int32
+	)
+	results := Run(context.Background(), hosts, 2, func(_ context.Context, host string) (string, error) {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			current := maxRun.Load()
+			if n <= current || maxRun.CompareAndSwap(current, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		if host == "host-2" {
+			return "", errors.New("connection refused")
+		}
+		return host + " running", nil
+	})
+
+	require.LessOrEqual(t, maxRun.Load(), int32(2))
+	require.Len(t, results, len(hosts))
+	for i, result := range results {
+		require.Equal(t, hosts[i], result.Host)
+	}
+	require.Len(t, results.Failed(), 1)
+	require.Equal(t, "host-2", results.Failed()[0].Host)
+	require.EqualError(t, results.Err(), "1 of 4 hosts failed")
+	require.Equal(t, "host-3 running", results[2].Output)
+}
+
+func TestRunRolling(t *testing.T) {
+	var (
+		hosts    = []string{"host-1", "host-2", "host-3"}
+		executed = make([]string, 0)
+	)
+	results := RunRolling(context.Background(), hosts, func(_ context.Context, host string) (string, error) {
+		executed = append(executed, host)
+		if host == "host-2" {
+			return "", errors.New("node unhealthy")
+		}
+		return host + " healthy", nil
+	})
+
+	require.Equal(t, []string{"host-1", "host-2"}, executed)
+	require.Len(t, results, len(hosts))
+	require.NoError(t, results[0].Err)
+	require.EqualError(t, results[1].Err, "node unhealthy")
+	require.ErrorIs(t, results[2].Err, ErrSkipped)
+	require.EqualError(t, results.Err(), "2 of 3 hosts failed")
+}
+
+func TestLoadGroups(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hosts.yml")
+	require.NoError(t, os.WriteFile(path, []byte(`groups:
+  testnet:
+    - root@10.0.0.1
+    - validator@10.0.0.2:2222
+  empty: []
+`), 0o644))
+
+	groups, err := LoadGroups(path)
+	require.NoError(t, err)
+
+	hosts, err := groups.Hosts("testnet")
+	require.NoError(t, err)
+	require.Equal(t, []string{"root@10.0.0.1", "validator@10.0.0.2:2222"}, hosts)
+
+	_, err = groups.Hosts("empty")
+	require.EqualError(t, err, "host group empty is empty")
+
+	_, err = groups.Hosts("mainnet")
+	require.EqualError(t, err, "host group mainnet not found")
+}
+
+func TestUnique(t *testing.T) {
+	require.Equal(t, []string{"a", "b", "c"}, Unique("a", "b", "a", "c", "b"))
+}
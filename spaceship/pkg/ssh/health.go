@@ -0,0 +1,94 @@
+package ssh
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ignite/cli/v28/ignite/pkg/errors"
+)
+
+const (
+	// DefaultRPCPort is the default port of the node RPC endpoint.
+	DefaultRPCPort = "26657"
+
+	// healthCheckInterval is the interval between the node health checks.
+	healthCheckInterval = 2 * time.Second
+)
+
+// ErrNodeUnhealthy indicates that the node did not become healthy in time.
+var ErrNodeUnhealthy = errors.New("node unhealthy")
+
+// NodeStatus represents the node sync status from the RPC status endpoint.
+type NodeStatus struct {
+	LatestBlockHeight int64
+	CatchingUp        bool
+}
+
+// NodeStatus queries the node RPC status endpoint through the SSH tunnel.
+func (s *SSH) NodeStatus(ctx context.Context, port string) (NodeStatus, error) {
+	res, err := s.tunnelGet(ctx, port, "/status")
+	if err != nil {
+		return NodeStatus{}, errors.Wrapf(err, "failed to query the node status on port %s", port)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return NodeStatus{}, errors.Errorf("node status returned status %s", res.Status)
+	}
+
+	var out struct {
+		Result struct {
+			SyncInfo struct {
+				LatestBlockHeight string `json:"latest_block_height"`
+				CatchingUp        bool   `json:"catching_up"`
+			} `json:"sync_info"`
+		} `json:"result"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&out); err != nil {
+		return NodeStatus{}, errors.Wrap(err, "failed to decode the node status")
+	}
+
+	height, err := strconv.ParseInt(out.Result.SyncInfo.LatestBlockHeight, 10, 64)
+	if err != nil {
+		return NodeStatus{}, errors.Wrapf(err, "invalid node height %s", out.Result.SyncInfo.LatestBlockHeight)
+	}
+	return NodeStatus{
+		LatestBlockHeight: height,
+		CatchingUp:        out.Result.SyncInfo.CatchingUp,
+	}, nil
+}
+
+// WaitHealthy waits until the node RPC is back and the node is no longer catching up.
+// It returns ErrNodeUnhealthy with the last status error if the timeout is reached.
+func (s *SSH) WaitHealthy(ctx context.Context, port string, timeout time.Duration) (NodeStatus, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(healthCheckInterval)
+	defer ticker.Stop()
+
+	lastErr := errors.New("node never responded")
+	for {
+		status, err := s.NodeStatus(ctx, port)
+		switch {
+		case err != nil:
+			lastErr = err
+		case status.CatchingUp:
+			lastErr = errors.Errorf("node still catching up at height %d", status.LatestBlockHeight)
+		default:
+			return status, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return NodeStatus{}, errors.Errorf("%w after %s: %s", ErrNodeUnhealthy, timeout, lastErr)
+			}
+			return NodeStatus{}, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,356 @@
+package ssh
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ignite/cli/v28/ignite/pkg/errors"
+)
+
+const (
+	// DefaultPrometheusPort is the default port of the node Prometheus endpoint.
+	DefaultPrometheusPort = "26660"
+
+	// diskSectorSize is the sector size used by the kernel to report the disk stats.
+	diskSectorSize = 512
+)
+
+type (
+	// HostMetrics represents a resource usage sample of the remote host.
+	HostMetrics struct {
+		CPUPercent       float64 `json:"cpu_percent"`
+		MemoryTotal      uint64  `json:"memory_total"`
+		MemoryUsed       uint64  `json:"memory_used"`
+		DiskReadBytes    uint64  `json:"disk_read_bytes"`
+		DiskWrittenBytes uint64  `json:"disk_written_bytes"`
+		DiskReadRate     float64 `json:"disk_read_rate"`
+		DiskWriteRate    float64 `json:"disk_write_rate"`
+	}
+
+	// NodeMetrics represents the node Prometheus metrics indexed by the series name.
+	NodeMetrics map[string]float64
+
+	// hostStat represents the raw host counters read from the proc filesystem.
+	hostStat struct {
+		cpuIdle        uint64
+		cpuTotal       uint64
+		sectorsRead    uint64
+		sectorsWritten uint64
+	}
+)
+
+// Get returns the value of a series matching the metric name suffix,
+// ignoring the namespace and the labels (e.g.: "consensus_height").
+// If many series match, the first one sorted by name and labels is returned.
+func (m NodeMetrics) Get(name string) (float64, bool) {
+	matches := make([]string, 0)
+	for series := range m {
+		metric := series
+		if i := strings.Index(metric, "{"); i >= 0 {
+			metric = metric[:i]
+		}
+		if metric == name || strings.HasSuffix(metric, "_"+name) {
+			matches = append(matches, series)
+		}
+	}
+	if len(matches) == 0 {
+		return 0, false
+	}
+	sort.Strings(matches)
+	return m[matches[0]], true
+}
+
+// EnablePrometheus enables the Prometheus endpoint into the remote chain config.toml
+// and returns true if the file was changed and the chain needs to be restarted.
+func (s *SSH) EnablePrometheus(port string) (bool, error) {
+	file, err := s.sftpClient.Open(s.Config())
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to open the chain config %s", s.Config())
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		return false, err
+	}
+
+	updated, changed := enablePrometheus(content, port)
+	if !changed {
+		return false, nil
+	}
+
+	dstFile, err := s.sftpClient.OpenFile(s.Config(), os.O_WRONLY|os.O_TRUNC)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to write the chain config %s", s.Config())
+	}
+	defer dstFile.Close()
+
+	if _, err := dstFile.Write(updated); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// HostMetrics samples the remote host resource usage during the interval.
+func (s *SSH) HostMetrics(ctx context.Context, interval time.Duration) (HostMetrics, error) {
+	devices, err := s.RunCommand(ctx, "ls", "/sys/block")
+	if err != nil {
+		return HostMetrics{}, err
+	}
+	disks := strings.Fields(devices)
+
+	first, err := s.hostStat(ctx, disks)
+	if err != nil {
+		return HostMetrics{}, err
+	}
+
+	select {
+	case <-ctx.Done():
+		return HostMetrics{}, ctx.Err()
+	case <-time.After(interval):
+	}
+
+	last, err := s.hostStat(ctx, disks)
+	if err != nil {
+		return HostMetrics{}, err
+	}
+
+	meminfo, err := s.RunCommand(ctx, "cat", "/proc/meminfo")
+	if err != nil {
+		return HostMetrics{}, err
+	}
+	total, available := parseMeminfo(meminfo)
+
+	var (
+		seconds = interval.Seconds()
+		metrics = HostMetrics{
+			MemoryTotal:      total,
+			MemoryUsed:       total - available,
+			DiskReadBytes:    last.sectorsRead * diskSectorSize,
+			DiskWrittenBytes: last.sectorsWritten * diskSectorSize,
+		}
+	)
+	if cpuTotal := last.cpuTotal - first.cpuTotal; cpuTotal > 0 {
+		cpuIdle := last.cpuIdle - first.cpuIdle
+		metrics.CPUPercent = float64(cpuTotal-cpuIdle) / float64(cpuTotal) * 100
+	}
+	if seconds > 0 {
+		metrics.DiskReadRate = float64((last.sectorsRead-first.sectorsRead)*diskSectorSize) / seconds
+		metrics.DiskWriteRate = float64((last.sectorsWritten-first.sectorsWritten)*diskSectorSize) / seconds
+	}
+	return metrics, nil
+}
+
+// NodeMetrics scrapes the node Prometheus endpoint through the SSH tunnel.
+func (s *SSH) NodeMetrics(ctx context.Context, port string) (NodeMetrics, error) {
+	res, err := s.tunnelGet(ctx, port, "/metrics")
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to scrape the node metrics on port %s", port)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("node metrics returned status %s", res.Status)
+	}
+	return parsePrometheus(res.Body)
+}
+
+// hostStat reads the cpu and disk counters from the remote proc filesystem.
+func (s *SSH) hostStat(ctx context.Context, disks []string) (hostStat, error) {
+	stat, err := s.RunCommand(ctx, "cat", "/proc/stat")
+	if err != nil {
+		return hostStat{}, err
+	}
+	diskstats, err := s.RunCommand(ctx, "cat", "/proc/diskstats")
+	if err != nil {
+		return hostStat{}, err
+	}
+
+	result := hostStat{}
+	result.cpuIdle, result.cpuTotal = parseCPUStat(stat)
+	result.sectorsRead, result.sectorsWritten = parseDiskStats(diskstats, disks)
+	return result, nil
+}
+
+// enablePrometheus sets the prometheus flag and listen address into the
+// instrumentation section of a CometBFT config.toml content, adding the missing
+// keys and the section itself if the config does not have them.
+func enablePrometheus(content []byte, port string) ([]byte, bool) {
+	var (
+		lines    = strings.Split(string(content), "\n")
+		settings = []struct{ key, line string }{
+			{key: "prometheus", line: "prometheus = true"},
+			{key: "prometheus_listen_addr", line: fmt.Sprintf("prometheus_listen_addr = \":%s\"", port)},
+		}
+		found   = make(map[string]bool)
+		section string
+		header  = -1
+		changed bool
+	)
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "[") {
+			section = strings.Trim(trimmed, "[]")
+			if section == "instrumentation" {
+				header = i
+			}
+			continue
+		}
+		if section != "instrumentation" {
+			continue
+		}
+
+		key, _, ok := strings.Cut(trimmed, "=")
+		if !ok {
+			continue
+		}
+		for _, setting := range settings {
+			if strings.TrimSpace(key) != setting.key {
+				continue
+			}
+			found[setting.key] = true
+			if trimmed != setting.line {
+				lines[i] = setting.line
+				changed = true
+			}
+		}
+	}
+
+	missing := make([]string, 0, len(settings))
+	for _, setting := range settings {
+		if !found[setting.key] {
+			missing = append(missing, setting.line)
+		}
+	}
+	if len(missing) == 0 {
+		return []byte(strings.Join(lines, "\n")), changed
+	}
+
+	if header >= 0 {
+		// add the missing keys at the top of the section.
+		lines = slices.Insert(lines, header+1, missing...)
+	} else {
+		// add the section at the end, before the trailing newline.
+		end := len(lines)
+		if end > 0 && lines[end-1] == "" {
+			end--
+		}
+		section := append([]string{"", "[instrumentation]"}, missing...)
+		if end == 0 {
+			section = section[1:]
+		}
+		lines = slices.Insert(lines, end, section...)
+	}
+	return []byte(strings.Join(lines, "\n")), true
+}
+
+// parseCPUStat returns the idle and total cpu time from the /proc/stat content.
+func parseCPUStat(stat string) (idle, total uint64) {
+	for _, line := range strings.Split(stat, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 5 || fields[0] != "cpu" {
+			continue
+		}
+		for i, field := range fields[1:] {
+			v, err := strconv.ParseUint(field, 10, 64)
+			if err != nil {
+				continue
+			}
+			total += v
+			// idle and iowait columns.
+			if i == 3 || i == 4 {
+				idle += v
+			}
+		}
+		break
+	}
+	return idle, total
+}
+
+// parseMeminfo returns the total and available memory in bytes from the /proc/meminfo content.
+func parseMeminfo(meminfo string) (total, available uint64) {
+	for _, line := range strings.Split(meminfo, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		v, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		switch fields[0] {
+		case "MemTotal:":
+			total = v * 1024
+		case "MemAvailable:":
+			available = v * 1024
+		}
+	}
+	return total, available
+}
+
+// parseDiskStats returns the total sectors read and written by the disks from the /proc/diskstats content.
+func parseDiskStats(diskstats string, disks []string) (read, written uint64) {
+	isDisk := make(map[string]bool)
+	for _, disk := range disks {
+		if !strings.HasPrefix(disk, "loop") && !strings.HasPrefix(disk, "ram") {
+			isDisk[disk] = true
+		}
+	}
+	for _, line := range strings.Split(diskstats, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 10 || !isDisk[fields[2]] {
+			continue
+		}
+		if v, err := strconv.ParseUint(fields[5], 10, 64); err == nil {
+			read += v
+		}
+		if v, err := strconv.ParseUint(fields[9], 10, 64); err == nil {
+			written += v
+		}
+	}
+	return read, written
+}
+
+// parsePrometheus parses the Prometheus text exposition format into node metrics.
+func parsePrometheus(r io.Reader) (NodeMetrics, error) {
+	var (
+		metrics = make(NodeMetrics)
+		scanner = bufio.NewScanner(r)
+	)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), 1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 || line[0] == '#' {
+			continue
+		}
+
+		// the labels can contain spaces, so the series ends at the closing brace.
+		var series, rest string
+		if i := bytes.LastIndexByte(line, '}'); i >= 0 {
+			series, rest = string(line[:i+1]), string(line[i+1:])
+		} else {
+			series, rest, _ = strings.Cut(string(line), " ")
+		}
+
+		fields := strings.Fields(rest)
+		if len(fields) == 0 {
+			continue
+		}
+		value, err := strconv.ParseFloat(fields[0], 64)
+		if err != nil {
+			continue
+		}
+		metrics[series] = value
+	}
+	return metrics, scanner.Err()
+}
@@ -0,0 +1,177 @@
+package ssh
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnablePrometheus(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		port    string
+		want    string
+		changed bool
+	}{
+		{
+			name: "disabled prometheus",
+			content: `[p2p]
+laddr = "tcp://0.0.0.0:26656"
+
+[instrumentation]
+# When true, Prometheus metrics are served under /metrics on
+prometheus = false
+prometheus_listen_addr = ":26660"
+namespace = "cometbft"`,
+			port: "26660",
+			want: `[p2p]
+laddr = "tcp://0.0.0.0:26656"
+
+[instrumentation]
+# When true, Prometheus metrics are served under /metrics on
+prometheus = true
+prometheus_listen_addr = ":26660"
+namespace = "cometbft"`,
+			changed: true,
+		},
+		{
+			name: "custom port",
+			content: `[instrumentation]
+prometheus = true
+prometheus_listen_addr = ":26660"`,
+			port: "9090",
+			want: `[instrumentation]
+prometheus = true
+prometheus_listen_addr = ":9090"`,
+			changed: true,
+		},
+		{
+			name: "already enabled",
+			content: `[instrumentation]
+prometheus = true
+prometheus_listen_addr = ":26660"`,
+			port: "26660",
+			want: `[instrumentation]
+prometheus = true
+prometheus_listen_addr = ":26660"`,
+		},
+		{
+			name: "missing keys",
+			content: `[instrumentation]
+namespace = "cometbft"
+
+[statesync]
+enable = false
+`,
+			port: "26660",
+			want: `[instrumentation]
+prometheus = true
+prometheus_listen_addr = ":26660"
+namespace = "cometbft"
+
+[statesync]
+enable = false
+`,
+			changed: true,
+		},
+		{
+			name: "missing listen address",
+			content: `[instrumentation]
+prometheus = true`,
+			port: "26660",
+			want: `[instrumentation]
+prometheus_listen_addr = ":26660"
+prometheus = true`,
+			changed: true,
+		},
+		{
+			name: "missing section",
+			content: `[rpc]
+prometheus = false
+`,
+			port: "26660",
+			want: `[rpc]
+prometheus = false
+
+[instrumentation]
+prometheus = true
+prometheus_listen_addr = ":26660"
+`,
+			changed: true,
+		},
+		{
+			name:    "empty config",
+			content: ``,
+			port:    "26660",
+			want: `[instrumentation]
+prometheus = true
+prometheus_listen_addr = ":26660"
+`,
+			changed: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, changed := enablePrometheus([]byte(tt.content), tt.port)
+			require.Equal(t, tt.changed, changed)
+			require.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestParsePrometheus(t *testing.T) {
+	metrics, err := parsePrometheus(strings.NewReader(`# HELP cometbft_consensus_height Height of the chain.
+# TYPE cometbft_consensus_height gauge
+cometbft_consensus_height{chain_id="mars"} 42
+cometbft_p2p_peers{chain_id="mars",moniker="my node"} 3
+go_goroutines 118 1700000000
+invalid_value abc
+`))
+	require.NoError(t, err)
+	require.Equal(t, NodeMetrics{
+		`cometbft_consensus_height{chain_id="mars"}`:            42,
+		`cometbft_p2p_peers{chain_id="mars",moniker="my node"}`: 3,
+		"go_goroutines": 118,
+	}, metrics)
+
+	height, ok := metrics.Get("consensus_height")
+	require.True(t, ok)
+	require.EqualValues(t, 42, height)
+
+	_, ok = metrics.Get("mempool_size")
+	require.False(t, ok)
+
+	// the first series sorted by labels is returned when many series match.
+	metrics = NodeMetrics{
+		`cometbft_mempool_size{chain_id="mars",node="b"}`: 2,
+		`cometbft_mempool_size{chain_id="mars",node="a"}`: 1,
+		`cometbft_mempool_size{chain_id="mars",node="c"}`: 3,
+	}
+	for i := 0; i < 10; i++ {
+		size, ok := metrics.Get("mempool_size")
+		require.True(t, ok)
+		require.EqualValues(t, 1, size)
+	}
+}
+
+func TestParseHostStats(t *testing.T) {
+	idle, total := parseCPUStat(`cpu  100 0 50 800 50 0 0 0 0 0
+cpu0 50 0 25 400 25 0 0 0 0 0
+intr 1234`)
+	require.EqualValues(t, 850, idle)
+	require.EqualValues(t, 1000, total)
+
+	memTotal, memAvailable := parseMeminfo(`MemTotal:        2048 kB
+MemFree:          512 kB
+MemAvailable:    1024 kB`)
+	require.EqualValues(t, 2048*1024, memTotal)
+	require.EqualValues(t, 1024*1024, memAvailable)
+
+	read, written := parseDiskStats(`   7       0 loop0 10 0 100 0 0 0 0 0 0 0 0
+   8       0 sda 10 0 200 0 5 0 300 0 0 0 0
+   8       1 sda1 10 0 200 0 5 0 300 0 0 0 0`, []string{"loop0", "sda"})
+	require.EqualValues(t, 200, read)
+	require.EqualValues(t, 300, written)
+}
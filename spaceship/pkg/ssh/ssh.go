@@ -7,11 +7,15 @@ package ssh
 import (
 	"context"
 	"fmt"
+	"net"
+	"net/http"
 	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/ignite/cli/v28/ignite/pkg/errors"
 	"github.com/ignite/cli/v28/ignite/pkg/gocmd"
@@ -19,13 +23,20 @@ import (
 	"github.com/manifoldco/promptui"
 	"github.com/melbahja/goph"
 	"github.com/pkg/sftp"
+	gossh "golang.org/x/crypto/ssh"
 	"golang.org/x/crypto/ssh/knownhosts"
 )
 
 const (
 	workdir = "spaceship"
+
+	defaultUser = "root"
+	defaultPort = "22"
 )
 
+// promptMu serializes the unknown host prompts between concurrent connections.
+var promptMu sync.Mutex
+
 // SSH represents the SSH configuration and clients for connecting and interacting
 // with remote servers via SSH.
 type SSH struct {
@@ -36,9 +47,11 @@ type SSH struct {
 	rawKey      string
 	key         string
 	keyPassword string
+	knownHosts  string
 	workspace   string
 	client      *goph.Client
 	sftpClient  *sftp.Client
+	httpClient  *http.Client
 }
 
 // Option configures SSH settings.
@@ -110,6 +123,14 @@ func WithKeyPassword(keyPassword string) Option {
 	}
 }
 
+// WithKnownHosts sets the known_hosts file used to verify the host key.
+func WithKnownHosts(knownHosts string) Option {
+	return func(o *SSH) error {
+		o.knownHosts = strings.TrimSpace(knownHosts)
+		return nil
+	}
+}
+
 // WithWorkspace sets the SSH workspace.
 func WithWorkspace(workspace string) Option {
 	return func(o *SSH) error {
@@ -136,6 +157,12 @@ func New(host string, options ...Option) (*SSH, error) {
 			return nil, err
 		}
 	}
+	if s.username == "" {
+		s.username = defaultUser
+	}
+	if s.port == "" {
+		s.port = defaultPort
+	}
 	return s, s.validate()
 }
 
@@ -151,22 +178,18 @@ func parseURI(uri string) (host string, port string, username string, password s
 	}
 	host = parsedURL.Hostname()
 	port = parsedURL.Port()
-	if port == "" {
-		port = "22"
-	}
-
 	if parsedURL.User != nil {
 		username = parsedURL.User.Username()
 		password, _ = parsedURL.User.Password()
 	}
-	if username == "" {
-		username = "root"
-	}
 	return host, port, username, password, nil
 }
 
 // Close closes the SSH and SFTP clients.
 func (s *SSH) Close() error {
+	if s.httpClient != nil {
+		s.httpClient.CloseIdleConnections()
+	}
 	if err := s.sftpClient.Close(); err != nil {
 		return err
 	}
@@ -180,8 +203,16 @@ func (s *SSH) Connect() error {
 		return err
 	}
 
-	s.client, err = goph.New(s.username, s.host, auth)
+	callback, err := s.hostKeyCallback()
+	if err != nil {
+		return err
+	}
+
+	s.client, err = s.dial(auth, callback)
 	if errors.Is(err, &knownhosts.KeyError{}) {
+		promptMu.Lock()
+		defer promptMu.Unlock()
+
 		prompt := promptui.Prompt{
 			Label:     fmt.Sprintf("Unknown host: %s. Do you want to proceed with the connection anyway", s.host),
 			IsConfirm: true,
@@ -191,7 +222,7 @@ func (s *SSH) Connect() error {
 		if _, err := prompt.Run(); err != nil {
 			return err
 		}
-		s.client, err = goph.NewUnknown(s.username, s.host, auth)
+		s.client, err = s.dial(auth, gossh.InsecureIgnoreHostKey())
 	}
 	if err != nil {
 		return errors.Wrapf(err, "Failed to connect to %v", s)
@@ -202,9 +233,44 @@ func (s *SSH) Connect() error {
 		return err
 	}
 
+	// a single transport keeps the tunneled HTTP connections alive between the requests.
+	client := s.client
+	s.httpClient = &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(_ context.Context, network, addr string) (net.Conn, error) {
+				return client.Dial(network, addr)
+			},
+		},
+	}
+
 	return s.ensureEnvironment()
 }
 
+// dial opens the SSH connection verifying the host key with the callback.
+func (s *SSH) dial(auth goph.Auth, callback gossh.HostKeyCallback) (*goph.Client, error) {
+	port, err := strconv.ParseUint(s.port, 10, 16)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid ssh port %s", s.port)
+	}
+	return goph.NewConn(&goph.Config{
+		User:     s.username,
+		Addr:     s.host,
+		Port:     uint(port),
+		Auth:     auth,
+		Timeout:  goph.DefaultTimeout,
+		Callback: callback,
+	})
+}
+
+// hostKeyCallback returns the host key callback from the known_hosts file,
+// the default $HOME/.ssh/known_hosts file is used if no file is set.
+func (s *SSH) hostKeyCallback() (gossh.HostKeyCallback, error) {
+	if s.knownHosts != "" {
+		return goph.KnownHosts(s.knownHosts)
+	}
+	return goph.DefaultKnownHosts()
+}
+
 // Workspace returns the workspace directory for the SSH session.
 func (s *SSH) Workspace() string {
 	return filepath.Join(workdir, s.workspace)
@@ -225,6 +291,11 @@ func (s *SSH) Genesis() string {
 	return filepath.Join(s.Home(), "config", "genesis.json")
 }
 
+// Config returns the path to the config.toml file within the home directory.
+func (s *SSH) Config() string {
+	return filepath.Join(s.Home(), "config", "config.toml")
+}
+
 // Log returns the log directory within the workspace.
 func (s *SSH) Log() string {
 	return filepath.Join(s.Workspace(), "log")
@@ -280,13 +351,13 @@ func (s *SSH) ensureEnvironment() error {
 }
 
 // ensureLocalBin uploads the specified binary to the remote server's bin directory.
-func (s *SSH) ensureLocalBin(name string, progressCallback ProgressCallback) error {
+func (s *SSH) ensureLocalBin(ctx context.Context, name string, progressCallback ProgressCallback) error {
 	// find ignite binary path
 	path, err := exec.LookPath(name)
 	if err != nil {
 		return err
 	}
-	_, err = s.UploadBinary(path, progressCallback)
+	_, err = s.UploadBinary(ctx, path, progressCallback)
 	if err != nil {
 		return err
 	}
@@ -313,6 +384,15 @@ func (s *SSH) RunCommand(ctx context.Context, name string, args ...string) (stri
 	return output, nil
 }
 
+// tunnelGet sends an HTTP GET request to a local port of the remote server through the SSH connection.
+func (s *SSH) tunnelGet(ctx context.Context, port, path string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("http://127.0.0.1:%s%s", port, path), nil)
+	if err != nil {
+		return nil, err
+	}
+	return s.httpClient.Do(req)
+}
+
 // Start runs the "start" script on the remote server.
 func (s *SSH) Start(ctx context.Context) (string, error) {
 	return s.runScript(ctx, "start")
@@ -335,7 +415,13 @@ func (s *SSH) Status(ctx context.Context) (string, error) {
 
 // runScript runs the specified script with arguments on the remote server.
 func (s *SSH) runScript(ctx context.Context, args ...string) (string, error) {
-	return s.RunCommand(ctx, s.RunnerScript(), args...)
+	return s.RunCommand(ctx, shellQuote(s.RunnerScript()), args...)
+}
+
+// shellQuote quotes the value as a single shell word, so it can be safely used into the
+// remote commands whatever its characters.
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
 }
 
 // HasGenesis checks if the genesis file exists on the remote server.
@@ -364,9 +450,9 @@ func (s *SSH) FileExist(ctx context.Context, path string) bool {
 // If isFile is true, it checks for a file, otherwise it checks for a directory.
 // It returns true if the specified file or directory exists, otherwise false.
 func (s *SSH) exist(ctx context.Context, path string, isFile bool) bool {
-	cmd := fmt.Sprintf("[ -d '%s' ] && echo 'true'", path)
+	cmd := fmt.Sprintf("[ -d %s ] && echo 'true'", shellQuote(path))
 	if isFile {
-		cmd = fmt.Sprintf("[ -f '%s' ] && echo 'true'", path)
+		cmd = fmt.Sprintf("[ -f %s ] && echo 'true'", shellQuote(path))
 	}
 	exist, err := s.RunCommand(ctx, cmd)
 	if err != nil {
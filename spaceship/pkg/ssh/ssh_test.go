@@ -0,0 +1,264 @@
+package ssh
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ignite/cli/v28/ignite/pkg/errors"
+	"github.com/ignite/cli/v28/ignite/pkg/gocmd"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ignite/apps/spaceship/pkg/sshtest"
+	"github.com/ignite/apps/spaceship/templates/script"
+)
+
+// fakeBinary is a chain binary replacement that logs the start arguments and keeps running.
+const fakeBinary = `#!/bin/sh
+echo "starting node $@"
+exec sleep 60
+`
+
+func noProgress(int64, int64) error { return nil }
+
+// newTestServer starts a test SSH server closed at the end of the test.
+func newTestServer(t *testing.T) *sshtest.Server {
+	t.Helper()
+	if runtime.GOOS != "linux" {
+		t.Skip("the ssh test server requires a linux shell")
+	}
+	srv, err := sshtest.NewServer()
+	require.NoError(t, err)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// connect connects to the test server using the key auth.
+func connect(t *testing.T, srv *sshtest.Server) *SSH {
+	t.Helper()
+	c, err := New(
+		srv.URI(),
+		WithKey(srv.KeyPath),
+		WithKnownHosts(srv.KnownHostsPath),
+		WithWorkspace("mars"),
+	)
+	require.NoError(t, err)
+	require.NoError(t, c.Connect())
+	t.Cleanup(func() { _ = c.Close() })
+	return c
+}
+
+func TestNew(t *testing.T) {
+	c, err := New("10.0.0.1", WithUser("validator"), WithPort("2222"))
+	require.NoError(t, err)
+	require.Equal(t, "validator", c.username)
+	require.Equal(t, "2222", c.port)
+
+	c, err = New("validator@10.0.0.1:2200", WithUser("admin"), WithPort("2222"))
+	require.NoError(t, err)
+	require.Equal(t, "validator", c.username)
+	require.Equal(t, "2200", c.port)
+
+	c, err = New("10.0.0.1")
+	require.NoError(t, err)
+	require.Equal(t, defaultUser, c.username)
+	require.Equal(t, defaultPort, c.port)
+
+	_, err = New("10.0.0.1", WithKey("id_rsa"), WithPassword("pass"))
+	require.EqualError(t, err, "ssh key and password are both set")
+}
+
+func TestConnect(t *testing.T) {
+	srv := newTestServer(t)
+
+	c, err := New(
+		srv.Host,
+		WithUser(srv.User),
+		WithPort(srv.Port),
+		WithPassword(srv.Password),
+		WithKnownHosts(srv.KnownHostsPath),
+		WithWorkspace("mars"),
+	)
+	require.NoError(t, err)
+	require.NoError(t, c.Connect())
+	defer c.Close()
+
+	for _, dir := range []string{c.Bin(), c.Home(), c.Log()} {
+		require.DirExists(t, filepath.Join(srv.Root, dir))
+	}
+
+	c, err = New(srv.URI(), WithPassword("wrong"), WithKnownHosts(srv.KnownHostsPath))
+	require.NoError(t, err)
+	require.Error(t, c.Connect())
+}
+
+func TestUpload(t *testing.T) {
+	var (
+		ctx = context.Background()
+		srv = newTestServer(t)
+		c   = connect(t, srv)
+		src = t.TempDir()
+	)
+	require.NoError(t, os.MkdirAll(filepath.Join(src, "config"), 0o755))
+	require.NoError(t, os.MkdirAll(filepath.Join(src, ".cache"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "config", "genesis.json"), []byte(`{"chain_id":"mars"}`), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "config", "config.toml"), []byte("moniker = \"mars\"\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(src, ".cache", "ignored"), []byte("ignored"), 0o644))
+
+	files, err := c.UploadHome(ctx, src, noProgress)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{c.Genesis(), c.Config()}, files)
+	require.True(t, c.HasGenesis(ctx))
+	require.NoFileExists(t, filepath.Join(srv.Root, c.Home(), ".cache", "ignored"))
+
+	genesis, err := os.ReadFile(filepath.Join(srv.Root, c.Genesis()))
+	require.NoError(t, err)
+	require.Equal(t, `{"chain_id":"mars"}`, string(genesis))
+
+	binary := filepath.Join(t.TempDir(), "marsd")
+	require.NoError(t, os.WriteFile(binary, []byte(fakeBinary), 0o644))
+	binPath, err := c.UploadBinary(ctx, binary, noProgress)
+	require.NoError(t, err)
+
+	info, err := os.Stat(filepath.Join(srv.Root, binPath))
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o755), info.Mode().Perm())
+}
+
+func TestBackupBinaryFailedUpload(t *testing.T) {
+	var (
+		ctx = context.Background()
+		srv = newTestServer(t)
+		c   = connect(t, srv)
+		dir = t.TempDir()
+	)
+
+	binary := filepath.Join(dir, "marsd")
+	require.NoError(t, os.WriteFile(binary, []byte(fakeBinary), 0o755))
+	binPath, err := c.UploadBinary(ctx, binary, noProgress)
+	require.NoError(t, err)
+
+	hasBackup, err := c.BackupBinary("marsd")
+	require.NoError(t, err)
+	require.True(t, hasBackup)
+
+	// Fail the upload of the new binary before it replaces the current one.
+	newBinary := filepath.Join(t.TempDir(), "marsd")
+	require.NoError(t, os.WriteFile(newBinary, []byte("#!/bin/sh\nexit 1\n"), 0o755))
+	_, err = c.UploadBinary(ctx, newBinary, func(int64, int64) error {
+		return errors.New("connection lost")
+	})
+	require.Error(t, err)
+
+	current, err := os.ReadFile(filepath.Join(srv.Root, binPath))
+	require.NoError(t, err)
+	require.Equal(t, fakeBinary, string(current))
+	info, err := os.Stat(filepath.Join(srv.Root, binPath+backupExtension))
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o755), info.Mode().Perm())
+
+	require.NoError(t, c.RestoreBinary("marsd"))
+	current, err = os.ReadFile(filepath.Join(srv.Root, binPath))
+	require.NoError(t, err)
+	require.Equal(t, fakeBinary, string(current))
+	require.NoFileExists(t, filepath.Join(srv.Root, binPath+backupExtension))
+
+	hasBackup, err = c.BackupBinary("missingd")
+	require.NoError(t, err)
+	require.False(t, hasBackup)
+}
+
+func TestDeploy(t *testing.T) {
+	var (
+		ctx = context.Background()
+		srv = newTestServer(t)
+		c   = connect(t, srv)
+		dir = t.TempDir()
+	)
+
+	target, err := c.Target(ctx)
+	require.NoError(t, err)
+	require.Equal(t, gocmd.BuildTarget(runtime.GOOS, runtime.GOARCH), target)
+
+	binary := filepath.Join(dir, "marsd")
+	require.NoError(t, os.WriteFile(binary, []byte(fakeBinary), 0o755))
+	binPath, err := c.UploadBinary(ctx, binary, noProgress)
+	require.NoError(t, err)
+
+	runScript, err := script.NewRunScript(c.Workspace(), c.Log(), c.Home(), binPath, dir)
+	require.NoError(t, err)
+	_, err = c.UploadRunnerScript(ctx, runScript, noProgress)
+	require.NoError(t, err)
+	require.True(t, c.HasRunnerScript(ctx))
+
+	status, err := c.Status(ctx)
+	require.NoError(t, err)
+	require.Contains(t, status, "is not running")
+
+	_, err = c.Start(ctx)
+	require.NoError(t, err)
+	defer c.Stop(ctx) //nolint:errcheck
+
+	status, err = c.Status(ctx)
+	require.NoError(t, err)
+	require.Contains(t, status, "is running with PID")
+
+	require.Eventually(t, func() bool {
+		log, err := c.LatestLog(10)
+		return err == nil && log == fmt.Sprintf("starting node start --home %s", filepath.Join(srv.Root, c.Home()))
+	}, 5*time.Second, 50*time.Millisecond)
+
+	_, err = c.Stop(ctx)
+	require.NoError(t, err)
+
+	status, err = c.Status(ctx)
+	require.NoError(t, err)
+	require.Contains(t, status, "is not running")
+}
+
+func TestNodeStatus(t *testing.T) {
+	var (
+		ctx = context.Background()
+		srv = newTestServer(t)
+		c   = connect(t, srv)
+	)
+
+	var conns atomic.Int32
+	node := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/status", r.URL.Path)
+		fmt.Fprint(w, `{"result":{"sync_info":{"latest_block_height":"42","catching_up":false}}}`)
+	}))
+	node.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			conns.Add(1)
+		}
+	}
+	node.Start()
+	defer node.Close()
+
+	_, port, err := net.SplitHostPort(node.Listener.Addr().String())
+	require.NoError(t, err)
+
+	status, err := c.NodeStatus(ctx, port)
+	require.NoError(t, err)
+	require.Equal(t, NodeStatus{LatestBlockHeight: 42}, status)
+
+	status, err = c.WaitHealthy(ctx, port, time.Second)
+	require.NoError(t, err)
+	require.Equal(t, int64(42), status.LatestBlockHeight)
+
+	// the tunneled connection is kept alive and reused by the next requests.
+	for i := 0; i < 5; i++ {
+		_, err := c.NodeStatus(ctx, port)
+		require.NoError(t, err)
+	}
+	require.EqualValues(t, 1, conns.Load())
+}
@@ -2,15 +2,36 @@ package ssh
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"github.com/ignite/cli/v28/ignite/pkg/errors"
 	"golang.org/x/sync/errgroup"
 )
 
+const (
+	// backupExtension is the extension of the binary backup files.
+	backupExtension = ".bak"
+
+	// partialExtension is the extension of the files being uploaded.
+	partialExtension = ".part"
+
+	// uploadChunkSize is the size of the chunks written to the remote files.
+	uploadChunkSize = 1 << 20
+
+	// uploadParallel is the number of files uploaded at the same time.
+	uploadParallel = 5
+)
+
+// ErrChecksumMismatch indicates that the uploaded file checksum does not match the local file.
+var ErrChecksumMismatch = errors.New("checksum mismatch")
+
 // ProgressCallback is a type for the callback function to update the progress.
 type ProgressCallback func(uploaded int64, total int64) error
 
@@ -28,77 +49,93 @@ func (pw *progressWriter) Write(p []byte) (int, error) {
 }
 
 // Upload uploads a directory recursively to the remote server with a progress callback.
+// Hidden files and folders are skipped and files already uploaded with the same checksum
+// are not uploaded again.
 func (s *SSH) Upload(ctx context.Context, srcPath, dstPath string, progressCallback ProgressCallback) ([]string, error) {
+	type uploadFile struct {
+		path string
+		dst  string
+		size int64
+	}
+
 	var (
-		totalFiles    int64
-		totalBytes    int64
-		uploadedBytes int64
+		files      = make([]uploadFile, 0)
+		totalBytes int64
 	)
-
-	// Count the total number of files and total bytes to be uploaded
 	err := filepath.Walk(srcPath, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
-		if !info.IsDir() {
-			rel, err := filepath.Rel(srcPath, path)
-			if err != nil {
-				return err
-			}
-			if !strings.HasPrefix(rel, ".") {
-				totalFiles++
-				totalBytes += info.Size()
-			}
+		if info.IsDir() {
+			return nil
 		}
+		rel, err := filepath.Rel(srcPath, path)
+		if err != nil {
+			return err
+		}
+		// skip hidden files and folders.
+		if strings.HasPrefix(rel, ".") {
+			return nil
+		}
+		files = append(files, uploadFile{
+			path: path,
+			dst:  filepath.Join(dstPath, rel),
+			size: info.Size(),
+		})
+		totalBytes += info.Size()
 		return nil
 	})
 	if err != nil {
 		return nil, err
 	}
 
+	var (
+		mu            sync.Mutex
+		uploadedBytes int64
+		uploadedFiles = make([]string, len(files))
+	)
 	grp, ctx := errgroup.WithContext(ctx)
-	grp.SetLimit(5)
-
-	uploadedFiles := make([]string, 0)
-	err = filepath.Walk(srcPath, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
-
-		if !info.IsDir() {
-			rel, err := filepath.Rel(srcPath, path)
+	grp.SetLimit(uploadParallel)
+	for i, file := range files {
+		grp.Go(func() error {
+			var fileUploaded int64
+			dst, err := s.UploadFile(ctx, file.path, file.dst, func(bytesUploaded int64, _ int64) error {
+				mu.Lock()
+				defer mu.Unlock()
+				uploadedBytes += bytesUploaded - fileUploaded
+				fileUploaded = bytesUploaded
+				// Call the progress callback with the total uploaded bytes
+				return progressCallback(uploadedBytes, totalBytes)
+			})
 			if err != nil {
 				return err
 			}
-			// skip hidden files and folders.
-			if strings.HasPrefix(rel, ".") {
-				return nil
-			}
-			newPath := filepath.Join(dstPath, rel)
-
-			grp.Go(func() error {
-				file, err := s.UploadFile(path, newPath, func(bytesUploaded int64, fileTotalBytes int64) error {
-					uploadedBytes += bytesUploaded
-					// Call the progress callback with the total uploaded bytes
-					return progressCallback(uploadedBytes, totalBytes)
-				})
-				if err != nil {
-					return err
-				}
-				uploadedFiles = append(uploadedFiles, file)
-				return nil
-			})
-		}
-		return nil
-	})
-	if err != nil {
+			uploadedFiles[i] = dst
+			return nil
+		})
+	}
+	if err := grp.Wait(); err != nil {
 		return nil, err
 	}
-	return uploadedFiles, grp.Wait()
+	return uploadedFiles, nil
 }
 
 // UploadFile uploads a single file to the remote server with progress tracking.
-func (s *SSH) UploadFile(filePath, dstPath string, progressCallback ProgressCallback) (string, error) {
+// The file is uploaded in chunks to a partial file, resuming from the partial file size if a
+// previous upload was interrupted. The SHA-256 checksum is verified before the partial file is
+// atomically renamed to the destination path. If the destination already has the same checksum,
+// the upload is skipped.
+func (s *SSH) UploadFile(ctx context.Context, filePath, dstPath string, progressCallback ProgressCallback) (string, error) {
+	return s.uploadFile(ctx, filePath, dstPath, 0, progressCallback)
+}
+
+// uploadFile uploads a single file and sets the file mode, if not zero, before moving it into place.
+func (s *SSH) uploadFile(
+	ctx context.Context,
+	filePath, dstPath string,
+	mode os.FileMode,
+	progressCallback ProgressCallback,
+) (string, error) {
 	dstDir := filepath.Dir(dstPath)
 	if err := s.sftpClient.MkdirAll(dstDir); err != nil {
 		return "", errors.Wrapf(err, "failed to create destination path %s", dstDir)
@@ -120,55 +157,210 @@ func (s *SSH) UploadFile(filePath, dstPath string, progressCallback ProgressCall
 		return "", errors.Wrapf(err, "failed to get file info for %s", srcPath)
 	}
 	totalBytes := fileInfo.Size()
-	srcReader := io.TeeReader(srcFile, &progressWriter{
-		totalBytes:       totalBytes,
-		progressCallback: progressCallback,
-	})
 
-	dstFile, err := s.sftpClient.Create(dstPath)
+	checksum, err := fileChecksum(srcFile)
 	if err != nil {
-		return "", errors.Wrapf(err, "failed to create destination file %s", dstPath)
+		return "", errors.Wrapf(err, "failed to compute the checksum of %s", srcPath)
+	}
+
+	// Skip the upload if the file is already in place.
+	if info, err := s.sftpClient.Stat(dstPath); err == nil && info.Size() == totalBytes {
+		if remote, err := s.Checksum(ctx, dstPath); err == nil && remote == checksum {
+			if mode != 0 {
+				if err := s.sftpClient.Chmod(dstPath, mode); err != nil {
+					return "", err
+				}
+			}
+			return dstPath, progressCallback(totalBytes, totalBytes)
+		}
+	}
+
+	partPath := dstPath + partialExtension
+	resumed, err := s.uploadPartial(srcFile, partPath, totalBytes, progressCallback)
+	if err != nil {
+		return "", err
 	}
-	defer dstFile.Close()
 
-	_, err = io.Copy(dstFile, srcReader)
+	remote, err := s.Checksum(ctx, partPath)
 	if err != nil {
-		return "", errors.Wrapf(err, "failed to upload file %s to %s", srcPath, dstPath)
+		return "", err
+	}
+	if remote != checksum && resumed {
+		// The partial file belongs to another version of the file, upload it again from zero.
+		if err := s.sftpClient.Remove(partPath); err != nil {
+			return "", errors.Wrapf(err, "failed to remove partial file %s", partPath)
+		}
+		if _, err := s.uploadPartial(srcFile, partPath, totalBytes, progressCallback); err != nil {
+			return "", err
+		}
+		if remote, err = s.Checksum(ctx, partPath); err != nil {
+			return "", err
+		}
+	}
+	if remote != checksum {
+		// Remove the partial file so the next upload starts from zero.
+		_ = s.sftpClient.Remove(partPath)
+		return "", errors.Wrapf(ErrChecksumMismatch, "%s: expected %s, got %s", dstPath, checksum, remote)
+	}
+
+	if mode != 0 {
+		if err := s.sftpClient.Chmod(partPath, mode); err != nil {
+			return "", err
+		}
+	}
+	if err := s.sftpClient.PosixRename(partPath, dstPath); err != nil {
+		return "", errors.Wrapf(err, "failed to move %s to %s", partPath, dstPath)
 	}
 	return dstPath, nil
 }
 
+// uploadPartial uploads the source file in chunks to the remote partial file, resuming from the
+// remote partial file size. The partial file is truncated if it is bigger than the source file.
+// It returns true if the upload was resumed from a previous partial file.
+func (s *SSH) uploadPartial(
+	srcFile *os.File,
+	partPath string,
+	totalBytes int64,
+	progressCallback ProgressCallback,
+) (bool, error) {
+	var offset int64
+	if info, err := s.sftpClient.Stat(partPath); err == nil && info.Size() <= totalBytes {
+		offset = info.Size()
+	}
+
+	dstFile, err := s.sftpClient.OpenFile(partPath, os.O_WRONLY|os.O_CREATE)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to create destination file %s", partPath)
+	}
+	defer dstFile.Close()
+
+	if err := dstFile.Truncate(offset); err != nil {
+		return false, errors.Wrapf(err, "failed to truncate destination file %s", partPath)
+	}
+	if _, err := dstFile.Seek(offset, io.SeekStart); err != nil {
+		return false, errors.Wrapf(err, "failed to seek destination file %s", partPath)
+	}
+	if _, err := srcFile.Seek(offset, io.SeekStart); err != nil {
+		return false, errors.Wrapf(err, "failed to seek source file %s", srcFile.Name())
+	}
+
+	progress := &progressWriter{
+		bytesUploaded:    offset,
+		totalBytes:       totalBytes,
+		progressCallback: progressCallback,
+	}
+	if err := progressCallback(offset, totalBytes); err != nil {
+		return false, err
+	}
+
+	chunk := make([]byte, uploadChunkSize)
+	for {
+		n, err := srcFile.Read(chunk)
+		if n > 0 {
+			if _, err := dstFile.Write(chunk[:n]); err != nil {
+				return false, errors.Wrapf(err, "failed to upload file %s to %s", srcFile.Name(), partPath)
+			}
+			if _, err := progress.Write(chunk[:n]); err != nil {
+				return false, err
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return false, errors.Wrapf(err, "failed to read source file %s", srcFile.Name())
+		}
+	}
+	if err := dstFile.Close(); err != nil {
+		return false, errors.Wrapf(err, "failed to close destination file %s", partPath)
+	}
+	return offset > 0, nil
+}
+
+// Checksum returns the hex encoded SHA-256 checksum of a remote file.
+func (s *SSH) Checksum(ctx context.Context, path string) (string, error) {
+	out, err := s.RunCommand(ctx, fmt.Sprintf("sha256sum %[1]s 2>/dev/null || shasum -a 256 %[1]s", shellQuote(path)))
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to compute the checksum of %s", path)
+	}
+	fields := strings.Fields(out)
+	if len(fields) == 0 {
+		return "", errors.Errorf("empty checksum output for %s", path)
+	}
+	return fields[0], nil
+}
+
+// fileChecksum returns the hex encoded SHA-256 checksum of a local file.
+func fileChecksum(file *os.File) (string, error) {
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	if _, err := io.Copy(h, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
 // UploadBinary uploads a binary file to the remote server's bin directory
-// and sets the appropriate permissions.
-func (s *SSH) UploadBinary(srcPath string, progressCallback ProgressCallback) (string, error) {
+// with the executable permission.
+func (s *SSH) UploadBinary(ctx context.Context, srcPath string, progressCallback ProgressCallback) (string, error) {
 	var (
 		filename = filepath.Base(srcPath)
 		binPath  = filepath.Join(s.Bin(), filename)
 	)
-	if _, err := s.UploadFile(srcPath, binPath, progressCallback); err != nil {
-		return "", err
+	return s.uploadFile(ctx, srcPath, binPath, 0o755, progressCallback)
+}
+
+// BackupBinary copies the current binary from the remote server's bin directory
+// to a backup file and returns false if there is no binary to backup. The binary
+// is copied, not moved, so the node keeps its binary if the next upload fails.
+func (s *SSH) BackupBinary(name string) (bool, error) {
+	binPath := filepath.Join(s.Bin(), name)
+	info, err := s.sftpClient.Stat(binPath)
+	if os.IsNotExist(err) {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Wrapf(err, "failed to stat binary %s", binPath)
 	}
 
-	// give binary permission
-	if err := s.sftpClient.Chmod(binPath, 0o755); err != nil {
-		return "", err
+	src, err := s.sftpClient.Open(binPath)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to open binary %s", binPath)
 	}
-	return binPath, nil
-}
+	defer src.Close()
 
-// UploadRunnerScript uploads a runner script to the remote server
-// and sets the appropriate permissions.
-func (s *SSH) UploadRunnerScript(srcPath string, progressCallback ProgressCallback) (string, error) {
-	path := s.RunnerScript()
-	if _, err := s.UploadFile(srcPath, s.RunnerScript(), progressCallback); err != nil {
-		return "", err
+	backupPath := binPath + backupExtension
+	dst, err := s.sftpClient.OpenFile(backupPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to create binary backup %s", backupPath)
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		_ = dst.Close()
+		return false, errors.Wrapf(err, "failed to backup binary %s", binPath)
 	}
+	if err := dst.Close(); err != nil {
+		return false, errors.Wrapf(err, "failed to backup binary %s", binPath)
+	}
+	if err := s.sftpClient.Chmod(backupPath, info.Mode().Perm()); err != nil {
+		return false, errors.Wrapf(err, "failed to backup binary %s", binPath)
+	}
+	return true, nil
+}
 
-	// give binary permission
-	if err := s.sftpClient.Chmod(path, 0o755); err != nil {
-		return "", err
+// RestoreBinary restores the binary backup into the remote server's bin directory.
+func (s *SSH) RestoreBinary(name string) error {
+	binPath := filepath.Join(s.Bin(), name)
+	if err := s.sftpClient.PosixRename(binPath+backupExtension, binPath); err != nil {
+		return errors.Wrapf(err, "failed to restore binary %s", binPath)
 	}
-	return path, nil
+	return nil
+}
+
+// UploadRunnerScript uploads a runner script to the remote server
+// with the executable permission.
+func (s *SSH) UploadRunnerScript(ctx context.Context, srcPath string, progressCallback ProgressCallback) (string, error) {
+	return s.uploadFile(ctx, srcPath, s.RunnerScript(), 0o755, progressCallback)
 }
 
 // UploadHome uploads the home directory to the remote server.
@@ -0,0 +1,130 @@
+package ssh
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUploadFileResume(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		srv     = newTestServer(t)
+		c       = connect(t, srv)
+		content = strings.Repeat("spaceship", uploadChunkSize/4)
+		src     = filepath.Join(t.TempDir(), "marsd")
+		dst     = filepath.Join(c.Bin(), "marsd")
+		part    = filepath.Join(srv.Root, dst+partialExtension)
+	)
+	require.NoError(t, os.WriteFile(src, []byte(content), 0o644))
+
+	tests := []struct {
+		name        string
+		partial     string
+		wantOffset  int64
+		wantUploads int
+	}{
+		{
+			name:        "new upload",
+			wantOffset:  0,
+			wantUploads: 1,
+		},
+		{
+			name:        "resume from the partial file",
+			partial:     content[:1000],
+			wantOffset:  1000,
+			wantUploads: 1,
+		},
+		{
+			name:        "partial file from another version",
+			partial:     strings.Repeat("x", 1000),
+			wantOffset:  1000,
+			wantUploads: 2,
+		},
+		{
+			name:        "partial file bigger than the source",
+			partial:     content + "extra",
+			wantOffset:  0,
+			wantUploads: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_ = os.Remove(filepath.Join(srv.Root, dst))
+			if tt.partial != "" {
+				require.NoError(t, os.WriteFile(part, []byte(tt.partial), 0o644))
+			}
+
+			offsets := make([]int64, 0)
+			progress := func(uploaded, total int64) error {
+				require.Equal(t, int64(len(content)), total)
+				if len(offsets) == 0 || uploaded < offsets[len(offsets)-1] {
+					offsets = append(offsets, uploaded)
+				}
+				return nil
+			}
+			got, err := c.UploadFile(ctx, src, dst, progress)
+			require.NoError(t, err)
+			require.Equal(t, dst, got)
+			require.Len(t, offsets, tt.wantUploads)
+			require.Equal(t, tt.wantOffset, offsets[0])
+
+			uploaded, err := os.ReadFile(filepath.Join(srv.Root, dst))
+			require.NoError(t, err)
+			require.Equal(t, content, string(uploaded))
+			require.NoFileExists(t, part)
+		})
+	}
+}
+
+func TestUploadFileSkip(t *testing.T) {
+	var (
+		ctx = context.Background()
+		srv = newTestServer(t)
+		c   = connect(t, srv)
+		src = filepath.Join(t.TempDir(), "genesis.json")
+		dst = c.Genesis()
+	)
+	require.NoError(t, os.WriteFile(src, []byte(`{"chain_id":"mars"}`), 0o644))
+
+	_, err := c.UploadFile(ctx, src, dst, noProgress)
+	require.NoError(t, err)
+
+	sum := sha256.Sum256([]byte(`{"chain_id":"mars"}`))
+	checksum, err := c.Checksum(ctx, dst)
+	require.NoError(t, err)
+	require.Equal(t, hex.EncodeToString(sum[:]), checksum)
+
+	// the partial file is never created if the file is already in place.
+	part := filepath.Join(srv.Root, dst+partialExtension)
+	require.NoError(t, os.Mkdir(part, 0o755))
+	_, err = c.UploadFile(ctx, src, dst, noProgress)
+	require.NoError(t, err)
+}
+
+func TestChecksumQuoting(t *testing.T) {
+	var (
+		ctx  = context.Background()
+		srv  = newTestServer(t)
+		c    = connect(t, srv)
+		path = filepath.Join(c.Home(), "it's'; touch 'injected")
+	)
+	require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(srv.Root, path)), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(srv.Root, path), []byte("spaceship"), 0o644))
+
+	sum := sha256.Sum256([]byte("spaceship"))
+	checksum, err := c.Checksum(ctx, path)
+	require.NoError(t, err)
+	require.Equal(t, hex.EncodeToString(sum[:]), checksum)
+	require.True(t, c.FileExist(ctx, path))
+
+	// the path is never run as a command.
+	require.NoFileExists(t, filepath.Join(srv.Root, "injected"))
+	require.NoFileExists(t, filepath.Join(srv.Root, c.Home(), "injected"))
+}
@@ -0,0 +1,373 @@
+// Package sshtest provides an in-process SSH and SFTP server to test the ssh package
+// without a remote machine. Commands are executed by the local shell inside the server
+// root directory, which is also the SFTP working directory and the $HOME of the commands.
+package sshtest
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	"github.com/ignite/cli/v28/ignite/pkg/errors"
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+const (
+	// DefaultUser is the default user accepted by the server.
+	DefaultUser = "spaceship"
+
+	// DefaultPassword is the default password accepted by the server.
+	DefaultPassword = "spaceship"
+)
+
+// Server represents an in-process SSH server with the exec, SFTP and TCP forwarding support.
+type Server struct {
+	// Host and Port are the address the server listens on.
+	Host string
+	Port string
+
+	// User and Password are the credentials accepted by the password auth.
+	User     string
+	Password string
+
+	// Root is the server home directory.
+	Root string
+
+	// KeyPath is the path of a private key accepted by the public key auth.
+	KeyPath string
+
+	// KnownHostsPath is the path of a known_hosts file containing the server host key.
+	KnownHostsPath string
+
+	config    *ssh.ServerConfig
+	listener  net.Listener
+	tmpDirs   []string
+	mu        sync.Mutex
+	conns     map[net.Conn]struct{}
+	wg        sync.WaitGroup
+	closeOnce sync.Once
+}
+
+// Option configures the test server.
+type Option func(*Server)
+
+// WithUser sets the user accepted by the server.
+func WithUser(user string) Option {
+	return func(s *Server) {
+		s.User = user
+	}
+}
+
+// WithPassword sets the password accepted by the server.
+func WithPassword(password string) Option {
+	return func(s *Server) {
+		s.Password = password
+	}
+}
+
+// WithRoot sets the server home directory, a temporary directory is used by default.
+func WithRoot(root string) Option {
+	return func(s *Server) {
+		s.Root = root
+	}
+}
+
+// NewServer starts a new SSH server listening on a random local port.
+// The server must be closed with Close.
+func NewServer(options ...Option) (*Server, error) {
+	s := &Server{
+		User:     DefaultUser,
+		Password: DefaultPassword,
+		conns:    make(map[net.Conn]struct{}),
+	}
+	for _, apply := range options {
+		apply(s)
+	}
+	if err := s.init(); err != nil {
+		s.removeTmpDirs()
+		return nil, err
+	}
+
+	s.wg.Add(1)
+	go s.serve()
+	return s, nil
+}
+
+// Addr returns the server address.
+func (s *Server) Addr() string {
+	return net.JoinHostPort(s.Host, s.Port)
+}
+
+// URI returns the server URI with the user, without the password.
+func (s *Server) URI() string {
+	return fmt.Sprintf("%s@%s", s.User, s.Addr())
+}
+
+// Close stops the server, closes all the open connections and removes the temporary files.
+func (s *Server) Close() {
+	s.closeOnce.Do(func() {
+		_ = s.listener.Close()
+		s.mu.Lock()
+		for conn := range s.conns {
+			_ = conn.Close()
+		}
+		s.mu.Unlock()
+		s.wg.Wait()
+		s.removeTmpDirs()
+	})
+}
+
+// init creates the server directories and keys and starts listening.
+func (s *Server) init() error {
+	if s.Root == "" {
+		root, err := s.mkdirTemp("sshtest-root")
+		if err != nil {
+			return err
+		}
+		s.Root = root
+	}
+	keysDir, err := s.mkdirTemp("sshtest-keys")
+	if err != nil {
+		return err
+	}
+
+	_, hostKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return err
+	}
+	hostSigner, err := ssh.NewSignerFromKey(hostKey)
+	if err != nil {
+		return err
+	}
+
+	userPub, userKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return err
+	}
+	authorizedKey, err := ssh.NewPublicKey(userPub)
+	if err != nil {
+		return err
+	}
+	block, err := ssh.MarshalPrivateKey(userKey, "")
+	if err != nil {
+		return err
+	}
+	s.KeyPath = filepath.Join(keysDir, "id_ed25519")
+	if err := os.WriteFile(s.KeyPath, pem.EncodeToMemory(block), 0o600); err != nil {
+		return err
+	}
+
+	s.config = &ssh.ServerConfig{
+		PasswordCallback: func(c ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+			if c.User() == s.User && string(password) == s.Password {
+				return nil, nil
+			}
+			return nil, errors.Errorf("password rejected for %q", c.User())
+		},
+		PublicKeyCallback: func(c ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if c.User() == s.User && string(key.Marshal()) == string(authorizedKey.Marshal()) {
+				return nil, nil
+			}
+			return nil, errors.Errorf("public key rejected for %q", c.User())
+		},
+	}
+	s.config.AddHostKey(hostSigner)
+
+	s.listener, err = net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return errors.Wrap(err, "failed to listen for connections")
+	}
+	addr := s.listener.Addr().(*net.TCPAddr)
+	s.Host, s.Port = addr.IP.String(), strconv.Itoa(addr.Port)
+
+	s.KnownHostsPath = filepath.Join(keysDir, "known_hosts")
+	line := knownhosts.Line([]string{s.Addr()}, hostSigner.PublicKey())
+	if err := os.WriteFile(s.KnownHostsPath, []byte(line+"\n"), 0o600); err != nil {
+		_ = s.listener.Close()
+		return err
+	}
+	return nil
+}
+
+// mkdirTemp creates a temporary directory removed when the server is closed.
+func (s *Server) mkdirTemp(pattern string) (string, error) {
+	dir, err := os.MkdirTemp("", pattern)
+	if err != nil {
+		return "", err
+	}
+	s.tmpDirs = append(s.tmpDirs, dir)
+	return dir, nil
+}
+
+// removeTmpDirs removes the temporary directories created by the server.
+func (s *Server) removeTmpDirs() {
+	for _, dir := range s.tmpDirs {
+		_ = os.RemoveAll(dir)
+	}
+}
+
+// serve accepts the incoming connections until the listener is closed.
+func (s *Server) serve() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.conns[conn] = struct{}{}
+		s.mu.Unlock()
+
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.handleConn(conn)
+			s.mu.Lock()
+			delete(s.conns, conn)
+			s.mu.Unlock()
+		}()
+	}
+}
+
+// handleConn performs the SSH handshake and serves the connection channels.
+func (s *Server) handleConn(conn net.Conn) {
+	defer conn.Close()
+
+	serverConn, chans, reqs, err := ssh.NewServerConn(conn, s.config)
+	if err != nil {
+		return
+	}
+	defer serverConn.Close()
+	go ssh.DiscardRequests(reqs)
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	for newChannel := range chans {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			switch newChannel.ChannelType() {
+			case "session":
+				s.handleSession(newChannel)
+			case "direct-tcpip":
+				s.handleDirectTCPIP(newChannel)
+			default:
+				_ = newChannel.Reject(ssh.UnknownChannelType, "unknown channel type")
+			}
+		}()
+	}
+}
+
+// handleSession serves the exec and sftp subsystem requests of a session channel.
+func (s *Server) handleSession(newChannel ssh.NewChannel) {
+	channel, requests, err := newChannel.Accept()
+	if err != nil {
+		return
+	}
+	defer channel.Close()
+
+	for req := range requests {
+		switch req.Type {
+		case "exec":
+			var payload struct{ Command string }
+			if err := ssh.Unmarshal(req.Payload, &payload); err != nil {
+				_ = req.Reply(false, nil)
+				continue
+			}
+			_ = req.Reply(true, nil)
+
+			status := struct{ Status uint32 }{s.exec(channel, payload.Command)}
+			_, _ = channel.SendRequest("exit-status", false, ssh.Marshal(&status))
+			return
+		case "subsystem":
+			var payload struct{ Name string }
+			if err := ssh.Unmarshal(req.Payload, &payload); err != nil || payload.Name != "sftp" {
+				_ = req.Reply(false, nil)
+				continue
+			}
+			_ = req.Reply(true, nil)
+
+			server, err := sftp.NewServer(channel, sftp.WithServerWorkingDirectory(s.Root))
+			if err != nil {
+				return
+			}
+			_ = server.Serve()
+			_ = server.Close()
+			return
+		case "env":
+			_ = req.Reply(true, nil)
+		default:
+			_ = req.Reply(false, nil)
+		}
+	}
+}
+
+// exec runs the command with the local shell inside the server root and returns the exit status.
+func (s *Server) exec(channel ssh.Channel, command string) uint32 {
+	cmd := exec.Command("sh", "-c", command)
+	cmd.Dir = s.Root
+	cmd.Env = append(os.Environ(), "HOME="+s.Root, "USER="+s.User)
+	cmd.Stdout = channel
+	cmd.Stderr = channel.Stderr()
+
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		return 0
+	case errors.As(err, &exitErr) && exitErr.ExitCode() >= 0:
+		return uint32(exitErr.ExitCode())
+	default:
+		_, _ = fmt.Fprintln(channel.Stderr(), err)
+		return 255
+	}
+}
+
+// handleDirectTCPIP forwards a direct-tcpip channel to the requested local address.
+func (s *Server) handleDirectTCPIP(newChannel ssh.NewChannel) {
+	var payload struct {
+		Host       string
+		Port       uint32
+		OriginHost string
+		OriginPort uint32
+	}
+	if err := ssh.Unmarshal(newChannel.ExtraData(), &payload); err != nil {
+		_ = newChannel.Reject(ssh.ConnectionFailed, "invalid direct-tcpip payload")
+		return
+	}
+
+	target, err := net.Dial("tcp", net.JoinHostPort(payload.Host, strconv.Itoa(int(payload.Port))))
+	if err != nil {
+		_ = newChannel.Reject(ssh.ConnectionFailed, err.Error())
+		return
+	}
+	defer target.Close()
+
+	channel, requests, err := newChannel.Accept()
+	if err != nil {
+		return
+	}
+	defer channel.Close()
+	go ssh.DiscardRequests(requests)
+
+	done := make(chan struct{}, 2)
+	go func() {
+		_, _ = io.Copy(target, channel)
+		done <- struct{}{}
+	}()
+	go func() {
+		_, _ = io.Copy(channel, target)
+		done <- struct{}{}
+	}()
+	<-done
+}